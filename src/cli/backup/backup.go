@@ -1,7 +1,44 @@
 package backup
 
 import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alcionai/corso/src/cli/config"
+	"github.com/alcionai/corso/src/cli/options"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+const (
+	dryRunFN   = "dry-run"
+	failuresFN = "failures"
+	removeFN   = "remove"
+)
+
+var (
+	// when set, the create subcommands estimate the backup instead of
+	// running it.
+	dryRun bool
+	// when set, the details subcommands show the failed items instead of the
+	// backup details.
+	showFailures bool
+	// key=value tags used to filter the list subcommands, or set by the
+	// tag subcommand.
+	tags []string
 )
 
 var subCommandFuncs = []func() *cobra.Command{
@@ -9,6 +46,8 @@ var subCommandFuncs = []func() *cobra.Command{
 	listCmd,
 	detailsCmd,
 	deleteCmd,
+	diffCmd,
+	searchCmd,
 }
 
 var serviceCommands = []func(cmd *cobra.Command) *cobra.Command{
@@ -30,6 +69,9 @@ func AddCommands(cmd *cobra.Command) {
 			addBackupTo(subCommand)
 		}
 	}
+
+	addTagCommand(backupC)
+	addHoldCommands(backupC)
 }
 
 // The backup category of commands.
@@ -88,6 +130,29 @@ func handleListCmd(cmd *cobra.Command, args []string) error {
 	return cmd.Help()
 }
 
+// addTagFilterFlag adds the --tag flag to a list subcommand.
+func addTagFilterFlag(fs *pflag.FlagSet) {
+	fs.StringSliceVar(
+		&tags,
+		utils.TagFN, nil,
+		"Only list backups with this tag, formatted as key=value; repeat to require multiple tags.")
+}
+
+// tagFilters produces the filters matching backups with all of the tags.
+func tagFilters(kvs []string) ([]store.FilterOption, error) {
+	ts, err := utils.ParseTags(kvs)
+	if err != nil {
+		return nil, err
+	}
+
+	fs := make([]store.FilterOption, 0, len(ts))
+	for k, v := range ts {
+		fs = append(fs, store.Tag(k, v))
+	}
+
+	return fs, nil
+}
+
 // The backup details subcommand.
 // `corso backup details <service> [<flag>...]`
 var detailsCommand = "details"
@@ -107,6 +172,120 @@ func handleDetailsCmd(cmd *cobra.Command, args []string) error {
 	return cmd.Help()
 }
 
+// addDryRunFlag adds the --dry-run flag to a create subcommand.
+func addDryRunFlag(fs *pflag.FlagSet) {
+	fs.BoolVar(
+		&dryRun,
+		dryRunFN, false,
+		"Count the data that would be backed up, without backing it up.")
+}
+
+// runBackups backs up each of the selectors, which select a single resource
+// owner apiece, and prints the results for each resource owner.
+func runBackups(
+	ctx context.Context,
+	r repository.Repository,
+	service string,
+	sels []selectors.Selector,
+) error {
+	mbo := r.NewMultiBackup(ctx, sels)
+	err := mbo.Run(ctx)
+
+	backup.PrintOwnerResults(ctx, mbo.Results)
+
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to run %s backups", service))
+	}
+
+	return nil
+}
+
+// runDryRuns estimates the backup of each of the selectors, and prints the
+// estimates.
+func runDryRuns(
+	ctx context.Context,
+	r repository.Repository,
+	service string,
+	sels []selectors.Selector,
+) error {
+	var (
+		errs *multierror.Error
+		ests []backup.Estimate
+	)
+
+	for _, sel := range sels {
+		owners := ownersOf(sel)
+
+		bo, err := r.NewBackup(ctx, sel)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(
+				err,
+				"Failed to initialize %s backup for %s",
+				service, owners,
+			))
+
+			continue
+		}
+
+		es, err := bo.DryRun(ctx)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(
+				err,
+				"Failed to estimate %s backup for %s",
+				service, owners,
+			))
+
+			continue
+		}
+
+		ests = append(ests, es...)
+	}
+
+	backup.PrintEstimates(ctx, ests)
+
+	if e := errs.ErrorOrNil(); e != nil {
+		return Only(ctx, e)
+	}
+
+	return nil
+}
+
+// ownersOf lists the resource owners included in the selector.
+func ownersOf(sel selectors.Selector) string {
+	owners, err := sel.IncludedOwners()
+	if err != nil {
+		return ""
+	}
+
+	return strings.Join(owners, ",")
+}
+
+// addFailuresFlag adds the --failures flag to a details subcommand.
+func addFailuresFlag(fs *pflag.FlagSet) {
+	fs.BoolVar(
+		&showFailures,
+		failuresFN, false,
+		"Show the items that failed to back up, instead of the backup details.")
+}
+
+// runFailuresCmd looks up the items that failed in the backup.
+func runFailuresCmd(
+	ctx context.Context,
+	r repository.BackupGetter,
+	backupID string,
+) ([]details.Failure, error) {
+	b, err := r.Backup(ctx, model.StableID(backupID))
+	if err != nil {
+		if errors.Is(err, kopia.ErrNotFound) {
+			return nil, errors.Errorf("No backup exists with the id %s", backupID)
+		}
+
+		return nil, errors.Wrap(err, "Failed to find backup "+backupID)
+	}
+
+	return b.Failures, nil
+}
+
 // The backup delete subcommand.
 // `corso backup delete <service> [<flag>...]`
 var deleteCommand = "delete"
@@ -125,3 +304,164 @@ func deleteCmd() *cobra.Command {
 func handleDeleteCmd(cmd *cobra.Command, args []string) error {
 	return cmd.Help()
 }
+
+// The backup diff subcommand.
+// `corso backup diff <service> [<flag>...]`
+var diffCommand = "diff"
+
+const (
+	fromFN = "from"
+	toFN   = "to"
+
+	diffCommandUseSuffix = "--from <backupId> --to <backupId>"
+)
+
+// ids of the backups compared by the diff subcommands.
+var (
+	fromBackupID string
+	toBackupID   string
+)
+
+func diffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   diffCommand,
+		Short: "Shows the changes between two backups of a service",
+		RunE:  handleDiffCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso backup diff`.
+// Produces the same output as `corso backup diff --help`.
+func handleDiffCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// addDiffFlags adds the flags shared by the diff subcommands.
+func addDiffFlags(c *cobra.Command, fs *pflag.FlagSet) {
+	c.Use = c.Use + " " + diffCommandUseSuffix
+
+	fs.StringVar(&fromBackupID,
+		fromFN, "",
+		"ID of the earlier backup. (required)")
+	cobra.CheckErr(c.MarkFlagRequired(fromFN))
+	fs.StringVar(&toBackupID,
+		toFN, "",
+		"ID of the later backup. (required)")
+	cobra.CheckErr(c.MarkFlagRequired(toFN))
+}
+
+// diffServiceCmd prints the changes between the two backups of the service.
+func diffServiceCmd(cmd *cobra.Command, service path.ServiceType) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, err := repository.Connect(ctx, acct, s, options.Control())
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	diff, err := runDiffCmd(ctx, r, service, fromBackupID, toBackupID)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	diff.PrintEntries(ctx)
+
+	return nil
+}
+
+// backupDiffer compares the backups in a repository.
+type backupDiffer interface {
+	repository.BackupGetter
+	DiffBackups(ctx context.Context, fromID, toID string) (*details.Diff, error)
+}
+
+// runDiffCmd compares the two backups, after checking that they're backups
+// of the service.
+func runDiffCmd(
+	ctx context.Context,
+	r backupDiffer,
+	service path.ServiceType,
+	fromID, toID string,
+) (*details.Diff, error) {
+	for _, id := range []string{fromID, toID} {
+		b, err := r.Backup(ctx, model.StableID(id))
+		if err != nil {
+			if errors.Is(err, kopia.ErrNotFound) {
+				return nil, errors.Errorf("No backup exists with the id %s", id)
+			}
+
+			return nil, errors.Wrap(err, "Failed to find backup "+id)
+		}
+
+		if b.Selectors.PathService() != service {
+			return nil, errors.Errorf("Backup %s isn't a backup of %s data", id, service)
+		}
+	}
+
+	diff, err := r.DiffBackups(ctx, fromID, toID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to compare the backups")
+	}
+
+	return diff, nil
+}
+
+// The backup search subcommand.
+// `corso backup search <service> [<flag>...]`
+var searchCommand = "search"
+
+func searchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   searchCommand,
+		Short: "Search every backup of a service for items",
+		RunE:  handleSearchCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso backup search`.
+// Produces the same output as `corso backup search --help`.
+func handleSearchCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// searchServiceCmd prints the versions of the items matching the selector
+// in every backup of the selector's service.
+func searchServiceCmd(cmd *cobra.Command, sel selectors.Selector) error {
+	ctx := cmd.Context()
+
+	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, err := repository.Connect(ctx, acct, s, options.Control())
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	ms, err := r.SearchBackups(ctx, sel)
+
+	// some backups may be unreadable; still show the matches in the others.
+	backup.PrintItemMatches(ctx, ms)
+
+	if err != nil {
+		return Only(ctx, errors.Wrap(err, "Failed to search the backups"))
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package backup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/utils/testdata"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type BackupSuite struct {
+	suite.Suite
+}
+
+func TestBackupSuite(t *testing.T) {
+	suite.Run(t, new(BackupSuite))
+}
+
+type mockBackupDiffer struct {
+	*testdata.MockBackupGetter
+	sels map[model.StableID]selectors.Selector
+}
+
+func (bd mockBackupDiffer) Backup(_ context.Context, id model.StableID) (*backup.Backup, error) {
+	sel, ok := bd.sels[id]
+	if !ok {
+		return nil, kopia.ErrNotFound
+	}
+
+	return &backup.Backup{Selectors: sel}, nil
+}
+
+func (bd mockBackupDiffer) DiffBackups(context.Context, string, string) (*details.Diff, error) {
+	return &details.Diff{Entries: []details.DiffEntry{{Change: details.ChangeAdded}}}, nil
+}
+
+func (suite *BackupSuite) TestRunDiffCmd() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	bd := mockBackupDiffer{
+		sels: map[model.StableID]selectors.Selector{
+			"ex1": selectors.NewExchangeBackup().Selector,
+			"ex2": selectors.NewExchangeBackup().Selector,
+			"od1": selectors.NewOneDriveBackup().Selector,
+		},
+	}
+
+	table := []struct {
+		name      string
+		from, to  string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{"same service", "ex1", "ex2", assert.NoError},
+		{"other service", "ex1", "od1", assert.Error},
+		{"missing backup", "ex1", "ex3", assert.Error},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			diff, err := runDiffCmd(ctx, bd, path.ExchangeService, test.from, test.to)
+			test.expectErr(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Len(t, diff.Entries, 1)
+		})
+	}
+}
@@ -3,7 +3,6 @@ package backup
 import (
 	"context"
 
-	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -16,7 +15,6 @@ import (
 	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
-	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/repository"
 	"github.com/alcionai/corso/src/pkg/selectors"
@@ -34,9 +32,11 @@ var (
 	exchangeData []string
 	user         []string
 
-	contact       []string
-	contactFolder []string
-	contactName   string
+	contact               []string
+	contactFolder         []string
+	contactModifiedAfter  string
+	contactModifiedBefore string
+	contactName           string
 
 	email               []string
 	emailFolder         []string
@@ -75,11 +75,34 @@ corso backup create exchange --user alice@example.com
 corso backup create exchange --user alice@example.com,bob@example.com --data contacts
 
 # Backup all Exchange data for all M365 users 
-corso backup create exchange --user '*'`
+corso backup create exchange --user '*'
+
+# Backup all Exchange data for all M365 users, eight users at a time
+corso backup create exchange --user '*' --parallel 8
+
+# Backup only the emails Alice received and the events starting since the start of 2016
+corso backup create exchange --user alice@example.com --data email,events \
+      --email-received-after 2016-01-01 --event-starts-after 2016-01-01
+
+# Count the Exchange data that a backup for all M365 users would store
+corso backup create exchange --user '*' --dry-run`
 
 	exchangeServiceCommandDeleteExamples = `# Delete Exchange backup with ID 1234abcd-12ab-cd34-56de-1234abcd
 corso backup delete exchange --backup 1234abcd-12ab-cd34-56de-1234abcd`
 
+	exchangeServiceCommandDiffExamples = `# Show what changed in Exchange between two backups
+corso backup diff exchange --from 1234abcd-12ab-cd34-56de-1234abcd --to 5678abcd-12ab-cd34-56de-5678abcd
+
+# Show the changes as JSON
+corso backup diff exchange --from 1234abcd-12ab-cd34-56de-1234abcd --to 5678abcd-12ab-cd34-56de-5678abcd --json`
+
+	exchangeServiceCommandSearchExamples = `# Find every backed-up version of Alice's emails with "Budget" in the subject
+corso backup search exchange --user alice@example.com --email-subject Budget
+
+# Find the backups holding Bob's emails from Carol received after the start of 2022
+corso backup search exchange --user bob@example.com --email-sender carol@example.com \
+      --email-received-after 2022-01-01T00:00:00`
+
 	exchangeServiceCommandDetailsExamples = `# Explore Alice's items in backup 1234abcd-12ab-cd34-56de-1234abcd 
 corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --user alice@example.com
 
@@ -120,6 +143,32 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 			&exchangeData,
 			utils.DataFN, nil,
 			"Select one or more types of data to backup: "+dataEmail+", "+dataContacts+", or "+dataEvents)
+		fs.StringVar(
+			&emailReceivedAfter,
+			utils.EmailReceivedAfterFN, "",
+			"Backup only emails received after this datetime.")
+		fs.StringVar(
+			&emailReceivedBefore,
+			utils.EmailReceivedBeforeFN, "",
+			"Backup only emails received before this datetime.")
+		fs.StringVar(
+			&eventStartsAfter,
+			utils.EventStartsAfterFN, "",
+			"Backup only events starting after this datetime.")
+		fs.StringVar(
+			&eventStartsBefore,
+			utils.EventStartsBeforeFN, "",
+			"Backup only events starting before this datetime.")
+		fs.StringVar(
+			&contactModifiedAfter,
+			utils.ContactModifiedAfterFN, "",
+			"Backup only contacts last modified after this datetime.")
+		fs.StringVar(
+			&contactModifiedBefore,
+			utils.ContactModifiedBeforeFN, "",
+			"Backup only contacts last modified before this datetime.")
+		addDryRunFlag(fs)
+		options.AddBackupFlags(c)
 		options.AddOperationFlags(c)
 
 	case listCommand:
@@ -128,6 +177,7 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		fs.StringVar(&backupID,
 			"backup", "",
 			"ID of the backup to retrieve.")
+		addTagFilterFlag(fs)
 
 	case detailsCommand:
 		c, fs = utils.AddCommand(cmd, exchangeDetailsCmd())
@@ -141,81 +191,8 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 			utils.BackupFN, "",
 			"ID of the backup to explore. (required)")
 		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
-		fs.StringSliceVar(
-			&user,
-			utils.UserFN, nil,
-			"Select backup details by user ID; accepts '"+utils.Wildcard+"' to select all users.")
-
-		// email flags
-		fs.StringSliceVar(
-			&email,
-			utils.EmailFN, nil,
-			"Select backup details for emails by email ID; accepts '"+utils.Wildcard+"' to select all emails.")
-		fs.StringSliceVar(
-			&emailFolder,
-			utils.EmailFolderFN, nil,
-			"Select backup details for emails within a folder; accepts '"+utils.Wildcard+"' to select all email folders.")
-		fs.StringVar(
-			&emailSubject,
-			utils.EmailSubjectFN, "",
-			"Select backup details for emails with a subject containing this value.")
-		fs.StringVar(
-			&emailSender,
-			utils.EmailSenderFN, "",
-			"Select backup details for emails from a specific sender.")
-		fs.StringVar(
-			&emailReceivedAfter,
-			utils.EmailReceivedAfterFN, "",
-			"Select backup details for emails received after this datetime.")
-		fs.StringVar(
-			&emailReceivedBefore,
-			utils.EmailReceivedBeforeFN, "",
-			"Select backup details for emails received before this datetime.")
-
-		// event flags
-		fs.StringSliceVar(
-			&event,
-			utils.EventFN, nil,
-			"Select backup details for events by event ID; accepts '"+utils.Wildcard+"' to select all events.")
-		fs.StringSliceVar(
-			&eventCalendar,
-			utils.EventCalendarFN, nil,
-			"Select backup details for events under a calendar; accepts '"+utils.Wildcard+"' to select all events.")
-		fs.StringVar(
-			&eventSubject,
-			utils.EventSubjectFN, "",
-			"Select backup details for events with a subject containing this value.")
-		fs.StringVar(
-			&eventOrganizer,
-			utils.EventOrganizerFN, "",
-			"Select backup details for events from a specific organizer.")
-		fs.StringVar(
-			&eventRecurs,
-			utils.EventRecursFN, "",
-			"Select backup details for recurring events. Use `--event-recurs false` to select non-recurring events.")
-		fs.StringVar(
-			&eventStartsAfter,
-			utils.EventStartsAfterFN, "",
-			"Select backup details for events starting after this datetime.")
-		fs.StringVar(
-			&eventStartsBefore,
-			utils.EventStartsBeforeFN, "",
-			"Select backup details for events starting before this datetime.")
-
-		// contact flags
-		fs.StringSliceVar(
-			&contact,
-			utils.ContactFN, nil,
-			"Select backup details for contacts by contact ID; accepts '"+utils.Wildcard+"' to select all contacts.")
-		fs.StringSliceVar(
-			&contactFolder,
-			utils.ContactFolderFN, nil,
-			"Select backup details for contacts within a folder; accepts '"+utils.Wildcard+"' to select all contact folders.")
-
-		fs.StringVar(
-			&contactName,
-			utils.ContactNameFN, "",
-			"Select backup details for contacts whose contact name contains this value.")
+		addFailuresFlag(fs)
+		addExchangeDetailsFlags(fs)
 
 	case deleteCommand:
 		c, fs = utils.AddCommand(cmd, exchangeDeleteCmd())
@@ -227,6 +204,20 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 			utils.BackupFN, "",
 			"ID of the backup to delete. (required)")
 		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+
+	case diffCommand:
+		c, fs = utils.AddCommand(cmd, exchangeDiffCmd())
+
+		c.Example = exchangeServiceCommandDiffExamples
+
+		addDiffFlags(c, fs)
+
+	case searchCommand:
+		c, fs = utils.AddCommand(cmd, exchangeSearchCmd())
+
+		c.Example = exchangeServiceCommandSearchExamples
+
+		addExchangeDetailsFlags(fs)
 	}
 
 	return c
@@ -254,7 +245,22 @@ func createExchangeCmd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if err := validateExchangeBackupCreateFlags(user, exchangeData); err != nil {
+	opts := utils.ExchangeOpts{
+		ContactModifiedAfter:  contactModifiedAfter,
+		ContactModifiedBefore: contactModifiedBefore,
+		EmailReceivedAfter:    emailReceivedAfter,
+		EmailReceivedBefore:   emailReceivedBefore,
+		EventStartsAfter:      eventStartsAfter,
+		EventStartsBefore:     eventStartsBefore,
+
+		Populated: utils.GetPopulatedFlags(cmd),
+	}
+
+	if err := validateExchangeBackupCreateFlags(user, exchangeData, opts); err != nil {
+		return err
+	}
+
+	if err := options.ValidateBackupFlags(); err != nil {
 		return err
 	}
 
@@ -263,7 +269,7 @@ func createExchangeCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, err)
 	}
 
-	r, err := repository.Connect(ctx, acct, s, control.Options{})
+	r, err := repository.Connect(ctx, acct, s, options.Control())
 	if err != nil {
 		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
 	}
@@ -277,54 +283,23 @@ func createExchangeCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, errors.Wrap(err, "Failed to retrieve M365 users"))
 	}
 
-	var (
-		errs *multierror.Error
-		bIDs []model.StableID
-	)
+	sels := []selectors.Selector{}
 
 	for _, scope := range sel.DiscreteScopes(users) {
 		for _, selUser := range scope.Get(selectors.ExchangeUser) {
 			opSel := selectors.NewExchangeBackup()
 			opSel.Include([]selectors.ExchangeScope{scope.DiscreteCopy(selUser)})
+			filterExchangeBackupCreateSelectors(opSel, opts)
 
-			bo, err := r.NewBackup(ctx, opSel.Selector)
-			if err != nil {
-				errs = multierror.Append(errs, errors.Wrapf(
-					err,
-					"Failed to initialize Exchange backup for user %s",
-					scope.Get(selectors.ExchangeUser),
-				))
-
-				continue
-			}
-
-			err = bo.Run(ctx)
-			if err != nil {
-				errs = multierror.Append(errs, errors.Wrapf(
-					err,
-					"Failed to run Exchange backup for user %s",
-					scope.Get(selectors.ExchangeUser),
-				))
-
-				continue
-			}
-
-			bIDs = append(bIDs, bo.Results.BackupID)
+			sels = append(sels, opSel.Selector)
 		}
 	}
 
-	bups, err := r.Backups(ctx, bIDs)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Unable to retrieve backup results from storage"))
-	}
-
-	backup.PrintAll(ctx, bups)
-
-	if e := errs.ErrorOrNil(); e != nil {
-		return Only(ctx, e)
+	if dryRun {
+		return runDryRuns(ctx, r, "Exchange", sels)
 	}
 
-	return nil
+	return runBackups(ctx, r, "Exchange", sels)
 }
 
 func exchangeBackupCreateSelectors(userIDs, data []string) *selectors.ExchangeBackup {
@@ -350,7 +325,29 @@ func exchangeBackupCreateSelectors(userIDs, data []string) *selectors.ExchangeBa
 	return sel
 }
 
-func validateExchangeBackupCreateFlags(userIDs, data []string) error {
+// filterExchangeBackupCreateSelectors limits the backup to the items within
+// the time windows set by the create flags.
+func filterExchangeBackupCreateSelectors(sel *selectors.ExchangeBackup, opts utils.ExchangeOpts) {
+	fs := []struct {
+		v string
+		f func(string) []selectors.ExchangeScope
+	}{
+		{opts.EmailReceivedAfter, sel.MailReceivedAfter},
+		{opts.EmailReceivedBefore, sel.MailReceivedBefore},
+		{opts.EventStartsAfter, sel.EventStartsAfter},
+		{opts.EventStartsBefore, sel.EventStartsBefore},
+		{opts.ContactModifiedAfter, sel.ContactModifiedAfter},
+		{opts.ContactModifiedBefore, sel.ContactModifiedBefore},
+	}
+
+	for _, f := range fs {
+		if len(f.v) > 0 {
+			sel.Filter(f.f(f.v))
+		}
+	}
+}
+
+func validateExchangeBackupCreateFlags(userIDs, data []string, opts utils.ExchangeOpts) error {
 	if len(userIDs) == 0 {
 		return errors.New("--user requires one or more ids or the wildcard *")
 	}
@@ -362,6 +359,23 @@ func validateExchangeBackupCreateFlags(userIDs, data []string) error {
 		}
 	}
 
+	times := []struct {
+		fn, v string
+	}{
+		{utils.EmailReceivedAfterFN, opts.EmailReceivedAfter},
+		{utils.EmailReceivedBeforeFN, opts.EmailReceivedBefore},
+		{utils.EventStartsAfterFN, opts.EventStartsAfter},
+		{utils.EventStartsBeforeFN, opts.EventStartsBefore},
+		{utils.ContactModifiedAfterFN, opts.ContactModifiedAfter},
+		{utils.ContactModifiedBeforeFN, opts.ContactModifiedBefore},
+	}
+
+	for _, t := range times {
+		if _, ok := opts.Populated[t.fn]; ok && !utils.IsValidTimeFormat(t.v) {
+			return errors.New("invalid time format for " + t.fn)
+		}
+	}
+
 	return nil
 }
 
@@ -383,6 +397,11 @@ func exchangeListCmd() *cobra.Command {
 func listExchangeCmd(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	filters, err := tagFilters(tags)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
 	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
 	if err != nil {
 		return Only(ctx, err)
@@ -410,7 +429,7 @@ func listExchangeCmd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	bs, err := r.BackupsByTag(ctx, store.Service(path.ExchangeService))
+	bs, err := r.BackupsByTag(ctx, append(filters, store.Service(path.ExchangeService))...)
 	if err != nil {
 		return Only(ctx, errors.Wrap(err, "Failed to list backups in the repository"))
 	}
@@ -424,6 +443,86 @@ func listExchangeCmd(cmd *cobra.Command, args []string) error {
 // backup details
 // ------------------------------------------------------------------------------------------------
 
+// addExchangeDetailsFlags adds the flags that select the items in a backup to
+// the details and search subcommands.
+func addExchangeDetailsFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(
+		&user,
+		utils.UserFN, nil,
+		"Select backup details by user ID; accepts '"+utils.Wildcard+"' to select all users.")
+
+	// email flags
+	fs.StringSliceVar(
+		&email,
+		utils.EmailFN, nil,
+		"Select backup details for emails by email ID; accepts '"+utils.Wildcard+"' to select all emails.")
+	fs.StringSliceVar(
+		&emailFolder,
+		utils.EmailFolderFN, nil,
+		"Select backup details for emails within a folder; accepts '"+utils.Wildcard+"' to select all email folders.")
+	fs.StringVar(
+		&emailSubject,
+		utils.EmailSubjectFN, "",
+		"Select backup details for emails with a subject containing this value.")
+	fs.StringVar(
+		&emailSender,
+		utils.EmailSenderFN, "",
+		"Select backup details for emails from a specific sender.")
+	fs.StringVar(
+		&emailReceivedAfter,
+		utils.EmailReceivedAfterFN, "",
+		"Select backup details for emails received after this datetime.")
+	fs.StringVar(
+		&emailReceivedBefore,
+		utils.EmailReceivedBeforeFN, "",
+		"Select backup details for emails received before this datetime.")
+
+	// event flags
+	fs.StringSliceVar(
+		&event,
+		utils.EventFN, nil,
+		"Select backup details for events by event ID; accepts '"+utils.Wildcard+"' to select all events.")
+	fs.StringSliceVar(
+		&eventCalendar,
+		utils.EventCalendarFN, nil,
+		"Select backup details for events under a calendar; accepts '"+utils.Wildcard+"' to select all events.")
+	fs.StringVar(
+		&eventSubject,
+		utils.EventSubjectFN, "",
+		"Select backup details for events with a subject containing this value.")
+	fs.StringVar(
+		&eventOrganizer,
+		utils.EventOrganizerFN, "",
+		"Select backup details for events from a specific organizer.")
+	fs.StringVar(
+		&eventRecurs,
+		utils.EventRecursFN, "",
+		"Select backup details for recurring events. Use `--event-recurs false` to select non-recurring events.")
+	fs.StringVar(
+		&eventStartsAfter,
+		utils.EventStartsAfterFN, "",
+		"Select backup details for events starting after this datetime.")
+	fs.StringVar(
+		&eventStartsBefore,
+		utils.EventStartsBeforeFN, "",
+		"Select backup details for events starting before this datetime.")
+
+	// contact flags
+	fs.StringSliceVar(
+		&contact,
+		utils.ContactFN, nil,
+		"Select backup details for contacts by contact ID; accepts '"+utils.Wildcard+"' to select all contacts.")
+	fs.StringSliceVar(
+		&contactFolder,
+		utils.ContactFolderFN, nil,
+		"Select backup details for contacts within a folder; accepts '"+utils.Wildcard+"' to select all contact folders.")
+
+	fs.StringVar(
+		&contactName,
+		utils.ContactNameFN, "",
+		"Select backup details for contacts whose contact name contains this value.")
+}
+
 // `corso backup details exchange [<flag>...]`
 func exchangeDetailsCmd() *cobra.Command {
 	return &cobra.Command{
@@ -441,27 +540,7 @@ func detailsExchangeCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := cmd.Context()
-	opts := utils.ExchangeOpts{
-		Contact:             contact,
-		ContactFolder:       contactFolder,
-		Email:               email,
-		EmailFolder:         emailFolder,
-		Event:               event,
-		EventCalendar:       eventCalendar,
-		Users:               user,
-		ContactName:         contactName,
-		EmailReceivedAfter:  emailReceivedAfter,
-		EmailReceivedBefore: emailReceivedBefore,
-		EmailSender:         emailSender,
-		EmailSubject:        emailSubject,
-		EventOrganizer:      eventOrganizer,
-		EventRecurs:         eventRecurs,
-		EventStartsAfter:    eventStartsAfter,
-		EventStartsBefore:   eventStartsBefore,
-		EventSubject:        eventSubject,
-
-		Populated: utils.GetPopulatedFlags(cmd),
-	}
+	opts := exchangeDetailsOpts(cmd)
 
 	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
 	if err != nil {
@@ -475,6 +554,17 @@ func detailsExchangeCmd(cmd *cobra.Command, args []string) error {
 
 	defer utils.CloseRepo(ctx, r)
 
+	if showFailures {
+		fs, err := runFailuresCmd(ctx, r, backupID)
+		if err != nil {
+			return Only(ctx, err)
+		}
+
+		details.PrintFailures(ctx, fs)
+
+		return nil
+	}
+
 	ds, err := runDetailsExchangeCmd(ctx, r, backupID, opts)
 	if err != nil {
 		return Only(ctx, err)
@@ -501,7 +591,9 @@ func runDetailsExchangeCmd(
 		return nil, err
 	}
 
-	d, _, err := r.BackupDetails(ctx, backupID)
+	sel := exchangeDetailsSelector(opts)
+
+	d, _, err := r.SelectedBackupDetails(ctx, backupID, sel.Selector)
 	if err != nil {
 		if errors.Is(err, kopia.ErrNotFound) {
 			return nil, errors.Errorf("No backup exists with the id %s", backupID)
@@ -510,6 +602,36 @@ func runDetailsExchangeCmd(
 		return nil, errors.Wrap(err, "Failed to get backup details in the repository")
 	}
 
+	return sel.Reduce(ctx, d), nil
+}
+
+// exchangeDetailsOpts collects the flags that select the items in a backup.
+func exchangeDetailsOpts(cmd *cobra.Command) utils.ExchangeOpts {
+	return utils.ExchangeOpts{
+		Contact:             contact,
+		ContactFolder:       contactFolder,
+		Email:               email,
+		EmailFolder:         emailFolder,
+		Event:               event,
+		EventCalendar:       eventCalendar,
+		Users:               user,
+		ContactName:         contactName,
+		EmailReceivedAfter:  emailReceivedAfter,
+		EmailReceivedBefore: emailReceivedBefore,
+		EmailSender:         emailSender,
+		EmailSubject:        emailSubject,
+		EventOrganizer:      eventOrganizer,
+		EventRecurs:         eventRecurs,
+		EventStartsAfter:    eventStartsAfter,
+		EventStartsBefore:   eventStartsBefore,
+		EventSubject:        eventSubject,
+
+		Populated: utils.GetPopulatedFlags(cmd),
+	}
+}
+
+// exchangeDetailsSelector produces the restore selector matching the opts.
+func exchangeDetailsSelector(opts utils.ExchangeOpts) *selectors.ExchangeRestore {
 	sel := selectors.NewExchangeRestore()
 	utils.IncludeExchangeRestoreDataSelectors(sel, opts)
 	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
@@ -519,7 +641,7 @@ func runDetailsExchangeCmd(
 		sel.Include(sel.Users(selectors.Any()))
 	}
 
-	return sel.Reduce(ctx, d), nil
+	return sel
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -557,10 +679,59 @@ func deleteExchangeCmd(cmd *cobra.Command, args []string) error {
 	defer utils.CloseRepo(ctx, r)
 
 	if err := r.DeleteBackup(ctx, model.StableID(backupID)); err != nil {
-		return Only(ctx, errors.Wrapf(err, "Deleting backup %s", backupID))
+		return Only(ctx, deleteErr(err, backupID))
 	}
 
 	Info(ctx, "Deleted Exchange backup ", backupID)
 
 	return nil
 }
+
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff exchange [<flag>...]`
+func exchangeDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     exchangeServiceCommand,
+		Short:   "Show the changes between two M365 Exchange service backups",
+		RunE:    diffExchangeCmd,
+		Args:    cobra.NoArgs,
+		Example: exchangeServiceCommandDiffExamples,
+	}
+}
+
+// prints the changes between two exchange service backups.
+func diffExchangeCmd(cmd *cobra.Command, args []string) error {
+	return diffServiceCmd(cmd, path.ExchangeService)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup search
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup search exchange [<flag>...]`
+func exchangeSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     exchangeServiceCommand,
+		Short:   "Search every M365 Exchange service backup for items",
+		RunE:    searchExchangeCmd,
+		Args:    cobra.NoArgs,
+		Example: exchangeServiceCommandSearchExamples,
+	}
+}
+
+// lists the versions of the matching items in every exchange service backup.
+func searchExchangeCmd(cmd *cobra.Command, args []string) error {
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := exchangeDetailsOpts(cmd)
+	if err := utils.ValidateExchangeFilterFlags(opts); err != nil {
+		return Only(cmd.Context(), err)
+	}
+
+	return searchServiceCmd(cmd, exchangeDetailsSelector(opts).Selector)
+}
@@ -93,7 +93,7 @@ func (suite *NoBackupExchangeIntegrationSuite) SetupSuite() {
 	suite.m365UserID = tester.M365UserID(t)
 
 	// init the repo first
-	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{})
+	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 }
 
@@ -181,7 +181,7 @@ func (suite *BackupExchangeIntegrationSuite) SetupSuite() {
 	suite.m365UserID = tester.M365UserID(t)
 
 	// init the repo first
-	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{})
+	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 }
 
@@ -278,7 +278,7 @@ func (suite *PreparedBackupExchangeIntegrationSuite) SetupSuite() {
 	suite.m365UserID = tester.M365UserID(t)
 
 	// init the repo first
-	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{})
+	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 
 	suite.backupOps = make(map[path.CategoryType]string)
@@ -511,7 +511,7 @@ func (suite *BackupDeleteExchangeIntegrationSuite) SetupSuite() {
 	defer flush()
 
 	// init the repo first
-	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{})
+	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 
 	m365UserID := tester.M365UserID(t)
@@ -11,6 +11,9 @@ import (
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/cli/utils/testdata"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
 type ExchangeSuite struct {
@@ -47,6 +50,14 @@ func (suite *ExchangeSuite) TestAddExchangeCommands() {
 			"delete exchange", deleteCommand, expectUse + " " + exchangeServiceCommandDeleteUseSuffix,
 			exchangeDeleteCmd().Short, deleteExchangeCmd,
 		},
+		{
+			"diff exchange", diffCommand, expectUse + " " + diffCommandUseSuffix,
+			exchangeDiffCmd().Short, diffExchangeCmd,
+		},
+		{
+			"search exchange", searchCommand, expectUse,
+			exchangeSearchCmd().Short, searchExchangeCmd,
+		},
 	}
 	for _, test := range table {
 		suite.T().Run(test.name, func(t *testing.T) {
@@ -70,6 +81,7 @@ func (suite *ExchangeSuite) TestValidateBackupCreateFlags() {
 	table := []struct {
 		name       string
 		user, data []string
+		opts       utils.ExchangeOpts
 		expect     assert.ErrorAssertionFunc
 	}{
 		{
@@ -92,14 +104,53 @@ func (suite *ExchangeSuite) TestValidateBackupCreateFlags() {
 			user:   []string{"fnord"},
 			expect: assert.NoError,
 		},
+		{
+			name: "valid time window",
+			user: []string{"fnord"},
+			opts: utils.ExchangeOpts{
+				EmailReceivedAfter:    "2016-01-01T00:00:00Z",
+				ContactModifiedBefore: "2022-01-01",
+				Populated: utils.PopulatedFlags{
+					utils.EmailReceivedAfterFN:    {},
+					utils.ContactModifiedBeforeFN: {},
+				},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name: "invalid time window",
+			user: []string{"fnord"},
+			opts: utils.ExchangeOpts{
+				EventStartsBefore: "next tuesday",
+				Populated: utils.PopulatedFlags{
+					utils.EventStartsBeforeFN: {},
+				},
+			},
+			expect: assert.Error,
+		},
 	}
 	for _, test := range table {
 		suite.T().Run(test.name, func(t *testing.T) {
-			test.expect(t, validateExchangeBackupCreateFlags(test.user, test.data))
+			test.expect(t, validateExchangeBackupCreateFlags(test.user, test.data, test.opts))
 		})
 	}
 }
 
+func (suite *ExchangeSuite) TestFilterExchangeBackupCreateSelectors() {
+	t := suite.T()
+	sel := selectors.NewExchangeBackup()
+	filterExchangeBackupCreateSelectors(sel, utils.ExchangeOpts{
+		EmailReceivedAfter: "2016-01-01T00:00:00Z",
+		EventStartsBefore:  "2022-01-01T00:00:00Z",
+	})
+	assert.Len(t, sel.Filters, 2)
+
+	after, before, err := sel.TimeWindow(path.EmailCategory)
+	require.NoError(t, err)
+	assert.Equal(t, 2016, after.Year())
+	assert.True(t, before.IsZero())
+}
+
 func (suite *ExchangeSuite) TestExchangeBackupCreateSelectors() {
 	table := []struct {
 		name             string
@@ -250,6 +301,36 @@ func (suite *ExchangeSuite) TestExchangeBackupDetailsSelectorsBadBackupID() {
 	assert.Empty(t, output)
 }
 
+func (suite *ExchangeSuite) TestExchangeBackupDetailsFailures() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	table := []struct {
+		name         string
+		backupGetter *testdata.MockBackupGetter
+		expect       []details.Failure
+		expectErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "failed items",
+			expect:    testdata.Failures,
+			expectErr: assert.NoError,
+		},
+		{
+			name:         "bad backup id",
+			backupGetter: &testdata.MockBackupGetter{},
+			expectErr:    assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			fs, err := runFailuresCmd(ctx, test.backupGetter, "backup-ID")
+			test.expectErr(t, err)
+			assert.Equal(t, test.expect, fs)
+		})
+	}
+}
+
 func (suite *ExchangeSuite) TestExchangeBackupDetailsSelectorsBadFormats() {
 	ctx, flush := tester.NewContext()
 	defer flush()
@@ -0,0 +1,182 @@
+package backup
+
+import (
+	osuser "os/user"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/config"
+	"github.com/alcionai/corso/src/cli/options"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/repository"
+)
+
+const reasonFN = "reason"
+
+// the reason recorded in the hold history by the hold subcommands.
+var holdReason string
+
+const (
+	holdCommand          = "hold"
+	holdSetCommand       = "set"
+	holdClearCommand     = "clear"
+	holdCommandUseSuffix = "--backup <backupId> --reason <reason>"
+)
+
+const holdSetCommandExamples = `# Keep backup 1234abcd-12ab-cd34-56de-1234abcd until the litigation ends
+corso backup hold set --backup 1234abcd-12ab-cd34-56de-1234abcd --reason "Case 2022-CV-0042"`
+
+const holdClearCommandExamples = `# Allow backup 1234abcd-12ab-cd34-56de-1234abcd to be deleted again
+corso backup hold clear --backup 1234abcd-12ab-cd34-56de-1234abcd --reason "Case 2022-CV-0042 closed"`
+
+// called by backup.go to add the hold subcommands.
+func addHoldCommands(cmd *cobra.Command) *cobra.Command {
+	c, _ := utils.AddCommand(cmd, holdCmd())
+
+	for _, sc := range []struct {
+		cmd     *cobra.Command
+		example string
+	}{
+		{holdSetCmd(), holdSetCommandExamples},
+		{holdClearCmd(), holdClearCommandExamples},
+	} {
+		child, fs := utils.AddCommand(c, sc.cmd)
+
+		child.Use = child.Use + " " + holdCommandUseSuffix
+		child.Example = sc.example
+
+		fs.StringVar(&backupID,
+			utils.BackupFN, "",
+			"ID of the backup. (required)")
+		cobra.CheckErr(child.MarkFlagRequired(utils.BackupFN))
+		fs.StringVar(&holdReason,
+			reasonFN, "",
+			"Reason for the change, kept in the backup's hold history. (required)")
+		cobra.CheckErr(child.MarkFlagRequired(reasonFN))
+	}
+
+	return c
+}
+
+// The backup hold subcommand.
+// `corso backup hold <set|clear> [<flag>...]`
+func holdCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   holdCommand,
+		Short: "Set or clear the legal hold on a backup",
+		Long:  `Backups under legal hold can't be deleted until the hold is cleared.`,
+		RunE:  handleHoldCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso backup hold`.
+// Produces the same output as `corso backup hold --help`.
+func handleHoldCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// `corso backup hold set [<flag>...]`
+func holdSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   holdSetCommand,
+		Short: "Place a backup under legal hold",
+		RunE:  setHoldCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// places a backup under legal hold.
+func setHoldCmd(cmd *cobra.Command, args []string) error {
+	return updateHold(cmd, func(r repository.Repository, id model.StableID, by string) (*backup.Backup, error) {
+		return r.SetBackupHold(cmd.Context(), id, holdReason, by)
+	})
+}
+
+// `corso backup hold clear [<flag>...]`
+func holdClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   holdClearCommand,
+		Short: "Release a backup from legal hold",
+		RunE:  clearHoldCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// releases a backup from legal hold.
+func clearHoldCmd(cmd *cobra.Command, args []string) error {
+	return updateHold(cmd, func(r repository.Repository, id model.StableID, by string) (*backup.Backup, error) {
+		return r.ClearBackupHold(cmd.Context(), id, holdReason, by)
+	})
+}
+
+// updateHold connects to the repository and applies the change to the
+// legal hold on the backup, as the current user.
+func updateHold(
+	cmd *cobra.Command,
+	change func(r repository.Repository, id model.StableID, by string) (*backup.Backup, error),
+) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if len(holdReason) == 0 {
+		return Only(ctx, errors.New("a --"+reasonFN+" is required"))
+	}
+
+	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, err := repository.Connect(ctx, acct, s, options.Control())
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	b, err := change(r, model.StableID(backupID), holdActor())
+	if err != nil {
+		if errors.Is(err, kopia.ErrNotFound) {
+			return Only(ctx, errors.Errorf("No backup exists with the id %s", backupID))
+		}
+
+		return Only(ctx, errors.Wrap(err, "Failed to update the legal hold on backup "+backupID))
+	}
+
+	b.Print(ctx)
+
+	return nil
+}
+
+// deleteErr describes the failure to delete the backup, explaining how to
+// release backups that are under legal hold.
+func deleteErr(err error, id string) error {
+	if errors.Is(err, backup.ErrLegalHold) {
+		return errors.Errorf(
+			"Backup %s is under legal hold, and can't be deleted until the hold is cleared "+
+				"with `corso backup %s %s`",
+			id, holdCommand, holdClearCommand)
+	}
+
+	return errors.Wrapf(err, "Deleting backup %s", id)
+}
+
+// holdActor identifies the person changing a legal hold by the name of the
+// local user running corso.
+func holdActor() string {
+	u, err := osuser.Current()
+	if err != nil || len(u.Username) == 0 {
+		return "unknown"
+	}
+
+	return u.Username
+}
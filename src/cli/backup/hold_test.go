@@ -0,0 +1,80 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+)
+
+type HoldSuite struct {
+	suite.Suite
+}
+
+func TestHoldSuite(t *testing.T) {
+	suite.Run(t, new(HoldSuite))
+}
+
+func (suite *HoldSuite) TestAddHoldCommands() {
+	cmd := &cobra.Command{Use: "backup"}
+
+	c := addHoldCommands(cmd)
+	require.NotNil(suite.T(), c)
+	assert.Equal(suite.T(), holdCommand, c.Use)
+
+	table := []struct {
+		name        string
+		expectUse   string
+		expectShort string
+		expectRunE  func(*cobra.Command, []string) error
+	}{
+		{"set", holdSetCommand + " " + holdCommandUseSuffix, holdSetCmd().Short, setHoldCmd},
+		{"clear", holdClearCommand + " " + holdCommandUseSuffix, holdClearCmd().Short, clearHoldCmd},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			child, _, err := c.Find([]string{test.name})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectUse, child.Use)
+			assert.Equal(t, test.expectShort, child.Short)
+			tester.AreSameFunc(t, test.expectRunE, child.RunE)
+
+			for _, fn := range []string{utils.BackupFN, reasonFN} {
+				assert.NotNil(t, child.Flags().Lookup(fn), fn)
+			}
+		})
+	}
+}
+
+func (suite *HoldSuite) TestDeleteErr() {
+	table := []struct {
+		name   string
+		err    error
+		expect string
+	}{
+		{
+			name: "held",
+			err:  errors.Wrap(backup.ErrLegalHold, "wrapped"),
+			expect: "Backup bid is under legal hold, and can't be deleted until the hold is cleared " +
+				"with `corso backup hold clear`",
+		},
+		{
+			name:   "other",
+			err:    assert.AnError,
+			expect: "Deleting backup bid: " + assert.AnError.Error(),
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, deleteErr(test.err, "bid"), test.expect)
+		})
+	}
+}
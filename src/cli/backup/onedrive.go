@@ -3,7 +3,6 @@ package backup
 import (
 	"context"
 
-	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -42,11 +41,26 @@ corso backup create onedrive --user alice@example.com
 corso backup create onedrive --user alice@example.com,bob@example.com
 
 # Backup all OneDrive data for all M365 users 
-corso backup create onedrive --user '*'`
+corso backup create onedrive --user '*'
+
+# Count the OneDrive data that a backup for all M365 users would store
+corso backup create onedrive --user '*' --dry-run`
 
 	oneDriveServiceCommandDeleteExamples = `# Delete OneDrive backup with ID 1234abcd-12ab-cd34-56de-1234abcd
 corso backup delete onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd`
 
+	oneDriveServiceCommandDiffExamples = `# Show what changed in OneDrive between two backups
+corso backup diff onedrive --from 1234abcd-12ab-cd34-56de-1234abcd --to 5678abcd-12ab-cd34-56de-5678abcd
+
+# Show the changes as JSON
+corso backup diff onedrive --from 1234abcd-12ab-cd34-56de-1234abcd --to 5678abcd-12ab-cd34-56de-5678abcd --json`
+
+	oneDriveServiceCommandSearchExamples = `# Find every backed-up version of Alice's files named "Fiscal 22"
+corso backup search onedrive --user alice@example.com --file-name "Fiscal 22"
+
+# Find the backups holding Bob's files modified after the start of 2022
+corso backup search onedrive --user bob@example.com --file-modified-after 2022-01-01T00:00:00`
+
 	oneDriveServiceCommandDetailsExamples = `# Explore Alice's files from backup 1234abcd-12ab-cd34-56de-1234abcd 
 corso backup details onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd --user alice@example.com
 
@@ -86,6 +100,8 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		fs.StringArrayVar(&user,
 			utils.UserFN, nil,
 			"Backup OneDrive data by user ID; accepts '"+utils.Wildcard+"' to select all users. (required)")
+		addDryRunFlag(fs)
+		options.AddBackupFlags(c)
 		options.AddOperationFlags(c)
 
 	case listCommand:
@@ -94,6 +110,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		fs.StringVar(&backupID,
 			utils.BackupFN, "",
 			"ID of the backup to retrieve.")
+		addTagFilterFlag(fs)
 
 	case detailsCommand:
 		c, fs = utils.AddCommand(cmd, oneDriveDetailsCmd())
@@ -105,38 +122,9 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 			utils.BackupFN, "",
 			"ID of the backup to explore. (required)")
 		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+		addFailuresFlag(fs)
 
-		// onedrive hierarchy flags
-
-		fs.StringSliceVar(
-			&folderPaths,
-			utils.FolderFN, nil,
-			"Select backup details by OneDrive folder; defaults to root.")
-
-		fs.StringSliceVar(
-			&fileNames,
-			utils.FileFN, nil,
-			"Select backup details by file name or ID.")
-
-		// onedrive info flags
-
-		fs.StringVar(
-			&fileCreatedAfter,
-			utils.FileCreatedAfterFN, "",
-			"Select backup details for files created after this datetime.")
-		fs.StringVar(
-			&fileCreatedBefore,
-			utils.FileCreatedBeforeFN, "",
-			"Select backup details for files created before this datetime.")
-
-		fs.StringVar(
-			&fileModifiedAfter,
-			utils.FileModifiedAfterFN, "",
-			"Select backup details for files modified after this datetime.")
-		fs.StringVar(
-			&fileModifiedBefore,
-			utils.FileModifiedBeforeFN, "",
-			"Select backup details for files modified before this datetime.")
+		addOneDriveDetailsFlags(fs)
 
 	case deleteCommand:
 		c, fs = utils.AddCommand(cmd, oneDriveDeleteCmd())
@@ -148,6 +136,20 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 			utils.BackupFN, "",
 			"ID of the backup to delete. (required)")
 		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+
+	case diffCommand:
+		c, fs = utils.AddCommand(cmd, oneDriveDiffCmd())
+
+		c.Example = oneDriveServiceCommandDiffExamples
+
+		addDiffFlags(c, fs)
+
+	case searchCommand:
+		c, fs = utils.AddCommand(cmd, oneDriveSearchCmd())
+
+		c.Example = oneDriveServiceCommandSearchExamples
+
+		addOneDriveDetailsFlags(fs)
 	}
 
 	return c
@@ -180,6 +182,10 @@ func createOneDriveCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := options.ValidateBackupFlags(); err != nil {
+		return err
+	}
+
 	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
 	if err != nil {
 		return Only(ctx, err)
@@ -199,54 +205,22 @@ func createOneDriveCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, errors.Wrap(err, "Failed to retrieve M365 users"))
 	}
 
-	var (
-		errs *multierror.Error
-		bIDs []model.StableID
-	)
+	sels := []selectors.Selector{}
 
 	for _, scope := range sel.DiscreteScopes(users) {
 		for _, selUser := range scope.Get(selectors.OneDriveUser) {
 			opSel := selectors.NewOneDriveBackup()
 			opSel.Include([]selectors.OneDriveScope{scope.DiscreteCopy(selUser)})
 
-			bo, err := r.NewBackup(ctx, opSel.Selector)
-			if err != nil {
-				errs = multierror.Append(errs, errors.Wrapf(
-					err,
-					"Failed to initialize OneDrive backup for user %s",
-					scope.Get(selectors.OneDriveUser),
-				))
-
-				continue
-			}
-
-			err = bo.Run(ctx)
-			if err != nil {
-				errs = multierror.Append(errs, errors.Wrapf(
-					err,
-					"Failed to run OneDrive backup for user %s",
-					scope.Get(selectors.OneDriveUser),
-				))
-
-				continue
-			}
-
-			bIDs = append(bIDs, bo.Results.BackupID)
+			sels = append(sels, opSel.Selector)
 		}
 	}
 
-	bups, err := r.Backups(ctx, bIDs)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Unable to retrieve backup results from storage"))
-	}
-
-	backup.PrintAll(ctx, bups)
-
-	if e := errs.ErrorOrNil(); e != nil {
-		return Only(ctx, e)
+	if dryRun {
+		return runDryRuns(ctx, r, "OneDrive", sels)
 	}
 
-	return nil
+	return runBackups(ctx, r, "OneDrive", sels)
 }
 
 func validateOneDriveBackupCreateFlags(users []string) error {
@@ -282,6 +256,11 @@ func oneDriveListCmd() *cobra.Command {
 func listOneDriveCmd(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	filters, err := tagFilters(tags)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
 	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
 	if err != nil {
 		return Only(ctx, err)
@@ -309,7 +288,7 @@ func listOneDriveCmd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	bs, err := r.BackupsByTag(ctx, store.Service(path.OneDriveService))
+	bs, err := r.BackupsByTag(ctx, append(filters, store.Service(path.OneDriveService))...)
 	if err != nil {
 		return Only(ctx, errors.Wrap(err, "Failed to list backups in the repository"))
 	}
@@ -323,6 +302,42 @@ func listOneDriveCmd(cmd *cobra.Command, args []string) error {
 // backup details
 // ------------------------------------------------------------------------------------------------
 
+// addOneDriveDetailsFlags adds the flags that select the items in a backup to
+// the details and search subcommands.
+func addOneDriveDetailsFlags(fs *pflag.FlagSet) {
+	// onedrive hierarchy flags
+
+	fs.StringSliceVar(
+		&folderPaths,
+		utils.FolderFN, nil,
+		"Select backup details by OneDrive folder; defaults to root.")
+
+	fs.StringSliceVar(
+		&fileNames,
+		utils.FileFN, nil,
+		"Select backup details by file name or ID.")
+
+	// onedrive info flags
+
+	fs.StringVar(
+		&fileCreatedAfter,
+		utils.FileCreatedAfterFN, "",
+		"Select backup details for files created after this datetime.")
+	fs.StringVar(
+		&fileCreatedBefore,
+		utils.FileCreatedBeforeFN, "",
+		"Select backup details for files created before this datetime.")
+
+	fs.StringVar(
+		&fileModifiedAfter,
+		utils.FileModifiedAfterFN, "",
+		"Select backup details for files modified after this datetime.")
+	fs.StringVar(
+		&fileModifiedBefore,
+		utils.FileModifiedBeforeFN, "",
+		"Select backup details for files modified before this datetime.")
+}
+
 // `corso backup details onedrive [<flag>...]`
 func oneDriveDetailsCmd() *cobra.Command {
 	return &cobra.Command{
@@ -354,18 +369,19 @@ func detailsOneDriveCmd(cmd *cobra.Command, args []string) error {
 
 	defer utils.CloseRepo(ctx, r)
 
-	opts := utils.OneDriveOpts{
-		Users:              user,
-		Paths:              folderPaths,
-		Names:              fileNames,
-		FileCreatedAfter:   fileCreatedAfter,
-		FileCreatedBefore:  fileCreatedBefore,
-		FileModifiedAfter:  fileModifiedAfter,
-		FileModifiedBefore: fileModifiedBefore,
+	if showFailures {
+		fs, err := runFailuresCmd(ctx, r, backupID)
+		if err != nil {
+			return Only(ctx, err)
+		}
 
-		Populated: utils.GetPopulatedFlags(cmd),
+		details.PrintFailures(ctx, fs)
+
+		return nil
 	}
 
+	opts := oneDriveDetailsOpts(cmd)
+
 	ds, err := runDetailsOneDriveCmd(ctx, r, backupID, opts)
 	if err != nil {
 		return Only(ctx, err)
@@ -392,7 +408,9 @@ func runDetailsOneDriveCmd(
 		return nil, err
 	}
 
-	d, _, err := r.BackupDetails(ctx, backupID)
+	sel := oneDriveDetailsSelector(opts)
+
+	d, _, err := r.SelectedBackupDetails(ctx, backupID, sel.Selector)
 	if err != nil {
 		if errors.Is(err, kopia.ErrNotFound) {
 			return nil, errors.Errorf("no backup exists with the id %s", backupID)
@@ -401,6 +419,26 @@ func runDetailsOneDriveCmd(
 		return nil, errors.Wrap(err, "Failed to get backup details in the repository")
 	}
 
+	return sel.Reduce(ctx, d), nil
+}
+
+// oneDriveDetailsOpts collects the flags that select the items in a backup.
+func oneDriveDetailsOpts(cmd *cobra.Command) utils.OneDriveOpts {
+	return utils.OneDriveOpts{
+		Users:              user,
+		Paths:              folderPaths,
+		Names:              fileNames,
+		FileCreatedAfter:   fileCreatedAfter,
+		FileCreatedBefore:  fileCreatedBefore,
+		FileModifiedAfter:  fileModifiedAfter,
+		FileModifiedBefore: fileModifiedBefore,
+
+		Populated: utils.GetPopulatedFlags(cmd),
+	}
+}
+
+// oneDriveDetailsSelector produces the restore selector matching the opts.
+func oneDriveDetailsSelector(opts utils.OneDriveOpts) *selectors.OneDriveRestore {
 	sel := selectors.NewOneDriveRestore()
 	utils.IncludeOneDriveRestoreDataSelectors(sel, opts)
 	utils.FilterOneDriveRestoreInfoSelectors(sel, opts)
@@ -410,7 +448,7 @@ func runDetailsOneDriveCmd(
 		sel.Include(sel.Users(selectors.Any()))
 	}
 
-	return sel.Reduce(ctx, d), nil
+	return sel
 }
 
 // `corso backup delete onedrive [<flag>...]`
@@ -445,10 +483,59 @@ func deleteOneDriveCmd(cmd *cobra.Command, args []string) error {
 	defer utils.CloseRepo(ctx, r)
 
 	if err := r.DeleteBackup(ctx, model.StableID(backupID)); err != nil {
-		return Only(ctx, errors.Wrapf(err, "Deleting backup %s", backupID))
+		return Only(ctx, deleteErr(err, backupID))
 	}
 
 	Info(ctx, "Deleted OneDrive backup ", backupID)
 
 	return nil
 }
+
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff onedrive [<flag>...]`
+func oneDriveDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     oneDriveServiceCommand,
+		Short:   "Show the changes between two M365 OneDrive service backups",
+		RunE:    diffOneDriveCmd,
+		Args:    cobra.NoArgs,
+		Example: oneDriveServiceCommandDiffExamples,
+	}
+}
+
+// prints the changes between two oneDrive service backups.
+func diffOneDriveCmd(cmd *cobra.Command, args []string) error {
+	return diffServiceCmd(cmd, path.OneDriveService)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup search
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup search onedrive [<flag>...]`
+func oneDriveSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     oneDriveServiceCommand,
+		Short:   "Search every M365 OneDrive service backup for items",
+		RunE:    searchOneDriveCmd,
+		Args:    cobra.NoArgs,
+		Example: oneDriveServiceCommandSearchExamples,
+	}
+}
+
+// lists the versions of the matching items in every OneDrive service backup.
+func searchOneDriveCmd(cmd *cobra.Command, args []string) error {
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := oneDriveDetailsOpts(cmd)
+	if err := utils.ValidateOneDriveFilterFlags(opts); err != nil {
+		return Only(cmd.Context(), err)
+	}
+
+	return searchServiceCmd(cmd, oneDriveDetailsSelector(opts).Selector)
+}
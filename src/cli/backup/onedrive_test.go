@@ -46,6 +46,14 @@ func (suite *OneDriveSuite) TestAddOneDriveCommands() {
 			"delete onedrive", deleteCommand, expectUse + " " + oneDriveServiceCommandDeleteUseSuffix,
 			oneDriveDeleteCmd().Short, deleteOneDriveCmd,
 		},
+		{
+			"diff onedrive", diffCommand, expectUse + " " + diffCommandUseSuffix,
+			oneDriveDiffCmd().Short, diffOneDriveCmd,
+		},
+		{
+			"search onedrive", searchCommand, expectUse,
+			oneDriveSearchCmd().Short, searchOneDriveCmd,
+		},
 	}
 	for _, test := range table {
 		suite.T().Run(test.name, func(t *testing.T) {
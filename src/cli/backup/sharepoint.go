@@ -3,7 +3,6 @@ package backup
 import (
 	"context"
 
-	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -62,6 +61,15 @@ corso backup create sharepoint --site '*'`
 	sharePointServiceCommandDeleteExamples = `# Delete SharePoint backup with ID 1234abcd-12ab-cd34-56de-1234abcd
 corso backup delete sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd`
 
+	sharePointServiceCommandDiffExamples = `# Show what changed in SharePoint between two backups
+corso backup diff sharepoint --from 1234abcd-12ab-cd34-56de-1234abcd --to 5678abcd-12ab-cd34-56de-5678abcd
+
+# Show the changes as JSON
+corso backup diff sharepoint --from 1234abcd-12ab-cd34-56de-1234abcd --to 5678abcd-12ab-cd34-56de-5678abcd --json`
+
+	sharePointServiceCommandSearchExamples = `# Find every backed-up version of the library item "Fiscal 22" in <site>
+corso backup search sharepoint --site <site_id> --library-item "Fiscal 22"`
+
 	sharePointServiceCommandDetailsExamples = `# Explore <site>'s files from backup 1234abcd-12ab-cd34-56de-1234abcd
 
 corso backup details sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd --site <site_id>`
@@ -94,6 +102,8 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 			&sharepointData,
 			utils.DataFN, nil,
 			"Select one or more types of data to backup: "+dataLibraries+".")
+		addDryRunFlag(fs)
+		options.AddBackupFlags(c)
 		options.AddOperationFlags(c)
 
 	case listCommand:
@@ -102,6 +112,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		fs.StringVar(&backupID,
 			utils.BackupFN, "",
 			"ID of the backup to retrieve.")
+		addTagFilterFlag(fs)
 
 	case detailsCommand:
 		c, fs = utils.AddCommand(cmd, sharePointDetailsCmd())
@@ -113,33 +124,9 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 			utils.BackupFN, "",
 			"ID of the backup to retrieve.")
 		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+		addFailuresFlag(fs)
 
-		// sharepoint hierarchy flags
-
-		fs.StringSliceVar(
-			&libraryPaths,
-			utils.LibraryFN, nil,
-			"Select backup details by Library name.")
-
-		fs.StringSliceVar(
-			&libraryItems,
-			utils.LibraryItemFN, nil,
-			"Select backup details by library item name or ID.")
-
-		fs.StringArrayVar(&site,
-			utils.SiteFN, nil,
-			"Backup SharePoint data by site ID; accepts '"+utils.Wildcard+"' to select all sites.")
-
-		fs.StringSliceVar(&weburl,
-			utils.WebURLFN, nil,
-			"Restore data by site webURL; accepts '"+utils.Wildcard+"' to select all sites.")
-
-		// info flags
-
-		// fs.StringVar(
-		// 	&fileCreatedAfter,
-		// 	utils.FileCreatedAfterFN, "",
-		// 	"Select backup details for items created after this datetime.")
+		addSharePointDetailsFlags(fs)
 
 	case deleteCommand:
 		c, fs = utils.AddCommand(cmd, sharePointDeleteCmd(), utils.HideCommand())
@@ -151,6 +138,20 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 			utils.BackupFN, "",
 			"ID of the backup to delete. (required)")
 		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+
+	case diffCommand:
+		c, fs = utils.AddCommand(cmd, sharePointDiffCmd(), utils.HideCommand())
+
+		c.Example = sharePointServiceCommandDiffExamples
+
+		addDiffFlags(c, fs)
+
+	case searchCommand:
+		c, fs = utils.AddCommand(cmd, sharePointSearchCmd(), utils.HideCommand())
+
+		c.Example = sharePointServiceCommandSearchExamples
+
+		addSharePointDetailsFlags(fs)
 	}
 
 	return c
@@ -183,6 +184,10 @@ func createSharePointCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := options.ValidateBackupFlags(); err != nil {
+		return err
+	}
+
 	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
 	if err != nil {
 		return Only(ctx, err)
@@ -205,54 +210,22 @@ func createSharePointCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, errors.Wrap(err, "Retrieving up sharepoint sites by ID and WebURL"))
 	}
 
-	var (
-		errs *multierror.Error
-		bIDs []model.StableID
-	)
+	sels := []selectors.Selector{}
 
 	for _, scope := range sel.DiscreteScopes(gc.GetSiteIDs()) {
 		for _, selSite := range scope.Get(selectors.SharePointSite) {
 			opSel := selectors.NewSharePointBackup()
 			opSel.Include([]selectors.SharePointScope{scope.DiscreteCopy(selSite)})
 
-			bo, err := r.NewBackup(ctx, opSel.Selector)
-			if err != nil {
-				errs = multierror.Append(errs, errors.Wrapf(
-					err,
-					"Failed to initialize SharePoint backup for site %s",
-					scope.Get(selectors.SharePointSite),
-				))
-
-				continue
-			}
-
-			err = bo.Run(ctx)
-			if err != nil {
-				errs = multierror.Append(errs, errors.Wrapf(
-					err,
-					"Failed to run SharePoint backup for site %s",
-					scope.Get(selectors.SharePointSite),
-				))
-
-				continue
-			}
-
-			bIDs = append(bIDs, bo.Results.BackupID)
+			sels = append(sels, opSel.Selector)
 		}
 	}
 
-	bups, err := r.Backups(ctx, bIDs)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Unable to retrieve backup results from storage"))
-	}
-
-	backup.PrintAll(ctx, bups)
-
-	if e := errs.ErrorOrNil(); e != nil {
-		return Only(ctx, e)
+	if dryRun {
+		return runDryRuns(ctx, r, "SharePoint", sels)
 	}
 
-	return nil
+	return runBackups(ctx, r, "SharePoint", sels)
 }
 
 func validateSharePointBackupCreateFlags(sites, weburls []string) error {
@@ -318,6 +291,11 @@ func sharePointListCmd() *cobra.Command {
 func listSharePointCmd(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	filters, err := tagFilters(tags)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
 	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
 	if err != nil {
 		return Only(ctx, err)
@@ -345,7 +323,7 @@ func listSharePointCmd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	bs, err := r.BackupsByTag(ctx, store.Service(path.SharePointService))
+	bs, err := r.BackupsByTag(ctx, append(filters, store.Service(path.SharePointService))...)
 	if err != nil {
 		return Only(ctx, errors.Wrap(err, "Failed to list backups in the repository"))
 	}
@@ -391,7 +369,7 @@ func deleteSharePointCmd(cmd *cobra.Command, args []string) error {
 	defer utils.CloseRepo(ctx, r)
 
 	if err := r.DeleteBackup(ctx, model.StableID(backupID)); err != nil {
-		return Only(ctx, errors.Wrapf(err, "Deleting backup %s", backupID))
+		return Only(ctx, deleteErr(err, backupID))
 	}
 
 	Info(ctx, "Deleted SharePoint backup ", backupID)
@@ -403,7 +381,38 @@ func deleteSharePointCmd(cmd *cobra.Command, args []string) error {
 // backup details
 // ------------------------------------------------------------------------------------------------
 
-// `corso backup details onedrive [<flag>...]`
+// addSharePointDetailsFlags adds the flags that select the items in a backup to
+// the details and search subcommands.
+func addSharePointDetailsFlags(fs *pflag.FlagSet) {
+	// sharepoint hierarchy flags
+
+	fs.StringSliceVar(
+		&libraryPaths,
+		utils.LibraryFN, nil,
+		"Select backup details by Library name.")
+
+	fs.StringSliceVar(
+		&libraryItems,
+		utils.LibraryItemFN, nil,
+		"Select backup details by library item name or ID.")
+
+	fs.StringArrayVar(&site,
+		utils.SiteFN, nil,
+		"Backup SharePoint data by site ID; accepts '"+utils.Wildcard+"' to select all sites.")
+
+	fs.StringSliceVar(&weburl,
+		utils.WebURLFN, nil,
+		"Restore data by site webURL; accepts '"+utils.Wildcard+"' to select all sites.")
+
+	// info flags
+
+	// fs.StringVar(
+	// 	&fileCreatedAfter,
+	// 	utils.FileCreatedAfterFN, "",
+	// 	"Select backup details for items created after this datetime.")
+}
+
+// `corso backup details sharepoint [<flag>...]`
 func sharePointDetailsCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:     sharePointServiceCommand,
@@ -434,15 +443,19 @@ func detailsSharePointCmd(cmd *cobra.Command, args []string) error {
 
 	defer utils.CloseRepo(ctx, r)
 
-	opts := utils.SharePointOpts{
-		LibraryItems: libraryItems,
-		LibraryPaths: libraryPaths,
-		Sites:        site,
-		WebURLs:      weburl,
+	if showFailures {
+		fs, err := runFailuresCmd(ctx, r, backupID)
+		if err != nil {
+			return Only(ctx, err)
+		}
 
-		Populated: utils.GetPopulatedFlags(cmd),
+		details.PrintFailures(ctx, fs)
+
+		return nil
 	}
 
+	opts := sharePointDetailsOpts(cmd)
+
 	ds, err := runDetailsSharePointCmd(ctx, r, backupID, opts)
 	if err != nil {
 		return Only(ctx, err)
@@ -469,7 +482,9 @@ func runDetailsSharePointCmd(
 		return nil, err
 	}
 
-	d, _, err := r.BackupDetails(ctx, backupID)
+	sel := sharePointDetailsSelector(opts)
+
+	d, _, err := r.SelectedBackupDetails(ctx, backupID, sel.Selector)
 	if err != nil {
 		if errors.Is(err, kopia.ErrNotFound) {
 			return nil, errors.Errorf("no backup exists with the id %s", backupID)
@@ -478,6 +493,23 @@ func runDetailsSharePointCmd(
 		return nil, errors.Wrap(err, "Failed to get backup details in the repository")
 	}
 
+	return sel.Reduce(ctx, d), nil
+}
+
+// sharePointDetailsOpts collects the flags that select the items in a backup.
+func sharePointDetailsOpts(cmd *cobra.Command) utils.SharePointOpts {
+	return utils.SharePointOpts{
+		LibraryItems: libraryItems,
+		LibraryPaths: libraryPaths,
+		Sites:        site,
+		WebURLs:      weburl,
+
+		Populated: utils.GetPopulatedFlags(cmd),
+	}
+}
+
+// sharePointDetailsSelector produces the restore selector matching the opts.
+func sharePointDetailsSelector(opts utils.SharePointOpts) *selectors.SharePointRestore {
 	sel := selectors.NewSharePointRestore()
 	utils.IncludeSharePointRestoreDataSelectors(sel, opts)
 	utils.FilterSharePointRestoreInfoSelectors(sel, opts)
@@ -487,5 +519,54 @@ func runDetailsSharePointCmd(
 		sel.Include(sel.Sites(selectors.Any()))
 	}
 
-	return sel.Reduce(ctx, d), nil
+	return sel
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff sharepoint [<flag>...]`
+func sharePointDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     sharePointServiceCommand,
+		Short:   "Show the changes between two M365 SharePoint service backups",
+		RunE:    diffSharePointCmd,
+		Args:    cobra.NoArgs,
+		Example: sharePointServiceCommandDiffExamples,
+	}
+}
+
+// prints the changes between two sharePoint service backups.
+func diffSharePointCmd(cmd *cobra.Command, args []string) error {
+	return diffServiceCmd(cmd, path.SharePointService)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup search
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup search sharepoint [<flag>...]`
+func sharePointSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     sharePointServiceCommand,
+		Short:   "Search every M365 SharePoint service backup for items",
+		RunE:    searchSharePointCmd,
+		Args:    cobra.NoArgs,
+		Example: sharePointServiceCommandSearchExamples,
+	}
+}
+
+// lists the versions of the matching items in every SharePoint service backup.
+func searchSharePointCmd(cmd *cobra.Command, args []string) error {
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := sharePointDetailsOpts(cmd)
+	if err := utils.ValidateSharePointFilterFlags(opts); err != nil {
+		return Only(cmd.Context(), err)
+	}
+
+	return searchServiceCmd(cmd, sharePointDetailsSelector(opts).Selector)
 }
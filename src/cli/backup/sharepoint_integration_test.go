@@ -82,7 +82,7 @@ func (suite *NoBackupSharePointIntegrationSuite) SetupSuite() {
 	suite.m365SiteID = tester.M365SiteID(t)
 
 	// init the repo first
-	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{})
+	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 }
 
@@ -168,7 +168,7 @@ func (suite *BackupDeleteSharePointIntegrationSuite) SetupSuite() {
 	defer flush()
 
 	// init the repo first
-	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{})
+	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 
 	m365SiteID := tester.M365SiteID(t)
@@ -49,6 +49,14 @@ func (suite *SharePointSuite) TestAddSharePointCommands() {
 			"delete sharepoint", deleteCommand, expectUse + " " + sharePointServiceCommandDeleteUseSuffix,
 			sharePointDeleteCmd().Short, deleteSharePointCmd,
 		},
+		{
+			"diff sharepoint", diffCommand, expectUse + " " + diffCommandUseSuffix,
+			sharePointDiffCmd().Short, diffSharePointCmd,
+		},
+		{
+			"search sharepoint", searchCommand, expectUse,
+			sharePointSearchCmd().Short, searchSharePointCmd,
+		},
 	}
 	for _, test := range table {
 		suite.T().Run(test.name, func(t *testing.T) {
@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/config"
+	"github.com/alcionai/corso/src/cli/options"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/repository"
+)
+
+// keys of the tags to remove with the tag subcommand.
+var removeTags []string
+
+const (
+	tagCommand          = "tag"
+	tagCommandUseSuffix = "--backup <backupId>"
+)
+
+const tagCommandExamples = `# Mark backup 1234abcd-12ab-cd34-56de-1234abcd as relevant to a ticket
+corso backup tag --backup 1234abcd-12ab-cd34-56de-1234abcd --tag ticket=INC123
+
+# Replace the ticket on backup 1234abcd-12ab-cd34-56de-1234abcd and drop its reason
+corso backup tag --backup 1234abcd-12ab-cd34-56de-1234abcd --tag ticket=INC456 --remove reason
+
+# List the Exchange backups relevant to the ticket
+corso backup list exchange --tag ticket=INC456`
+
+// called by backup.go to add the tag subcommand.
+func addTagCommand(cmd *cobra.Command) *cobra.Command {
+	c, fs := utils.AddCommand(cmd, tagCmd())
+
+	c.Use = c.Use + " " + tagCommandUseSuffix
+	c.Example = tagCommandExamples
+
+	fs.StringVar(&backupID,
+		utils.BackupFN, "",
+		"ID of the backup to tag. (required)")
+	cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+	fs.StringSliceVar(
+		&tags,
+		utils.TagFN, nil,
+		"Tag to attach to the backup, formatted as key=value; repeat to attach multiple tags.")
+	fs.StringSliceVar(
+		&removeTags,
+		removeFN, nil,
+		"Key of a tag to remove from the backup; repeat to remove multiple tags.")
+
+	return c
+}
+
+// The backup tag subcommand.
+// `corso backup tag [<flag>...]`
+func tagCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   tagCommand,
+		Short: "Add or remove the tags on a backup",
+		RunE:  tagBackupCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// updates the tags on a backup.
+func tagBackupCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	set, err := utils.ParseTags(tags)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if len(set) == 0 && len(removeTags) == 0 {
+		return Only(ctx, errors.New("at least one --"+utils.TagFN+" or --"+removeFN+" is required"))
+	}
+
+	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, err := repository.Connect(ctx, acct, s, options.Control())
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	b, err := r.UpdateBackupTags(ctx, model.StableID(backupID), set, removeTags)
+	if err != nil {
+		if errors.Is(err, kopia.ErrNotFound) {
+			return Only(ctx, errors.Errorf("No backup exists with the id %s", backupID))
+		}
+
+		return Only(ctx, errors.Wrap(err, "Failed to tag backup "+backupID))
+	}
+
+	b.Print(ctx)
+
+	return nil
+}
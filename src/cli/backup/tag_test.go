@@ -0,0 +1,73 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type TagSuite struct {
+	suite.Suite
+}
+
+func TestTagSuite(t *testing.T) {
+	suite.Run(t, new(TagSuite))
+}
+
+func (suite *TagSuite) TestAddTagCommand() {
+	t := suite.T()
+	cmd := &cobra.Command{Use: "backup"}
+
+	c := addTagCommand(cmd)
+	require.NotNil(t, c)
+
+	cmds := cmd.Commands()
+	require.Len(t, cmds, 1)
+
+	child := cmds[0]
+	assert.Equal(t, tagCommand+" "+tagCommandUseSuffix, child.Use)
+	assert.Equal(t, tagCmd().Short, child.Short)
+	tester.AreSameFunc(t, tagBackupCmd, child.RunE)
+
+	for _, fn := range []string{utils.BackupFN, utils.TagFN, removeFN} {
+		assert.NotNil(t, child.Flags().Lookup(fn), fn)
+	}
+}
+
+func (suite *TagSuite) TestTagFilters() {
+	table := []struct {
+		name      string
+		kvs       []string
+		expectLen int
+		expect    assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "none",
+			expect: assert.NoError,
+		},
+		{
+			name:      "many",
+			kvs:       []string{"ticket=INC123", "reason=audit"},
+			expectLen: 2,
+			expect:    assert.NoError,
+		},
+		{
+			name:   "malformed",
+			kvs:    []string{"ticket"},
+			expect: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			fs, err := tagFilters(test.kvs)
+			test.expect(t, err)
+			assert.Len(t, fs, test.expectLen)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/alcionai/corso/src/cli/backup"
 	"github.com/alcionai/corso/src/cli/config"
+	"github.com/alcionai/corso/src/cli/export"
 	"github.com/alcionai/corso/src/cli/help"
 	"github.com/alcionai/corso/src/cli/options"
 	"github.com/alcionai/corso/src/cli/print"
@@ -64,6 +65,7 @@ func BuildCommandTree(cmd *cobra.Command) {
 	cmd.Flags().BoolP("version", "v", false, "current version info")
 	cmd.PersistentPostRunE = config.InitFunc()
 	config.AddConfigFlags(cmd)
+	config.AddM365Flags(cmd)
 	logger.AddLogLevelFlag(cmd)
 	observe.AddProgressBarFlags(cmd)
 	print.AddOutputFlag(cmd)
@@ -76,6 +78,7 @@ func BuildCommandTree(cmd *cobra.Command) {
 	repo.AddCommands(cmd)
 	backup.AddCommands(cmd)
 	restore.AddCommands(cmd)
+	export.AddCommands(cmd)
 	help.AddCommands(cmd)
 }
 
@@ -2,8 +2,10 @@ package config
 
 import (
 	"os"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/alcionai/corso/src/cli/utils"
@@ -12,6 +14,57 @@ import (
 	"github.com/alcionai/corso/src/pkg/credentials"
 )
 
+// m365 credential info from flags
+var (
+	azureCloud              string
+	azureClientCertPath     string
+	azureClientCertPassword string
+)
+
+// AddM365Flags adds the persistent flags for the m365 tenant and app
+// credentials that can't be provided as env vars alone.
+func AddM365Flags(cmd *cobra.Command) {
+	fs := cmd.PersistentFlags()
+	fs.StringVar(
+		&azureCloud,
+		"azure-cloud",
+		"",
+		"Azure cloud hosting the M365 tenant, one of: "+strings.Join(account.AzureClouds, ", ")+". (default \"public\")")
+	fs.StringVar(
+		&azureClientCertPath,
+		"azure-client-cert",
+		"",
+		"Path to a PEM or PFX certificate used to authenticate the Azure AD application, instead of a client secret.")
+	fs.StringVar(
+		&azureClientCertPassword,
+		"azure-client-cert-password",
+		"",
+		"Password for the Azure AD application certificate, if it's encrypted.")
+}
+
+// m365FlagOverrides adds the values of the m365 credential flags to the
+// overrides.
+func m365FlagOverrides(in map[string]string) map[string]string {
+	out := map[string]string{}
+	for k, v := range in {
+		out[k] = v
+	}
+
+	if len(azureCloud) > 0 {
+		out[account.AzureCloud] = azureCloud
+	}
+
+	if len(azureClientCertPath) > 0 {
+		out[credentials.AzureClientCertPath] = azureClientCertPath
+	}
+
+	if len(azureClientCertPassword) > 0 {
+		out[credentials.AzureClientCertPassword] = azureClientCertPassword
+	}
+
+	return out
+}
+
 // prerequisite: readRepoConfig must have been run prior to this to populate the global viper values.
 func m365ConfigsFromViper(vpr *viper.Viper) (account.M365Config, error) {
 	var m365 account.M365Config
@@ -22,6 +75,8 @@ func m365ConfigsFromViper(vpr *viper.Viper) (account.M365Config, error) {
 	}
 
 	m365.AzureTenantID = vpr.GetString(AzureTenantIDKey)
+	m365.AzureCloud = vpr.GetString(AzureCloudKey)
+	m365.AzureClientCertPath = vpr.GetString(AzureClientCertPathKey)
 
 	return m365, nil
 }
@@ -29,6 +84,7 @@ func m365ConfigsFromViper(vpr *viper.Viper) (account.M365Config, error) {
 func m365Overrides(in map[string]string) map[string]string {
 	return map[string]string{
 		account.AzureTenantID:  in[account.AzureTenantID],
+		account.AzureCloud:     in[account.AzureCloud],
 		AccountProviderTypeKey: in[AccountProviderTypeKey],
 	}
 }
@@ -59,6 +115,14 @@ func configureAccount(
 
 	// compose the m365 config and credentials
 	m365 := credentials.GetM365()
+	m365.AzureClientCertPath = common.First(
+		overrides[credentials.AzureClientCertPath],
+		m365Cfg.AzureClientCertPath,
+		m365.AzureClientCertPath)
+	m365.AzureClientCertPassword = common.First(
+		overrides[credentials.AzureClientCertPassword],
+		m365.AzureClientCertPassword)
+
 	if err := m365.Validate(); err != nil {
 		return acct, errors.Wrap(err, "validating m365 credentials")
 	}
@@ -69,13 +133,17 @@ func configureAccount(
 			overrides[account.AzureTenantID],
 			m365Cfg.AzureTenantID,
 			os.Getenv(account.AzureTenantID)),
+		AzureCloud: common.First(
+			overrides[account.AzureCloud],
+			m365Cfg.AzureCloud,
+			os.Getenv(account.AzureCloud)),
 	}
 
 	// ensure required properties are present
+	// the client secret or certificate was checked by m365.Validate()
 	if err := utils.RequireProps(map[string]string{
-		credentials.AzureClientID:     m365Cfg.AzureClientID,
-		credentials.AzureClientSecret: m365Cfg.AzureClientSecret,
-		account.AzureTenantID:         m365Cfg.AzureTenantID,
+		credentials.AzureClientID: m365Cfg.AzureClientID,
+		account.AzureTenantID:     m365Cfg.AzureTenantID,
 	}); err != nil {
 		return acct, err
 	}
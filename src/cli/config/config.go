@@ -28,6 +28,8 @@ const (
 	// M365 config
 	AccountProviderTypeKey = "account_provider"
 	AzureTenantIDKey       = "azure_tenantid"
+	AzureCloudKey          = "azure_cloud"
+	AzureClientCertPathKey = "azure_client_cert_path"
 )
 
 var (
@@ -199,6 +201,9 @@ func writeRepoConfigWithViper(vpr *viper.Viper, s3Config storage.S3Config, m365C
 
 	vpr.Set(AccountProviderTypeKey, account.ProviderM365.String())
 	vpr.Set(AzureTenantIDKey, m365Config.AzureTenantID)
+	vpr.Set(AzureCloudKey, m365Config.AzureCloud)
+	// the certificate password is a secret, and is never persisted
+	vpr.Set(AzureClientCertPathKey, m365Config.AzureClientCertPath)
 
 	if err := vpr.SafeWriteConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileAlreadyExistsError); ok {
@@ -218,7 +223,7 @@ func GetStorageAndAccount(
 	readFromFile bool,
 	overrides map[string]string,
 ) (storage.Storage, account.Account, error) {
-	return getStorageAndAccountWithViper(GetViper(ctx), readFromFile, overrides)
+	return getStorageAndAccountWithViper(GetViper(ctx), readFromFile, m365FlagOverrides(overrides))
 }
 
 // getSorageAndAccountWithViper implements GetSorageAndAccount, but takes in a viper
@@ -267,6 +272,7 @@ func getStorageAndAccountWithViper(
 
 var constToTomlKeyMap = map[string]string{
 	account.AzureTenantID:  AzureTenantIDKey,
+	account.AzureCloud:     AzureCloudKey,
 	AccountProviderTypeKey: AccountProviderTypeKey,
 	storage.Bucket:         BucketNameKey,
 	storage.Endpoint:       EndpointKey,
@@ -289,6 +295,14 @@ func mustMatchConfig(vpr *viper.Viper, m map[string]string) error {
 		}
 
 		vv := vpr.GetString(tomlK)
+
+		// configs written before clouds were supported don't hold one, and
+		// are in the public cloud.
+		if k == account.AzureCloud {
+			v = account.M365Config{AzureCloud: v}.Cloud()
+			vv = account.M365Config{AzureCloud: vv}.Cloud()
+		}
+
 		if v != vv {
 			return errors.New("value of " + k + " (" + v + ") does not match corso configuration value (" + vv + ")")
 		}
@@ -86,7 +86,14 @@ func (suite *ConfigSuite) TestWriteReadConfig() {
 	require.NoError(t, initWithViper(vpr, testConfigFilePath), "initializing repo config")
 
 	s3Cfg := storage.S3Config{Bucket: bkt, DoNotUseTLS: true, DoNotVerifyTLS: true}
-	m365 := account.M365Config{AzureTenantID: tid}
+	m365 := account.M365Config{
+		M365: credentials.M365{
+			AzureClientCertPath:     "/path/to/cert.pfx",
+			AzureClientCertPassword: "password",
+		},
+		AzureTenantID: tid,
+		AzureCloud:    account.AzureCloudUSGovGCCHigh,
+	}
 
 	require.NoError(t, writeRepoConfigWithViper(vpr, s3Cfg, m365), "writing repo config")
 	require.NoError(t, vpr.ReadInConfig(), "reading repo config")
@@ -100,6 +107,9 @@ func (suite *ConfigSuite) TestWriteReadConfig() {
 	readM365, err := m365ConfigsFromViper(vpr)
 	require.NoError(t, err)
 	assert.Equal(t, readM365.AzureTenantID, m365.AzureTenantID)
+	assert.Equal(t, readM365.AzureCloud, m365.AzureCloud)
+	assert.Equal(t, readM365.AzureClientCertPath, m365.AzureClientCertPath)
+	assert.Empty(t, readM365.AzureClientCertPassword)
 }
 
 func (suite *ConfigSuite) TestMustMatchConfig() {
@@ -170,6 +180,24 @@ func (suite *ConfigSuite) TestMustMatchConfig() {
 			},
 			errCheck: assert.Error,
 		},
+		{
+			name: "public cloud matches config without a cloud",
+			input: map[string]string{
+				storage.Bucket:        bkt,
+				account.AzureTenantID: tid,
+				account.AzureCloud:    account.AzureCloudPublic,
+			},
+			errCheck: assert.NoError,
+		},
+		{
+			name: "cloud mismatch",
+			input: map[string]string{
+				storage.Bucket:        bkt,
+				account.AzureTenantID: tid,
+				account.AzureCloud:    account.AzureCloudChina,
+			},
+			errCheck: assert.Error,
+		},
 	}
 	for _, test := range table {
 		t.Run(test.name, func(t *testing.T) {
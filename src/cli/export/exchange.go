@@ -0,0 +1,276 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alcionai/corso/src/cli/config"
+	"github.com/alcionai/corso/src/cli/options"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+const formatFN = "format"
+
+// exchange bucket info from flags
+var (
+	format string
+
+	contact       []string
+	contactFolder []string
+	contactName   string
+
+	email               []string
+	emailFolder         []string
+	emailReceivedAfter  string
+	emailReceivedBefore string
+	emailSender         string
+	emailSubject        string
+
+	event             []string
+	eventCalendar     []string
+	eventOrganizer    string
+	eventRecurs       string
+	eventStartsAfter  string
+	eventStartsBefore string
+	eventSubject      string
+)
+
+// called by export.go to map subcommands to provider-specific handling.
+func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
+	var (
+		c  *cobra.Command
+		fs *pflag.FlagSet
+	)
+
+	switch cmd.Use {
+	case exportCommand:
+		c, fs = utils.AddCommand(cmd, exchangeExportCmd())
+
+		c.Use = c.Use + " " + exchangeServiceCommandUseSuffix
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		// More generic (ex: --user) and more frequently used flags take precedence.
+		fs.SortFlags = false
+
+		// general flags
+		fs.StringVar(&backupID,
+			utils.BackupFN, "",
+			"ID of the backup to export. (required)")
+		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+
+		fs.StringSliceVar(&user,
+			utils.UserFN, nil,
+			"Export data by user ID; accepts '"+utils.Wildcard+"' to select all users.")
+
+		// email flags
+		fs.StringSliceVar(&email,
+			utils.EmailFN, nil,
+			"Export emails by ID; accepts '"+utils.Wildcard+"' to select all emails.")
+		fs.StringSliceVar(
+			&emailFolder,
+			utils.EmailFolderFN, nil,
+			"Export emails within a folder; accepts '"+utils.Wildcard+"' to select all email folders.")
+		fs.StringVar(
+			&emailSubject,
+			utils.EmailSubjectFN, "",
+			"Export emails with a subject containing this value.")
+		fs.StringVar(
+			&emailSender,
+			utils.EmailSenderFN, "",
+			"Export emails from a specific sender.")
+		fs.StringVar(
+			&emailReceivedAfter,
+			utils.EmailReceivedAfterFN, "",
+			"Export emails received after this datetime.")
+		fs.StringVar(
+			&emailReceivedBefore,
+			utils.EmailReceivedBeforeFN, "",
+			"Export emails received before this datetime.")
+
+		// event flags
+		fs.StringSliceVar(&event,
+			utils.EventFN, nil,
+			"Export events by event ID; accepts '"+utils.Wildcard+"' to select all events.")
+		fs.StringSliceVar(
+			&eventCalendar,
+			utils.EventCalendarFN, nil,
+			"Export events under a calendar; accepts '"+utils.Wildcard+"' to select all event calendars.")
+		fs.StringVar(
+			&eventSubject,
+			utils.EventSubjectFN, "",
+			"Export events with a subject containing this value.")
+		fs.StringVar(
+			&eventOrganizer,
+			utils.EventOrganizerFN, "",
+			"Export events from a specific organizer.")
+		fs.StringVar(
+			&eventRecurs,
+			utils.EventRecursFN, "",
+			"Export recurring events. Use `--event-recurs false` to export non-recurring events.")
+		fs.StringVar(
+			&eventStartsAfter,
+			utils.EventStartsAfterFN, "",
+			"Export events starting after this datetime.")
+		fs.StringVar(
+			&eventStartsBefore,
+			utils.EventStartsBeforeFN, "",
+			"Export events starting before this datetime.")
+
+		// contacts flags
+		fs.StringSliceVar(
+			&contact,
+			utils.ContactFN, nil,
+			"Export contacts by contact ID; accepts '"+utils.Wildcard+"' to select all contacts.")
+		fs.StringSliceVar(
+			&contactFolder,
+			utils.ContactFolderFN, nil,
+			"Export contacts within a folder; accepts '"+utils.Wildcard+"' to select all contact folders.")
+		fs.StringVar(
+			&contactName,
+			utils.ContactNameFN, "",
+			"Export contacts whose contact name contains this value.")
+
+		// export destination flags
+		fs.StringVar(
+			&format,
+			formatFN, string(control.EMLFormat),
+			"File format for exported emails: '"+string(control.EMLFormat)+"' writes one file per message, '"+
+				string(control.MBOXFormat)+"' writes one file per folder.")
+		addArchiveFlag(fs)
+
+		// others
+		options.AddOperationFlags(c)
+	}
+
+	return c
+}
+
+const (
+	exchangeServiceCommand          = "exchange"
+	exchangeServiceCommandUseSuffix = "<destination> --backup <backupId>"
+
+	//nolint:lll
+	exchangeServiceCommandExportExamples = `# Export all data in a backup to the directory ./exchange-export
+# Emails are written as .eml files, contacts as .vcf files, and events as .ics files
+corso export exchange ./exchange-export --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Export Alice's inbox as an mbox file
+corso export exchange ./mail-export --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --user alice@example.com --email-folder Inbox --format mbox
+
+# Export all emails from Bob received before 2020 to a zip file
+corso export exchange ./bob.zip --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --user bob@example.com --email-received-before 2020-01-01T00:00:00 --archive zip
+
+# Export all of Alice's contacts and calendars
+corso export exchange ./alice --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --user alice@example.com --contact-folder '*' --event-calendar '*'`
+)
+
+// `corso export exchange <destination> [<flag>...]`
+func exchangeExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     exchangeServiceCommand,
+		Short:   "Export M365 Exchange service data",
+		RunE:    exportExchangeCmd,
+		Args:    cobra.ExactArgs(1),
+		Example: exchangeServiceCommandExportExamples,
+	}
+}
+
+// processes an exchange service export.
+func exportExchangeCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.ExchangeOpts{
+		Contact:             contact,
+		ContactFolder:       contactFolder,
+		Email:               email,
+		EmailFolder:         emailFolder,
+		Event:               event,
+		EventCalendar:       eventCalendar,
+		Users:               user,
+		ContactName:         contactName,
+		EmailReceivedAfter:  emailReceivedAfter,
+		EmailReceivedBefore: emailReceivedBefore,
+		EmailSender:         emailSender,
+		EmailSubject:        emailSubject,
+		EventOrganizer:      eventOrganizer,
+		EventRecurs:         eventRecurs,
+		EventStartsAfter:    eventStartsAfter,
+		EventStartsBefore:   eventStartsBefore,
+		EventSubject:        eventSubject,
+
+		Populated: utils.GetPopulatedFlags(cmd),
+	}
+
+	if err := utils.ValidateExchangeRestoreFlags(backupID, opts); err != nil {
+		return err
+	}
+
+	exportDest, err := exportDestination(args[0])
+	if err != nil {
+		return err
+	}
+
+	exportDest.MailFormat, err = mailFormat()
+	if err != nil {
+		return err
+	}
+
+	s, a, err := config.GetStorageAndAccount(ctx, true, nil)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, err := repository.Connect(ctx, a, s, options.Control())
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	sel := selectors.NewExchangeRestore()
+	utils.IncludeExchangeRestoreDataSelectors(sel, opts)
+	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
+
+	// if no selector flags were specified, get all data in the service.
+	if len(sel.Scopes()) == 0 {
+		sel.Include(sel.Users(selectors.Any()))
+	}
+
+	eo, err := r.NewExport(ctx, backupID, sel.Selector, exportDest)
+	if err != nil {
+		return Only(ctx, errors.Wrap(err, "Failed to initialize Exchange export"))
+	}
+
+	ds, err := eo.Run(ctx)
+	if err != nil {
+		return Only(ctx, errors.Wrap(err, "Failed to run Exchange export"))
+	}
+
+	ds.PrintEntries(ctx)
+
+	return nil
+}
+
+// mailFormat validates the format flag.
+func mailFormat() (control.MailFormat, error) {
+	switch f := control.MailFormat(format); f {
+	case control.EMLFormat, control.MBOXFormat:
+		return f, nil
+	default:
+		return "", errors.Errorf(
+			"invalid value for --%s: %q; must be one of %s, %s",
+			formatFN, format, control.EMLFormat, control.MBOXFormat)
+	}
+}
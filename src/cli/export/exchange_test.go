@@ -0,0 +1,74 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type ExchangeSuite struct {
+	suite.Suite
+}
+
+func TestExchangeSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeSuite))
+}
+
+func (suite *ExchangeSuite) TestAddExchangeCommands() {
+	expectUse := exchangeServiceCommand + " " + exchangeServiceCommandUseSuffix
+
+	table := []struct {
+		name        string
+		use         string
+		expectUse   string
+		expectShort string
+		expectRunE  func(*cobra.Command, []string) error
+	}{
+		{"export exchange", exportCommand, expectUse, exchangeExportCmd().Short, exportExchangeCmd},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			cmd := &cobra.Command{Use: test.use}
+
+			c := addExchangeCommands(cmd)
+			require.NotNil(t, c)
+
+			cmds := cmd.Commands()
+			require.Len(t, cmds, 1)
+
+			child := cmds[0]
+			assert.Equal(t, test.expectUse, child.Use)
+			assert.Equal(t, test.expectShort, child.Short)
+			tester.AreSameFunc(t, test.expectRunE, child.RunE)
+		})
+	}
+}
+
+func (suite *ExchangeSuite) TestMailFormat() {
+	table := []struct {
+		input     string
+		expect    control.MailFormat
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{"eml", control.EMLFormat, assert.NoError},
+		{"mbox", control.MBOXFormat, assert.NoError},
+		{"", "", assert.Error},
+		{"pst", "", assert.Error},
+	}
+	for _, test := range table {
+		suite.T().Run(test.input, func(t *testing.T) {
+			format = test.input
+			defer func() { format = string(control.EMLFormat) }()
+
+			result, err := mailFormat()
+			test.expectErr(t, err)
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
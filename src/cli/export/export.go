@@ -0,0 +1,88 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+const (
+	archiveFN = "archive"
+
+	archiveZip = "zip"
+	archiveTar = "tar"
+)
+
+// common flags and flag attachers for commands
+var (
+	archive  string
+	backupID string
+)
+
+var exportCommands = []func(cmd *cobra.Command) *cobra.Command{
+	addExchangeCommands,
+	addOneDriveCommands,
+	addSharePointCommands,
+}
+
+// AddCommands attaches all `corso export * *` commands to the parent.
+func AddCommands(cmd *cobra.Command) {
+	exportC := exportCmd()
+	cmd.AddCommand(exportC)
+
+	for _, addExportTo := range exportCommands {
+		addExportTo(exportC)
+	}
+}
+
+const exportCommand = "export"
+
+// The export category of commands.
+// `corso export [<subcommand>] [<flag>...]`
+func exportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   exportCommand,
+		Short: "Export your service data",
+		Long:  `Export the data stored in one of your M365 services to the local filesystem.`,
+		RunE:  handleExportCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for flat calls to `corso export`.
+// Produces the same output as `corso export --help`.
+func handleExportCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// adds the flag that packs the export into a single archive file.
+func addArchiveFlag(fs *pflag.FlagSet) {
+	fs.StringVar(
+		&archive,
+		archiveFN, "",
+		"Write the export to a single archive file at the destination instead of a directory; "+
+			"accepts '"+archiveZip+"' or '"+archiveTar+"'.")
+}
+
+// exportDestination produces the destination for an export, validating
+// the archive flag.
+func exportDestination(p string) (control.ExportDestination, error) {
+	dest := control.ExportDestination{Path: p}
+
+	switch archive {
+	case "":
+		dest.Archive = control.NoArchive
+	case archiveZip:
+		dest.Archive = control.ZipArchive
+	case archiveTar:
+		dest.Archive = control.TarArchive
+	default:
+		return dest, errors.Errorf(
+			"invalid value for --%s: %q; must be one of %s, %s",
+			archiveFN, archive, archiveZip, archiveTar)
+	}
+
+	return dest, nil
+}
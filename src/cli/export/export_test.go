@@ -0,0 +1,48 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type ExportSuite struct {
+	suite.Suite
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
+
+func (suite *ExportSuite) TestExportDestination() {
+	table := []struct {
+		name          string
+		archive       string
+		expectArchive control.ArchiveFormat
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{"directory", "", control.NoArchive, assert.NoError},
+		{"zip", archiveZip, control.ZipArchive, assert.NoError},
+		{"tar", archiveTar, control.TarArchive, assert.NoError},
+		{"unknown", "rar", control.NoArchive, assert.Error},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			archive = test.archive
+			defer func() { archive = "" }()
+
+			dest, err := exportDestination("dir")
+			test.expectErr(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, "dir", dest.Path)
+			assert.Equal(t, test.expectArchive, dest.Archive)
+		})
+	}
+}
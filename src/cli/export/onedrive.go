@@ -0,0 +1,185 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alcionai/corso/src/cli/config"
+	"github.com/alcionai/corso/src/cli/options"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+var (
+	folderPaths []string
+	fileNames   []string
+	user        []string
+
+	fileCreatedAfter   string
+	fileCreatedBefore  string
+	fileModifiedAfter  string
+	fileModifiedBefore string
+)
+
+// called by export.go to map subcommands to provider-specific handling.
+func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
+	var (
+		c  *cobra.Command
+		fs *pflag.FlagSet
+	)
+
+	switch cmd.Use {
+	case exportCommand:
+		c, fs = utils.AddCommand(cmd, oneDriveExportCmd())
+
+		c.Use = c.Use + " " + oneDriveServiceCommandUseSuffix
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		// More generic (ex: --user) and more frequently used flags take precedence.
+		fs.SortFlags = false
+
+		fs.StringVar(&backupID,
+			utils.BackupFN, "",
+			"ID of the backup to export. (required)")
+		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+
+		fs.StringSliceVar(&user,
+			utils.UserFN, nil,
+			"Export data by user ID; accepts '"+utils.Wildcard+"' to select all users.")
+
+		// onedrive hierarchy (path/name) flags
+
+		fs.StringSliceVar(
+			&folderPaths,
+			utils.FolderFN, nil,
+			"Export items by OneDrive folder; defaults to root")
+
+		fs.StringSliceVar(
+			&fileNames,
+			utils.FileFN, nil,
+			"Export items by file name or ID")
+
+		// onedrive info flags
+
+		fs.StringVar(
+			&fileCreatedAfter,
+			utils.FileCreatedAfterFN, "",
+			"Export files created after this datetime")
+		fs.StringVar(
+			&fileCreatedBefore,
+			utils.FileCreatedBeforeFN, "",
+			"Export files created before this datetime")
+
+		fs.StringVar(
+			&fileModifiedAfter,
+			utils.FileModifiedAfterFN, "",
+			"Export files modified after this datetime")
+		fs.StringVar(
+			&fileModifiedBefore,
+			utils.FileModifiedBeforeFN, "",
+			"Export files modified before this datetime")
+
+		// export destination flags
+		addArchiveFlag(fs)
+
+		// others
+		options.AddOperationFlags(c)
+	}
+
+	return c
+}
+
+const (
+	oneDriveServiceCommand          = "onedrive"
+	oneDriveServiceCommandUseSuffix = "<destination> --backup <backupId>"
+
+	oneDriveServiceCommandExportExamples = `# Export all files in a backup to the directory ./onedrive-export
+corso export onedrive ./onedrive-export --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Export Alice's files in "Documents/Finance Reports" from a specific backup
+corso export onedrive ./onedrive-export --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --user alice@example.com --folder "Documents/Finance Reports"
+
+# Export all of Bob's files to a single zip file
+corso export onedrive ./bob.zip --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --user bob@example.com --archive zip`
+)
+
+// `corso export onedrive <destination> [<flag>...]`
+func oneDriveExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     oneDriveServiceCommand,
+		Short:   "Export M365 OneDrive service data",
+		RunE:    exportOneDriveCmd,
+		Args:    cobra.ExactArgs(1),
+		Example: oneDriveServiceCommandExportExamples,
+	}
+}
+
+// processes an onedrive service export.
+func exportOneDriveCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.OneDriveOpts{
+		Users:              user,
+		Paths:              folderPaths,
+		Names:              fileNames,
+		FileCreatedAfter:   fileCreatedAfter,
+		FileCreatedBefore:  fileCreatedBefore,
+		FileModifiedAfter:  fileModifiedAfter,
+		FileModifiedBefore: fileModifiedBefore,
+
+		Populated: utils.GetPopulatedFlags(cmd),
+	}
+
+	if err := utils.ValidateOneDriveRestoreFlags(backupID, opts); err != nil {
+		return err
+	}
+
+	exportDest, err := exportDestination(args[0])
+	if err != nil {
+		return err
+	}
+
+	s, a, err := config.GetStorageAndAccount(ctx, true, nil)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, err := repository.Connect(ctx, a, s, options.Control())
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	sel := selectors.NewOneDriveRestore()
+	utils.IncludeOneDriveRestoreDataSelectors(sel, opts)
+	utils.FilterOneDriveRestoreInfoSelectors(sel, opts)
+
+	// if no selector flags were specified, get all data in the service.
+	if len(sel.Scopes()) == 0 {
+		sel.Include(sel.Users(selectors.Any()))
+	}
+
+	eo, err := r.NewExport(ctx, backupID, sel.Selector, exportDest)
+	if err != nil {
+		return Only(ctx, errors.Wrap(err, "Failed to initialize OneDrive export"))
+	}
+
+	ds, err := eo.Run(ctx)
+	if err != nil {
+		return Only(ctx, errors.Wrap(err, "Failed to run OneDrive export"))
+	}
+
+	ds.PrintEntries(ctx)
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/alcionai/corso/src/cli/config"
+	"github.com/alcionai/corso/src/cli/options"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+var (
+	libraryItems []string
+	libraryPaths []string
+	site         []string
+	weburl       []string
+)
+
+// called by export.go to map subcommands to provider-specific handling.
+func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
+	var (
+		c  *cobra.Command
+		fs *pflag.FlagSet
+	)
+
+	switch cmd.Use {
+	case exportCommand:
+		c, fs = utils.AddCommand(cmd, sharePointExportCmd(), utils.HideCommand())
+
+		c.Use = c.Use + " " + sharePointServiceCommandUseSuffix
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		// More generic (ex: --site) and more frequently used flags take precedence.
+		fs.SortFlags = false
+
+		fs.StringVar(&backupID,
+			utils.BackupFN, "",
+			"ID of the backup to export. (required)")
+		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+
+		fs.StringSliceVar(&site,
+			utils.SiteFN, nil,
+			"Export data by site ID; accepts '"+utils.Wildcard+"' to select all sites.")
+
+		fs.StringSliceVar(&weburl,
+			utils.WebURLFN, nil,
+			"Export data by site webURL; accepts '"+utils.Wildcard+"' to select all sites.")
+
+		// sharepoint hierarchy (path/name) flags
+
+		fs.StringSliceVar(
+			&libraryPaths,
+			utils.LibraryFN, nil,
+			"Export library items by SharePoint library")
+
+		fs.StringSliceVar(
+			&libraryItems,
+			utils.LibraryItemFN, nil,
+			"Export library items by file name or ID")
+
+		// export destination flags
+		addArchiveFlag(fs)
+
+		// others
+		options.AddOperationFlags(c)
+	}
+
+	return c
+}
+
+const (
+	sharePointServiceCommand          = "sharepoint"
+	sharePointServiceCommandUseSuffix = "<destination> --backup <backupId>"
+
+	//nolint:lll
+	sharePointServiceCommandExportExamples = `# Export all library files in a backup to the directory ./sharepoint-export
+corso export sharepoint ./sharepoint-export --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Export <site>'s files in "Display Templates/Style Sheets" from a specific backup to a tar file
+corso export sharepoint ./site.tar --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --site <siteID> --library "Display Templates/Style Sheets" --archive tar`
+)
+
+// `corso export sharepoint <destination> [<flag>...]`
+func sharePointExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     sharePointServiceCommand,
+		Short:   "Export M365 SharePoint service data",
+		RunE:    exportSharePointCmd,
+		Args:    cobra.ExactArgs(1),
+		Example: sharePointServiceCommandExportExamples,
+	}
+}
+
+// processes a sharepoint service export.
+func exportSharePointCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.SharePointOpts{
+		LibraryItems: libraryItems,
+		LibraryPaths: libraryPaths,
+		Sites:        site,
+		WebURLs:      weburl,
+
+		Populated: utils.GetPopulatedFlags(cmd),
+	}
+
+	if err := utils.ValidateSharePointRestoreFlags(backupID, opts); err != nil {
+		return err
+	}
+
+	exportDest, err := exportDestination(args[0])
+	if err != nil {
+		return err
+	}
+
+	s, a, err := config.GetStorageAndAccount(ctx, true, nil)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, err := repository.Connect(ctx, a, s, options.Control())
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	sel := selectors.NewSharePointRestore()
+	utils.IncludeSharePointRestoreDataSelectors(sel, opts)
+	utils.FilterSharePointRestoreInfoSelectors(sel, opts)
+
+	// if no selector flags were specified, get all data in the service.
+	if len(sel.Scopes()) == 0 {
+		sel.Include(sel.Sites(selectors.Any()))
+	}
+
+	eo, err := r.NewExport(ctx, backupID, sel.Selector, exportDest)
+	if err != nil {
+		return Only(ctx, errors.Wrap(err, "Failed to initialize SharePoint export"))
+	}
+
+	ds, err := eo.Run(ctx)
+	if err != nil {
+		return Only(ctx, errors.Wrap(err, "Failed to run SharePoint export"))
+	}
+
+	ds.PrintEntries(ctx)
+
+	return nil
+}
@@ -69,7 +69,11 @@ var (
 	azureEVs = []envVar{
 		{azure, "AZURE_CLIENT_ID", "Client ID for your Azure AD application used to access your M365 tenant."},
 		{azure, "AZURE_TENANT_ID", "ID for the M365 tenant where the Azure AD application is registered."},
+		{azure, "AZURE_CLOUD", "Azure cloud hosting the M365 tenant: public (default), usgov-gcchigh, usgov-dod, or china."},
 		{azure, "AZURE_CLIENT_SECRET", "Azure secret for your Azure AD application used to access your M365 tenant."},
+		{azure, "AZURE_CLIENT_CERTIFICATE_PATH", "Path to a PEM or PFX certificate for your Azure AD application, " +
+			"used instead of a client secret."},
+		{azure, "AZURE_CLIENT_CERTIFICATE_PASSWORD", "Password for the Azure AD application certificate, if it's encrypted."},
 	}
 	awsEVs = []envVar{
 		{aws, "AWS_ACCESS_KEY_ID", "Access key for an IAM user or role for accessing an S3 bucket."},
@@ -1,22 +1,106 @@
 package options
 
 import (
+	"strings"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/pkg/control"
 )
 
 var (
-	fastFail bool
-	noStats  bool
+	collisions       string
+	fastFail         bool
+	noStats          bool
+	parallelBackups  int
+	parallelItems    int
+	maxParallelItems int
+	tags             []string
+)
+
+// collision policy flag values
+const (
+	CollisionCopy    = "copy"
+	CollisionSkip    = "skip"
+	CollisionReplace = "replace"
 )
 
 // AddOperationFlags adds command-local operation flags
 func AddOperationFlags(cmd *cobra.Command) {
 	fs := cmd.Flags()
-	fs.BoolVar(&fastFail, "fast-fail", false, "stop processing immediately if any error occurs")
-	// TODO: reveal this flag when fail-fast support is implemented
-	cobra.CheckErr(fs.MarkHidden("fast-fail"))
+	fs.BoolVar(
+		&fastFail,
+		"fast-fail",
+		false,
+		"Stop processing immediately if any item fails.  By default, failed items are skipped and recorded.")
+}
+
+// AddBackupFlags adds command-local backup flags
+func AddBackupFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.IntVar(
+		&parallelBackups,
+		"parallel", 0,
+		"max number of users or sites backed up at once (default 1)")
+	fs.StringSliceVar(
+		&tags,
+		utils.TagFN, nil,
+		"tag to attach to the backups, formatted as key=value; repeat to attach multiple tags")
+}
+
+// ValidateBackupFlags returns an error if the backup flags hold
+// unsupported values.
+func ValidateBackupFlags() error {
+	if parallelBackups < 0 {
+		return errors.New("parallel backup count must not be negative")
+	}
+
+	if _, err := utils.ParseTags(tags); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddRestoreFlags adds command-local restore flags
+func AddRestoreFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&collisions,
+		"collisions", CollisionCopy,
+		"how to handle items that already exist when restoring --"+utils.InPlaceFN+": "+
+			CollisionCopy+", "+CollisionSkip+", or "+CollisionReplace)
+	fs.IntVar(
+		&parallelItems,
+		"parallel-items", 0,
+		"max number of items restored at once into each user or site (default 4)")
+	fs.IntVar(
+		&maxParallelItems,
+		"max-parallel-items", 0,
+		"max number of items restored at once across all users or sites (default 16)")
+}
+
+// ValidateRestoreFlags returns an error if the restore flags hold
+// unsupported values.  Collisions only happen when restoring in place,
+// so the skip and replace policies require an in place restore.
+func ValidateRestoreFlags(inPlace bool) error {
+	switch strings.ToLower(collisions) {
+	case CollisionCopy:
+	case CollisionSkip, CollisionReplace:
+		if !inPlace {
+			return errors.New("--collisions " + collisions + " requires --" + utils.InPlaceFN)
+		}
+	default:
+		return errors.New("invalid collisions value: " + collisions)
+	}
+
+	if parallelItems < 0 || maxParallelItems < 0 {
+		return errors.New("parallel item counts must not be negative")
+	}
+
+	return nil
 }
 
 // AddGlobalOperationFlags adds the global operations flag set.
@@ -37,5 +121,31 @@ func Control() control.Options {
 		opt.DisableMetrics = true
 	}
 
+	if parallelBackups > 0 {
+		opt.BackupParallelism = parallelBackups
+	}
+
+	// malformed tags are rejected by ValidateBackupFlags
+	if ts, err := utils.ParseTags(tags); err == nil && len(ts) > 0 {
+		opt.Tags = ts
+	}
+
+	if parallelItems > 0 {
+		opt.RestoreParallelism.PerResourceOwner = parallelItems
+	}
+
+	if maxParallelItems > 0 {
+		opt.RestoreParallelism.Global = maxParallelItems
+	}
+
+	switch strings.ToLower(collisions) {
+	case CollisionSkip:
+		opt.Collision = control.Skip
+	case CollisionReplace:
+		opt.Collision = control.Replace
+	default:
+		opt.Collision = control.Copy
+	}
+
 	return opt
 }
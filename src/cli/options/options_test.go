@@ -0,0 +1,41 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type OptionsUnitSuite struct {
+	suite.Suite
+}
+
+func TestOptionsUnitSuite(t *testing.T) {
+	suite.Run(t, new(OptionsUnitSuite))
+}
+
+func (suite *OptionsUnitSuite) TestValidateRestoreFlags() {
+	table := []struct {
+		name       string
+		collisions string
+		inPlace    bool
+		expect     assert.ErrorAssertionFunc
+	}{
+		{"copy", CollisionCopy, false, assert.NoError},
+		{"copy in place", CollisionCopy, true, assert.NoError},
+		{"skip in place", CollisionSkip, true, assert.NoError},
+		{"replace in place", CollisionReplace, true, assert.NoError},
+		{"skip", CollisionSkip, false, assert.Error},
+		{"replace", CollisionReplace, false, assert.Error},
+		{"invalid", "fnords", true, assert.Error},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			collisions = test.collisions
+			defer func() { collisions = CollisionCopy }()
+
+			test.expect(t, ValidateRestoreFlags(test.inPlace))
+		})
+	}
+}
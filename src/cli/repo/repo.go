@@ -1,12 +1,20 @@
 package repo
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/config"
+	"github.com/alcionai/corso/src/cli/options"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/repository"
 )
 
 const (
-	initCommand    = "init"
-	connectCommand = "connect"
+	initCommand        = "init"
+	connectCommand     = "connect"
+	maintenanceCommand = "maintenance"
 )
 
 var repoCommands = []func(cmd *cobra.Command) *cobra.Command{
@@ -26,6 +34,7 @@ func AddCommands(cmd *cobra.Command) {
 	cmd.AddCommand(repoCmd)
 	repoCmd.AddCommand(initCmd)
 	repoCmd.AddCommand(connectCmd)
+	repoCmd.AddCommand(maintenanceCmd())
 
 	for _, addRepoTo := range repoCommands {
 		addRepoTo(initCmd)
@@ -84,3 +93,47 @@ func connectCmd() *cobra.Command {
 func handleConnectCmd(cmd *cobra.Command, args []string) error {
 	return cmd.Help()
 }
+
+// The repo maintenance subcommand.
+// `corso repo maintenance`
+func maintenanceCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   maintenanceCommand,
+		Short: "Run maintenance on the connected repository.",
+		Long: `Extend the object lock on the repository data for another full retention period.
+Repositories initialized with object lock must run maintenance more often than the retention
+period, so that the data still used by backups stays locked.
+
+Every object in the repository is locked again, including the data only used by deleted
+backups, and each object costs one S3 request.  In compliance mode, the storage used by
+deleted backups is never freed.`,
+		RunE: handleMaintenanceCmd,
+		Args: cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso repo maintenance`.
+func handleMaintenanceCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	s, acct, err := config.GetStorageAndAccount(ctx, true, nil)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, err := repository.Connect(ctx, acct, s, options.Control())
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to connect to the %s repository", s.Provider))
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	n, err := r.ExtendRetention(ctx)
+	if err != nil {
+		return Only(ctx, errors.Wrap(err, "Failed to extend the object lock retention"))
+	}
+
+	Infof(ctx, "Extended the object lock retention of %d repository objects.", n)
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type RepoSuite struct {
+	suite.Suite
+}
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoSuite))
+}
+
+func (suite *RepoSuite) TestAddCommands_maintenance() {
+	t := suite.T()
+	cmd := &cobra.Command{Use: "corso"}
+
+	AddCommands(cmd)
+
+	child, _, err := cmd.Find([]string{"repo", maintenanceCommand})
+	require.NoError(t, err)
+
+	assert.Equal(t, maintenanceCommand, child.Use)
+	assert.Equal(t, maintenanceCmd().Short, child.Short)
+	tester.AreSameFunc(t, handleMaintenanceCmd, child.RunE)
+}
@@ -2,6 +2,8 @@ package repo
 
 import (
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -12,6 +14,7 @@ import (
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/repository"
 	"github.com/alcionai/corso/src/pkg/storage"
 )
@@ -24,6 +27,15 @@ var (
 	doNotUseTLS     bool
 	doNotVerifyTLS  bool
 	succeedIfExists bool
+
+	// object lock settings, only used by init
+	objectLockMode      string
+	objectLockRetention time.Duration
+)
+
+const (
+	objectLockModeFN      = "object-lock-mode"
+	objectLockRetentionFN = "object-lock-retention"
 )
 
 // called by repo.go to map subcommands to provider-specific handling.
@@ -52,6 +64,18 @@ func addS3Commands(cmd *cobra.Command) *cobra.Command {
 	fs.BoolVar(&doNotUseTLS, "disable-tls", false, "Disable TLS (HTTPS)")
 	fs.BoolVar(&doNotVerifyTLS, "disable-tls-verification", false, "Disable TLS (HTTPS) certificate verification.")
 
+	if cmd.Use == initCommand {
+		fs.StringVar(
+			&objectLockMode,
+			objectLockModeFN, "",
+			"Enable S3 Object Lock on the repo data, in governance or compliance mode.  "+
+				"The bucket must have object lock enabled.")
+		fs.DurationVar(
+			&objectLockRetention,
+			objectLockRetentionFN, 0,
+			"How long the repo data stays locked after it's written, such as 720h.  At least 24h.")
+	}
+
 	// In general, we don't want to expose this flag to users and have them mistake it
 	// for a broad-scale idempotency solution.  We can un-hide it later the need arises.
 	fs.BoolVar(&succeedIfExists, "succeed-if-exists", false, "Exit with success if the repo has already been initialized.")
@@ -73,7 +97,10 @@ corso repo init s3 --bucket my-bucket
 corso repo init s3 --bucket my-bucket --prefix my-prefix
 
 # Create a new Corso repo in an S3 compliant storage provider
-corso repo init s3 --bucket my-bucket --endpoint https://my-s3-server-endpoint`
+corso repo init s3 --bucket my-bucket --endpoint https://my-s3-server-endpoint
+
+# Create a new Corso repo whose data can't be deleted by anyone for 30 days
+corso repo init s3 --bucket my-locked-bucket --object-lock-mode compliance --object-lock-retention 720h`
 
 	s3ProviderCommandConnectExamples = `# Connect to a Corso repo in AWS S3 bucket named "my-bucket"
 corso repo connect s3 --bucket my-bucket
@@ -109,6 +136,15 @@ func initS3Cmd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	ol := control.ObjectLock{
+		Mode:      control.ObjectLockMode(strings.ToLower(objectLockMode)),
+		Retention: objectLockRetention,
+	}
+
+	if err := ol.Validate(); err != nil {
+		return Only(ctx, err)
+	}
+
 	s, a, err := config.GetStorageAndAccount(ctx, false, s3Overrides())
 	if err != nil {
 		return Only(ctx, err)
@@ -124,7 +160,7 @@ func initS3Cmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, errors.Wrap(err, "Failed to parse m365 account config"))
 	}
 
-	r, err := repository.Initialize(ctx, a, s, options.Control())
+	r, err := repository.Initialize(ctx, a, s, options.Control(), ol)
 	if err != nil {
 		if succeedIfExists && errors.Is(err, repository.ErrorRepoAlreadyExists) {
 			return nil
@@ -137,6 +173,14 @@ func initS3Cmd(cmd *cobra.Command, args []string) error {
 
 	Infof(ctx, "Initialized a S3 repository within bucket %s.", s3Cfg.Bucket)
 
+	if ol.Enabled() {
+		Infof(
+			ctx,
+			"Repository data is locked in %s mode for %s after it's written.  "+
+				"Run `corso repo %s` more often than that to keep the data in use locked.",
+			ol.Mode, ol.Retention, maintenanceCommand)
+	}
+
 	if err = config.WriteRepoConfig(ctx, s3Cfg, m365); err != nil {
 		return Only(ctx, errors.Wrap(err, "Failed to write repository configuration"))
 	}
@@ -175,7 +175,7 @@ func (suite *S3IntegrationSuite) TestConnectS3Cmd() {
 			ctx = config.SetViper(ctx, vpr)
 
 			// init the repo first
-			_, err = repository.Initialize(ctx, account.Account{}, st, control.Options{})
+			_, err = repository.Initialize(ctx, account.Account{}, st, control.Options{}, control.ObjectLock{})
 			require.NoError(t, err)
 
 			// then test it
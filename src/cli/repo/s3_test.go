@@ -46,6 +46,11 @@ func (suite *S3Suite) TestAddS3Commands() {
 			assert.Equal(t, test.expectUse, child.Use)
 			assert.Equal(t, test.expectShort, child.Short)
 			tester.AreSameFunc(t, test.expectRunE, child.RunE)
+
+			// object lock is only configured when initializing a repo
+			for _, fn := range []string{objectLockModeFN, objectLockRetentionFN} {
+				assert.Equal(t, test.use == initCommand, child.Flags().Lookup(fn) != nil, fn)
+			}
 		})
 	}
 }
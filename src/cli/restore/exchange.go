@@ -10,7 +10,6 @@ import (
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/common"
-	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/repository"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
@@ -56,10 +55,7 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		// More generic (ex: --user) and more frequently used flags take precedence.
 		// general flags
-		fs.StringVar(&backupID,
-			utils.BackupFN, "",
-			"ID of the backup to restore. (required)")
-		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+		addRestoreSourceFlags(fs)
 
 		fs.StringSliceVar(&user,
 			utils.UserFN, nil,
@@ -133,6 +129,11 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 			utils.ContactNameFN, "",
 			"Restore contacts whose contact name contains this value.")
 
+		// restore destination flags
+		addRestoreDestinationFlags(fs)
+		addDestinationUserFlag(fs)
+		options.AddRestoreFlags(c)
+
 		// others
 		options.AddOperationFlags(c)
 	}
@@ -142,7 +143,7 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 
 const (
 	exchangeServiceCommand          = "exchange"
-	exchangeServiceCommandUseSuffix = "--backup <backupId>"
+	exchangeServiceCommandUseSuffix = "--backup <backupId> | --point-in-time <datetime>"
 
 	exchangeServiceCommandRestoreExamples = `# Restore emails with ID 98765abcdef and 12345abcdef from a specific backup
 corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --email 98765abcdef,12345abcdef
@@ -156,7 +157,18 @@ corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
       --user bob@example.com --event-calendar Calendar
 
 # Restore contact with ID abdef0101 from a specific backup
-corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --contact abdef0101`
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --contact abdef0101
+
+# Restore Alice's deleted "Projects" folder back into its original location
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --user alice@example.com --email-folder Projects --in-place --collisions skip
+
+# Restore Alice's entire mailbox into Bob's account
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --user alice@example.com --destination-user bob@example.com
+
+# Restore Alice's and Bob's mailboxes as they were at 3am, each from its latest backup by then
+corso restore exchange --point-in-time 2022-10-04T03:00:00Z --user alice@example.com,bob@example.com`
 )
 
 // `corso restore exchange [<flag>...]`
@@ -200,7 +212,15 @@ func restoreExchangeCmd(cmd *cobra.Command, args []string) error {
 		Populated: utils.GetPopulatedFlags(cmd),
 	}
 
-	if err := utils.ValidateExchangeRestoreFlags(backupID, opts); err != nil {
+	if err := validateRestoreSource(); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateExchangeFilterFlags(opts); err != nil {
+		return err
+	}
+
+	if err := options.ValidateRestoreFlags(inPlace); err != nil {
 		return err
 	}
 
@@ -216,28 +236,25 @@ func restoreExchangeCmd(cmd *cobra.Command, args []string) error {
 
 	defer utils.CloseRepo(ctx, r)
 
-	sel := selectors.NewExchangeRestore()
-	utils.IncludeExchangeRestoreDataSelectors(sel, opts)
-	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
-
-	// if no selector flags were specified, get all data in the service.
-	if len(sel.Scopes()) == 0 {
-		sel.Include(sel.Users(selectors.Any()))
-	}
+	selFor := func(excluded []string) selectors.Selector {
+		sel := selectors.NewExchangeRestore()
+		utils.IncludeExchangeRestoreDataSelectors(sel, opts)
+		utils.FilterExchangeRestoreInfoSelectors(sel, opts)
 
-	restoreDest := control.DefaultRestoreDestination(common.SimpleDateTime)
+		// if no selector flags were specified, get all data in the service.
+		if len(sel.Scopes()) == 0 {
+			sel.Include(sel.Users(selectors.Any()))
+		}
 
-	ro, err := r.NewRestore(ctx, backupID, sel.Selector, restoreDest)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Failed to initialize Exchange restore"))
-	}
+		// leaves out the resource owners restored from other backups.
+		if len(excluded) > 0 {
+			sel.Exclude(sel.Users(excluded))
+		}
 
-	ds, err := ro.Run(ctx)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Failed to run Exchange restore"))
+		return sel.Selector
 	}
 
-	ds.PrintEntries(ctx)
+	restoreDest := restoreDestination(common.SimpleDateTime, destinationUser)
 
-	return nil
+	return runRestores(ctx, r, "Exchange", selFor, restoreDest)
 }
@@ -83,7 +83,7 @@ func (suite *RestoreExchangeIntegrationSuite) SetupSuite() {
 	suite.m365UserID = tester.M365UserID(t)
 
 	// init the repo first
-	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{})
+	suite.repo, err = repository.Initialize(ctx, suite.acct, suite.st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 
 	suite.backupOps = make(map[path.CategoryType]operations.BackupOperation)
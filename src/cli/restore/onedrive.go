@@ -10,7 +10,6 @@ import (
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/common"
-	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/repository"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
@@ -42,10 +41,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		// More generic (ex: --user) and more frequently used flags take precedence.
 		fs.SortFlags = false
 
-		fs.StringVar(&backupID,
-			utils.BackupFN, "",
-			"ID of the backup to restore. (required)")
-		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+		addRestoreSourceFlags(fs)
 
 		fs.StringSliceVar(&user,
 			utils.UserFN, nil,
@@ -83,6 +79,11 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 			utils.FileModifiedBeforeFN, "",
 			"Restore files modified before this datetime")
 
+		// restore destination flags
+		addRestoreDestinationFlags(fs)
+		addDestinationUserFlag(fs)
+		options.AddRestoreFlags(c)
+
 		// others
 		options.AddOperationFlags(c)
 	}
@@ -92,7 +93,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 
 const (
 	oneDriveServiceCommand          = "onedrive"
-	oneDriveServiceCommandUseSuffix = "--backup <backupId>"
+	oneDriveServiceCommandUseSuffix = "--backup <backupId> | --point-in-time <datetime>"
 
 	oneDriveServiceCommandRestoreExamples = `# Restore file with ID 98765abcdef
 corso restore onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd --file 98765abcdef
@@ -103,7 +104,14 @@ corso restore onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
 
 # Restore all files from Bob's folder that were created before 2020 when captured in a specific backup
 corso restore onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd 
-      --user bob@example.com --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00`
+      --user bob@example.com --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00
+
+# Restore all of Alice's files into Bob's OneDrive
+corso restore onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
+      --user alice@example.com --destination-user bob@example.com
+
+# Restore every user's files as they were at 3am, each from its latest backup by then
+corso restore onedrive --point-in-time 2022-10-04T03:00:00Z`
 )
 
 // `corso restore onedrive [<flag>...]`
@@ -137,7 +145,15 @@ func restoreOneDriveCmd(cmd *cobra.Command, args []string) error {
 		Populated: utils.GetPopulatedFlags(cmd),
 	}
 
-	if err := utils.ValidateOneDriveRestoreFlags(backupID, opts); err != nil {
+	if err := validateRestoreSource(); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateOneDriveFilterFlags(opts); err != nil {
+		return err
+	}
+
+	if err := options.ValidateRestoreFlags(inPlace); err != nil {
 		return err
 	}
 
@@ -153,28 +169,25 @@ func restoreOneDriveCmd(cmd *cobra.Command, args []string) error {
 
 	defer utils.CloseRepo(ctx, r)
 
-	sel := selectors.NewOneDriveRestore()
-	utils.IncludeOneDriveRestoreDataSelectors(sel, opts)
-	utils.FilterOneDriveRestoreInfoSelectors(sel, opts)
+	selFor := func(excluded []string) selectors.Selector {
+		sel := selectors.NewOneDriveRestore()
+		utils.IncludeOneDriveRestoreDataSelectors(sel, opts)
+		utils.FilterOneDriveRestoreInfoSelectors(sel, opts)
 
-	// if no selector flags were specified, get all data in the service.
-	if len(sel.Scopes()) == 0 {
-		sel.Include(sel.Users(selectors.Any()))
-	}
+		// if no selector flags were specified, get all data in the service.
+		if len(sel.Scopes()) == 0 {
+			sel.Include(sel.Users(selectors.Any()))
+		}
 
-	restoreDest := control.DefaultRestoreDestination(common.SimpleDateTimeOneDrive)
+		// leaves out the resource owners restored from other backups.
+		if len(excluded) > 0 {
+			sel.Exclude(sel.Users(excluded))
+		}
 
-	ro, err := r.NewRestore(ctx, backupID, sel.Selector, restoreDest)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Failed to initialize OneDrive restore"))
-	}
-
-	ds, err := ro.Run(ctx)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Failed to run OneDrive restore"))
+		return sel.Selector
 	}
 
-	ds.PrintEntries(ctx)
+	restoreDest := restoreDestination(common.SimpleDateTimeOneDrive, destinationUser)
 
-	return nil
+	return runRestores(ctx, r, "OneDrive", selFor, restoreDest)
 }
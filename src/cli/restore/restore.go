@@ -1,9 +1,36 @@
 package restore
 
 import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/common"
+	"github.com/alcionai/corso/src/internal/operations"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// restore destination flags
+var (
+	destinationSite string
+	destinationUser string
+	inPlace         bool
 )
 
+// restore source flags
+var pointInTime string
+
 var restoreCommands = []func(cmd *cobra.Command) *cobra.Command{
 	addExchangeCommands,
 	addOneDriveCommands,
@@ -39,3 +66,275 @@ func restoreCmd() *cobra.Command {
 func handleRestoreCmd(cmd *cobra.Command, args []string) error {
 	return cmd.Help()
 }
+
+// adds the flags that control where restored data is written.
+func addRestoreDestinationFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(
+		&inPlace,
+		utils.InPlaceFN, false,
+		"Restore items into their original location instead of a new "+
+			"folder; missing parent folders are recreated.")
+}
+
+// adds the flag that redirects a restore to a different user.
+func addDestinationUserFlag(fs *pflag.FlagSet) {
+	fs.StringVar(
+		&destinationUser,
+		utils.DestinationUserFN, "",
+		"Restore data into this user's account instead of the account it was backed up from.")
+}
+
+// adds the flag that redirects a restore to a different site.
+func addDestinationSiteFlag(fs *pflag.FlagSet) {
+	fs.StringVar(
+		&destinationSite,
+		utils.DestinationSiteFN, "",
+		"Restore data into this site ID instead of the site it was backed up from.")
+}
+
+// restoreDestination produces the destination for a restore: either the
+// original location of each item, or a new timestamped root folder.
+// A non-empty resourceOwner redirects all restored items to that owner.
+func restoreDestination(timeFormat common.TimeFormat, resourceOwner string) control.RestoreDestination {
+	dest := control.DefaultRestoreDestination(timeFormat)
+	if inPlace {
+		dest = control.InPlaceRestoreDestination()
+	}
+
+	dest.ResourceOwnerOverride = resourceOwner
+
+	return dest
+}
+
+// adds the flags that pick the backup to restore from: either a backup ID,
+// or a point in time.
+func addRestoreSourceFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&backupID,
+		utils.BackupFN, "",
+		"ID of the backup to restore. (required, unless --"+utils.PointInTimeFN+" is given)")
+	fs.StringVar(&pointInTime,
+		utils.PointInTimeFN, "",
+		"Restore each resource owner from its latest completed backup at or before this datetime.")
+}
+
+// validateRestoreSource checks that exactly one of the backup ID or the
+// point in time was given.
+func validateRestoreSource() error {
+	if len(backupID) > 0 && len(pointInTime) > 0 {
+		return errors.New("only one of a backup ID or a point in time can be given")
+	}
+
+	if len(pointInTime) > 0 {
+		if !utils.IsValidTimeFormat(pointInTime) {
+			return errors.New("invalid time format for point-in-time")
+		}
+
+		return nil
+	}
+
+	if len(backupID) == 0 {
+		return errors.New("a backup ID or a point in time is required")
+	}
+
+	return nil
+}
+
+// selectorExcluding produces the restore selector with the data of the
+// excluded resource owners removed.
+type selectorExcluding func(excluded []string) selectors.Selector
+
+// runRestores restores the selected data from the backup given by --backup,
+// or from the backups picked for the --point-in-time.
+func runRestores(
+	ctx context.Context,
+	r repository.Repository,
+	serviceName string,
+	selFor selectorExcluding,
+	dest control.RestoreDestination,
+) error {
+	if len(pointInTime) == 0 {
+		return runRestore(ctx, r, backupID, serviceName, selFor(nil), dest)
+	}
+
+	// validated by validateRestoreSource
+	at, _ := common.ParseTime(pointInTime)
+
+	pitrs, err := pointInTimeRestores(ctx, r, selFor(nil), at)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	for _, pitr := range pitrs {
+		owners := "all other resource owners"
+		if len(pitr.owners) > 0 {
+			owners = strings.Join(pitr.owners, ", ")
+		}
+
+		Infof(
+			ctx,
+			"Restoring %s from backup %s, started at %s",
+			owners,
+			pitr.backup.ID,
+			common.FormatTabularDisplayTime(pitr.backup.StartedAt))
+
+		if err := runRestore(ctx, r, string(pitr.backup.ID), serviceName, selFor(pitr.excluded), dest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runRestore restores the selected data from a single backup.
+func runRestore(
+	ctx context.Context,
+	r repository.Repository,
+	bID, serviceName string,
+	sel selectors.Selector,
+	dest control.RestoreDestination,
+) error {
+	ro, err := r.NewRestore(ctx, bID, sel, dest)
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to initialize %s restore", serviceName))
+	}
+
+	ds, err := ro.Run(ctx)
+	if err != nil {
+		return Only(ctx, errors.Wrapf(err, "Failed to run %s restore", serviceName))
+	}
+
+	ds.PrintEntries(ctx)
+
+	return nil
+}
+
+// pointInTimeRestore restores the data of the owners from one of the
+// backups picked for a point in time.  The data of the owners restored
+// from the other backups is excluded.
+type pointInTimeRestore struct {
+	backup *backup.Backup
+	// owners is nil when the backup holds every remaining owner.
+	owners   []string
+	excluded []string
+}
+
+// pointInTimeRestores picks, for each resource owner included by the
+// selector, the latest completed backup of the selector's service that
+// started at or before the point in time.
+func pointInTimeRestores(
+	ctx context.Context,
+	r repository.BackupGetter,
+	sel selectors.Selector,
+	at time.Time,
+) ([]pointInTimeRestore, error) {
+	bs, err := r.BackupsByTag(ctx, store.Service(sel.PathService()))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list backups")
+	}
+
+	ros, err := sel.ResourceOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	// wanted maps the lower-cased owners to the names given for them, since
+	// owners match regardless of case, like selectors do.  A nil set of
+	// wanted owners wants every owner.
+	var wanted map[string]string
+
+	if !ros.IncludesAny && len(ros.Includes) > 0 {
+		wanted = map[string]string{}
+
+		for _, o := range ros.Includes {
+			wanted[strings.ToLower(o)] = o
+		}
+	}
+
+	candidates := []*backup.Backup{}
+
+	for _, b := range bs {
+		if b.Status == operations.Completed.String() && !b.StartedAt.After(at) {
+			candidates = append(candidates, b)
+		}
+	}
+
+	// newest first
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].StartedAt.After(candidates[j].StartedAt)
+	})
+
+	var (
+		pitrs     = []pointInTimeRestore{}
+		assigned  = map[string]struct{}{}
+		coversAll bool
+	)
+
+	for _, b := range candidates {
+		if wanted != nil && len(assigned) == len(wanted) {
+			break
+		}
+
+		owners, all := b.ResourceOwners()
+		if all {
+			pitrs = append(pitrs, pointInTimeRestore{backup: b})
+			coversAll = true
+
+			break
+		}
+
+		picked := []string{}
+
+		for _, o := range owners {
+			lo := strings.ToLower(o)
+
+			if _, ok := assigned[lo]; ok {
+				continue
+			}
+
+			if _, ok := wanted[lo]; wanted != nil && !ok {
+				continue
+			}
+
+			assigned[lo] = struct{}{}
+			picked = append(picked, o)
+		}
+
+		if len(picked) > 0 {
+			pitrs = append(pitrs, pointInTimeRestore{backup: b, owners: picked})
+		}
+	}
+
+	if len(pitrs) == 0 {
+		return nil, errors.Errorf("no completed backups at or before %s", common.FormatTime(at))
+	}
+
+	if !coversAll {
+		missing := []string{}
+
+		for lo, o := range wanted {
+			if _, ok := assigned[lo]; !ok {
+				missing = append(missing, o)
+			}
+		}
+
+		if len(missing) > 0 {
+			sort.Strings(missing)
+
+			return nil, errors.Errorf(
+				"no completed backups of %s at or before %s",
+				strings.Join(missing, ", "),
+				common.FormatTime(at))
+		}
+	}
+
+	// each backup only restores the owners it was picked for.
+	for i := range pitrs {
+		for j, other := range pitrs {
+			if i != j {
+				pitrs[i].excluded = append(pitrs[i].excluded, other.owners...)
+			}
+		}
+	}
+
+	return pitrs, nil
+}
@@ -0,0 +1,198 @@
+package restore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/utils/testdata"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/operations"
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+type RestoreSuite struct {
+	suite.Suite
+}
+
+func TestRestoreSuite(t *testing.T) {
+	suite.Run(t, new(RestoreSuite))
+}
+
+func (suite *RestoreSuite) TestValidateRestoreSource() {
+	table := []struct {
+		name        string
+		backupID    string
+		pointInTime string
+		expect      assert.ErrorAssertionFunc
+	}{
+		{"backup ID", "bid", "", assert.NoError},
+		{"point in time", "", "2022-10-04T03:00:00Z", assert.NoError},
+		{"neither", "", "", assert.Error},
+		{"both", "bid", "2022-10-04T03:00:00Z", assert.Error},
+		{"bad point in time", "", "3am", assert.Error},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			backupID, pointInTime = test.backupID, test.pointInTime
+			defer func() { backupID, pointInTime = "", "" }()
+
+			test.expect(t, validateRestoreSource())
+		})
+	}
+}
+
+type mockBackupLister struct {
+	*testdata.MockBackupGetter
+	bs []*backup.Backup
+}
+
+func (bl mockBackupLister) BackupsByTag(context.Context, ...store.FilterOption) ([]*backup.Backup, error) {
+	return bl.bs, nil
+}
+
+func stubBackup(id, status string, started time.Time, owners ...string) *backup.Backup {
+	sel := selectors.NewExchangeBackup()
+	sel.Include(sel.Users(owners))
+
+	return &backup.Backup{
+		BaseModel:       model.BaseModel{ID: model.StableID(id)},
+		Status:          status,
+		Selectors:       sel.Selector,
+		StartAndEndTime: stats.StartAndEndTime{StartedAt: started},
+	}
+}
+
+func (suite *RestoreSuite) TestPointInTimeRestores() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	var (
+		completed = operations.Completed.String()
+		at        = time.Date(2022, 10, 4, 3, 0, 0, 0, time.UTC)
+		hour      = time.Hour
+	)
+
+	bl := mockBackupLister{
+		bs: []*backup.Backup{
+			stubBackup("all-old", completed, at.Add(-48*hour), selectors.Any()...),
+			stubBackup("a-old", completed, at.Add(-3*hour), "a"),
+			stubBackup("ab", completed, at.Add(-2*hour), "a", "b"),
+			stubBackup("a-failed", operations.Failed.String(), at.Add(-hour), "a"),
+			stubBackup("c", completed, at.Add(-hour), "c"),
+			stubBackup("a-later", completed, at.Add(hour), "a"),
+		},
+	}
+
+	table := []struct {
+		name      string
+		owners    []string
+		at        time.Time
+		expect    []pointInTimeRestore
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "one owner",
+			owners: []string{"a"},
+			at:     at,
+			expect: []pointInTimeRestore{
+				{backup: bl.bs[2], owners: []string{"a"}},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:   "owners from different backups",
+			owners: []string{"a", "c"},
+			at:     at,
+			expect: []pointInTimeRestore{
+				{backup: bl.bs[4], owners: []string{"c"}, excluded: []string{"a"}},
+				{backup: bl.bs[2], owners: []string{"a"}, excluded: []string{"c"}},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:   "owners in another case",
+			owners: []string{"A", "C"},
+			at:     at,
+			expect: []pointInTimeRestore{
+				{backup: bl.bs[4], owners: []string{"c"}, excluded: []string{"a"}},
+				{backup: bl.bs[2], owners: []string{"a"}, excluded: []string{"c"}},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:   "every owner",
+			owners: selectors.Any(),
+			at:     at,
+			expect: []pointInTimeRestore{
+				{backup: bl.bs[4], owners: []string{"c"}, excluded: []string{"a", "b"}},
+				{backup: bl.bs[2], owners: []string{"a", "b"}, excluded: []string{"c"}},
+				{backup: bl.bs[0], excluded: []string{"c", "a", "b"}},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:   "owner only in the backup of every owner",
+			owners: []string{"d"},
+			at:     at,
+			expect: []pointInTimeRestore{
+				{backup: bl.bs[0]},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "before the first backup",
+			owners:    []string{"a"},
+			at:        at.Add(-72 * hour),
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			sel := selectors.NewExchangeRestore()
+			sel.Include(sel.Users(test.owners))
+
+			pitrs, err := pointInTimeRestores(ctx, bl, sel.Selector, test.at)
+			test.expectErr(t, err)
+
+			if err != nil {
+				return
+			}
+
+			require.Len(t, pitrs, len(test.expect))
+
+			for i, e := range test.expect {
+				assert.Equal(t, e.backup.ID, pitrs[i].backup.ID, "backup")
+				assert.ElementsMatch(t, e.owners, pitrs[i].owners, "owners")
+				assert.ElementsMatch(t, e.excluded, pitrs[i].excluded, "excluded")
+			}
+		})
+	}
+}
+
+func (suite *RestoreSuite) TestPointInTimeRestores_missingOwner() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	t := suite.T()
+	at := time.Date(2022, 10, 4, 3, 0, 0, 0, time.UTC)
+	bl := mockBackupLister{
+		bs: []*backup.Backup{
+			stubBackup("a", operations.Completed.String(), at.Add(-time.Hour), "a"),
+		},
+	}
+
+	sel := selectors.NewExchangeRestore()
+	sel.Include(sel.Users([]string{"a", "b"}))
+
+	_, err := pointInTimeRestores(ctx, bl, sel.Selector, at)
+	assert.ErrorContains(t, err, "no completed backups of b")
+}
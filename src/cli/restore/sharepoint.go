@@ -10,7 +10,6 @@ import (
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/common"
-	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/repository"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
@@ -39,10 +38,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		// More generic (ex: --site) and more frequently used flags take precedence.
 		fs.SortFlags = false
 
-		fs.StringVar(&backupID,
-			utils.BackupFN, "",
-			"ID of the backup to restore. (required)")
-		cobra.CheckErr(c.MarkFlagRequired(utils.BackupFN))
+		addRestoreSourceFlags(fs)
 
 		fs.StringSliceVar(&site,
 			utils.SiteFN, nil,
@@ -71,6 +67,11 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		// 	utils.FileCreatedAfterFN, "",
 		// 	"Restore files created after this datetime")
 
+		// restore destination flags
+		addRestoreDestinationFlags(fs)
+		addDestinationSiteFlag(fs)
+		options.AddRestoreFlags(c)
+
 		// others
 		options.AddOperationFlags(c)
 	}
@@ -80,7 +81,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 
 const (
 	sharePointServiceCommand          = "sharepoint"
-	sharePointServiceCommandUseSuffix = "--backup <backupId>"
+	sharePointServiceCommandUseSuffix = "--backup <backupId> | --point-in-time <datetime>"
 
 	//nolint:lll
 	sharePointServiceCommandRestoreExamples = `# Restore file with ID 98765abcdef
@@ -92,7 +93,10 @@ corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
 
 # Restore all files from <site> that were created before 2020 when captured in a specific backup
 corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd 
-      --site <siteID> --folder "Display Templates/Style Sheets" --file-created-before 2020-01-01T00:00:00`
+      --site <siteID> --folder "Display Templates/Style Sheets" --file-created-before 2020-01-01T00:00:00
+
+# Restore the site as it was at 3am, from its latest backup by then
+corso restore sharepoint --point-in-time 2022-10-04T03:00:00Z --site <siteID>`
 )
 
 // `corso restore sharepoint [<flag>...]`
@@ -124,7 +128,15 @@ func restoreSharePointCmd(cmd *cobra.Command, args []string) error {
 		Populated: utils.GetPopulatedFlags(cmd),
 	}
 
-	if err := utils.ValidateSharePointRestoreFlags(backupID, opts); err != nil {
+	if err := validateRestoreSource(); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateSharePointFilterFlags(opts); err != nil {
+		return err
+	}
+
+	if err := options.ValidateRestoreFlags(inPlace); err != nil {
 		return err
 	}
 
@@ -140,28 +152,25 @@ func restoreSharePointCmd(cmd *cobra.Command, args []string) error {
 
 	defer utils.CloseRepo(ctx, r)
 
-	sel := selectors.NewSharePointRestore()
-	utils.IncludeSharePointRestoreDataSelectors(sel, opts)
-	utils.FilterSharePointRestoreInfoSelectors(sel, opts)
-
-	// if no selector flags were specified, get all data in the service.
-	if len(sel.Scopes()) == 0 {
-		sel.Include(sel.Sites(selectors.Any()))
-	}
+	selFor := func(excluded []string) selectors.Selector {
+		sel := selectors.NewSharePointRestore()
+		utils.IncludeSharePointRestoreDataSelectors(sel, opts)
+		utils.FilterSharePointRestoreInfoSelectors(sel, opts)
 
-	restoreDest := control.DefaultRestoreDestination(common.SimpleDateTimeOneDrive)
+		// if no selector flags were specified, get all data in the service.
+		if len(sel.Scopes()) == 0 {
+			sel.Include(sel.Sites(selectors.Any()))
+		}
 
-	ro, err := r.NewRestore(ctx, backupID, sel.Selector, restoreDest)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Failed to initialize SharePoint restore"))
-	}
+		// leaves out the resource owners restored from other backups.
+		if len(excluded) > 0 {
+			sel.Exclude(sel.Sites(excluded))
+		}
 
-	ds, err := ro.Run(ctx)
-	if err != nil {
-		return Only(ctx, errors.Wrap(err, "Failed to run SharePoint restore"))
+		return sel.Selector
 	}
 
-	ds.PrintEntries(ctx)
+	restoreDest := restoreDestination(common.SimpleDateTimeOneDrive, destinationSite)
 
-	return nil
+	return runRestores(ctx, r, "SharePoint", selFor, restoreDest)
 }
@@ -8,42 +8,46 @@ import (
 
 // flag names
 const (
-	ContactFN             = "contact"
-	ContactFolderFN       = "contact-folder"
-	EmailFN               = "email"
-	EmailFolderFN         = "email-folder"
-	EventFN               = "event"
-	EventCalendarFN       = "event-calendar"
-	ContactNameFN         = "contact-name"
-	EmailReceivedAfterFN  = "email-received-after"
-	EmailReceivedBeforeFN = "email-received-before"
-	EmailSenderFN         = "email-sender"
-	EmailSubjectFN        = "email-subject"
-	EventOrganizerFN      = "event-organizer"
-	EventRecursFN         = "event-recurs"
-	EventStartsAfterFN    = "event-starts-after"
-	EventStartsBeforeFN   = "event-starts-before"
-	EventSubjectFN        = "event-subject"
+	ContactFN               = "contact"
+	ContactFolderFN         = "contact-folder"
+	EmailFN                 = "email"
+	EmailFolderFN           = "email-folder"
+	EventFN                 = "event"
+	EventCalendarFN         = "event-calendar"
+	ContactModifiedAfterFN  = "contact-modified-after"
+	ContactModifiedBeforeFN = "contact-modified-before"
+	ContactNameFN           = "contact-name"
+	EmailReceivedAfterFN    = "email-received-after"
+	EmailReceivedBeforeFN   = "email-received-before"
+	EmailSenderFN           = "email-sender"
+	EmailSubjectFN          = "email-subject"
+	EventOrganizerFN        = "event-organizer"
+	EventRecursFN           = "event-recurs"
+	EventStartsAfterFN      = "event-starts-after"
+	EventStartsBeforeFN     = "event-starts-before"
+	EventSubjectFN          = "event-subject"
 )
 
 type ExchangeOpts struct {
-	Contact             []string
-	ContactFolder       []string
-	Email               []string
-	EmailFolder         []string
-	Event               []string
-	EventCalendar       []string
-	Users               []string
-	ContactName         string
-	EmailReceivedAfter  string
-	EmailReceivedBefore string
-	EmailSender         string
-	EmailSubject        string
-	EventOrganizer      string
-	EventRecurs         string
-	EventStartsAfter    string
-	EventStartsBefore   string
-	EventSubject        string
+	Contact               []string
+	ContactFolder         []string
+	Email                 []string
+	EmailFolder           []string
+	Event                 []string
+	EventCalendar         []string
+	Users                 []string
+	ContactModifiedAfter  string
+	ContactModifiedBefore string
+	ContactName           string
+	EmailReceivedAfter    string
+	EmailReceivedBefore   string
+	EmailSender           string
+	EmailSubject          string
+	EventOrganizer        string
+	EventRecurs           string
+	EventStartsAfter      string
+	EventStartsBefore     string
+	EventSubject          string
 
 	Populated PopulatedFlags
 }
@@ -103,6 +107,12 @@ func ValidateExchangeRestoreFlags(backupID string, opts ExchangeOpts) error {
 		return errors.New("a backup ID is required")
 	}
 
+	return ValidateExchangeFilterFlags(opts)
+}
+
+// ValidateExchangeFilterFlags checks the formats of the flags that filter items
+// by their properties.
+func ValidateExchangeFilterFlags(opts ExchangeOpts) error {
 	if _, ok := opts.Populated[EmailReceivedAfterFN]; ok && !IsValidTimeFormat(opts.EmailReceivedAfter) {
 		return errors.New("invalid time format for email-received-after")
 	}
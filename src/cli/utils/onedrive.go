@@ -35,6 +35,12 @@ func ValidateOneDriveRestoreFlags(backupID string, opts OneDriveOpts) error {
 		return errors.New("a backup ID is required")
 	}
 
+	return ValidateOneDriveFilterFlags(opts)
+}
+
+// ValidateOneDriveFilterFlags checks the formats of the flags that filter items
+// by their properties.
+func ValidateOneDriveFilterFlags(opts OneDriveOpts) error {
 	if _, ok := opts.Populated[FileCreatedAfterFN]; ok && !IsValidTimeFormat(opts.FileCreatedAfter) {
 		return errors.New("invalid time format for created-after")
 	}
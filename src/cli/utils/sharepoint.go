@@ -27,6 +27,12 @@ func ValidateSharePointRestoreFlags(backupID string, opts SharePointOpts) error
 		return errors.New("a backup ID is required")
 	}
 
+	return ValidateSharePointFilterFlags(opts)
+}
+
+// ValidateSharePointFilterFlags checks the formats of the flags that filter
+// items by their properties.
+func ValidateSharePointFilterFlags(opts SharePointOpts) error {
 	// if _, ok := opts.Populated[FileCreatedAfterFN]; ok && !IsValidTimeFormat(opts.FileCreatedAfter) {
 	// 	return errors.New("invalid time format for created-after")
 	// }
@@ -483,16 +483,38 @@ var (
 	}
 )
 
+// Failures are the failed items in the backups produced by a nil
+// MockBackupGetter.
+var Failures = []details.Failure{
+	{
+		Path:   "tid/exchange/uid/email/Inbox",
+		ItemID: "item-1",
+		Class:  details.FailureNotFound,
+		Error:  "item not found",
+	},
+	{
+		Path:    "tid/exchange/uid/email/Inbox",
+		ItemID:  "item-2",
+		Class:   details.FailureThrottled,
+		Error:   "too many requests",
+		Retries: 4,
+	},
+}
+
 // MockBackupGetter implements the repo.BackupGetter interface and returns
-// (selectors/testdata.GetDetailsSet(), nil, nil) when BackupDetails is called
-// on the nil instance. If an instance is given or Backups is called returns an
-// error.
+// (selectors/testdata.GetDetailsSet(), nil, nil) when BackupDetails or
+// SelectedBackupDetails is called on the nil instance. If an instance is
+// given or Backups is called returns an error.
 type MockBackupGetter struct{}
 
-func (MockBackupGetter) Backup(
-	context.Context,
-	model.StableID,
+func (bg *MockBackupGetter) Backup(
+	ctx context.Context,
+	backupID model.StableID,
 ) (*backup.Backup, error) {
+	if bg == nil {
+		return &backup.Backup{Failures: Failures}, nil
+	}
+
 	return nil, errors.New("unexpected call to mock")
 }
 
@@ -517,3 +539,11 @@ func (bg *MockBackupGetter) BackupDetails(
 
 	return nil, nil, errors.New("unexpected call to mock")
 }
+
+func (bg *MockBackupGetter) SelectedBackupDetails(
+	ctx context.Context,
+	backupID string,
+	_ selectors.Selector,
+) (*details.Details, *backup.Backup, error) {
+	return bg.BackupDetails(ctx, backupID)
+}
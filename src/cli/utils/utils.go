@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -15,16 +16,38 @@ import (
 
 // common flag names
 const (
-	BackupFN = "backup"
-	DataFN   = "data"
-	SiteFN   = "site"
-	UserFN   = "user"
+	BackupFN          = "backup"
+	DataFN            = "data"
+	DestinationSiteFN = "destination-site"
+	DestinationUserFN = "destination-user"
+	InPlaceFN         = "in-place"
+	PointInTimeFN     = "point-in-time"
+	SiteFN            = "site"
+	TagFN             = "tag"
+	UserFN            = "user"
 )
 
 const (
 	Wildcard = "*"
 )
 
+// ParseTags converts a set of key=value strings into a map of tag keys to
+// values.  Errors if any string isn't formatted as key=value.
+func ParseTags(kvs []string) (map[string]string, error) {
+	tags := make(map[string]string, len(kvs))
+
+	for _, kv := range kvs {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || len(k) == 0 {
+			return nil, errors.New("invalid tag " + kv + ": tags must be formatted as key=value")
+		}
+
+		tags[k] = v
+	}
+
+	return tags, nil
+}
+
 // RequireProps validates the existence of the properties
 // in the map.  Expects the format map[propName]propVal.
 func RequireProps(props map[string]string) error {
@@ -37,6 +37,44 @@ func (suite *CliUtilsSuite) TestRequireProps() {
 	}
 }
 
+func (suite *CliUtilsSuite) TestParseTags() {
+	table := []struct {
+		name     string
+		kvs      []string
+		expect   map[string]string
+		errCheck assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "none",
+			expect:   map[string]string{},
+			errCheck: assert.NoError,
+		},
+		{
+			name:     "tags",
+			kvs:      []string{"reason=legal-hold", "ticket=INC123", "note=a=b", "empty="},
+			expect:   map[string]string{"reason": "legal-hold", "ticket": "INC123", "note": "a=b", "empty": ""},
+			errCheck: assert.NoError,
+		},
+		{
+			name:     "no value",
+			kvs:      []string{"reason"},
+			errCheck: assert.Error,
+		},
+		{
+			name:     "no key",
+			kvs:      []string{"=legal-hold"},
+			errCheck: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			tags, err := ParseTags(test.kvs)
+			test.errCheck(t, err)
+			assert.Equal(t, test.expect, tags)
+		})
+	}
+}
+
 func (suite *CliUtilsSuite) TestSplitFoldersIntoContainsAndPrefix() {
 	table := []struct {
 		name    string
@@ -154,7 +154,7 @@ func generateAndRestoreItems(
 
 	Infof(ctx, "Generating %d %s items in %s\n", howMany, cat, destination)
 
-	return gc.RestoreDataCollections(ctx, sel, dest, dataColls)
+	return gc.RestoreDataCollections(ctx, sel, dest, control.Options{}, dataColls)
 }
 
 // ------------------------------------------------------------------------------------------
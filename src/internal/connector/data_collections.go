@@ -122,10 +122,12 @@ func verifyBackupInputs(sels selectors.Selector, userPNs, siteIDs []string) erro
 
 // createExchangeCollections - utility function that retrieves M365
 // IDs through Microsoft Graph API. The selectors.ExchangeScope
-// determines the type of collections that are retrieved.
+// determines the type of collections that are retrieved, and the
+// exchange.TimeWindow limits the items within them.
 func (gc *GraphConnector) createExchangeCollections(
 	ctx context.Context,
 	scope selectors.ExchangeScope,
+	tw exchange.TimeWindow,
 	dps exchange.DeltaPaths,
 	ctrlOpts control.Options,
 ) ([]data.Collection, error) {
@@ -161,6 +163,7 @@ func (gc *GraphConnector) createExchangeCollections(
 			gc.UpdateStatus,
 			resolver,
 			scope,
+			tw,
 			dps,
 			ctrlOpts)
 
@@ -208,9 +211,17 @@ func (gc *GraphConnector) ExchangeDataCollection(
 	}
 
 	for _, scope := range scopes {
-		dps := cdps[scope.Category().PathType()]
+		cat := scope.Category().PathType()
+		dps := cdps[cat]
 
-		dcs, err := gc.createExchangeCollections(ctx, scope, dps, control.Options{})
+		after, before, err := eb.TimeWindow(cat)
+		if err != nil {
+			return nil, errors.Wrap(err, "exchangeDataCollection: parsing time filters")
+		}
+
+		tw := exchange.TimeWindow{After: after, Before: before}
+
+		dcs, err := gc.createExchangeCollections(ctx, scope, tw, dps, ctrlOpts)
 		if err != nil {
 			user := scope.Get(selectors.ExchangeUser)
 			return nil, support.WrapAndAppend(user[0], err, errs)
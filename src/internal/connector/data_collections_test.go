@@ -286,7 +286,13 @@ func (suite *ConnectorCreateExchangeCollectionIntegrationSuite) TestMailFetch()
 
 	for _, test := range tests {
 		suite.T().Run(test.name, func(t *testing.T) {
-			collections, err := gc.createExchangeCollections(ctx, test.scope, exchange.DeltaPaths{}, control.Options{})
+			collections, err := gc.createExchangeCollections(
+				ctx,
+				test.scope,
+				exchange.TimeWindow{},
+				exchange.DeltaPaths{},
+				control.Options{},
+			)
 			require.NoError(t, err)
 
 			for _, c := range collections {
@@ -338,7 +344,13 @@ func (suite *ConnectorCreateExchangeCollectionIntegrationSuite) TestDelta() {
 	for _, test := range tests {
 		suite.T().Run(test.name, func(t *testing.T) {
 			// get collections without providing any delta history (ie: full backup)
-			collections, err := gc.createExchangeCollections(ctx, test.scope, exchange.DeltaPaths{}, control.Options{})
+			collections, err := gc.createExchangeCollections(
+				ctx,
+				test.scope,
+				exchange.TimeWindow{},
+				exchange.DeltaPaths{},
+				control.Options{},
+			)
 			require.NoError(t, err)
 			assert.Less(t, 1, len(collections), "retrieved metadata and data collections")
 
@@ -359,7 +371,13 @@ func (suite *ConnectorCreateExchangeCollectionIntegrationSuite) TestDelta() {
 
 			// now do another backup with the previous delta tokens,
 			// which should only contain the difference.
-			collections, err = gc.createExchangeCollections(ctx, test.scope, dps, control.Options{})
+			collections, err = gc.createExchangeCollections(
+				ctx,
+				test.scope,
+				exchange.TimeWindow{},
+				dps,
+				control.Options{},
+			)
 			require.NoError(t, err)
 
 			// TODO(keepers): this isn't a very useful test at the moment.  It needs to
@@ -393,7 +411,13 @@ func (suite *ConnectorCreateExchangeCollectionIntegrationSuite) TestMailSerializ
 
 	sel.Include(sel.MailFolders([]string{suite.user}, []string{exchange.DefaultMailFolder}, selectors.PrefixMatch()))
 
-	collection, err := connector.createExchangeCollections(ctx, sel.Scopes()[0], exchange.DeltaPaths{}, control.Options{})
+	collection, err := connector.createExchangeCollections(
+		ctx,
+		sel.Scopes()[0],
+		exchange.TimeWindow{},
+		exchange.DeltaPaths{},
+		control.Options{},
+	)
 	require.NoError(t, err)
 
 	for _, edc := range collection {
@@ -438,7 +462,13 @@ func (suite *ConnectorCreateExchangeCollectionIntegrationSuite) TestContactSeria
 				scope := selectors.
 					NewExchangeBackup().
 					ContactFolders([]string{suite.user}, []string{exchange.DefaultContactFolder}, selectors.PrefixMatch())[0]
-				collections, err := connector.createExchangeCollections(ctx, scope, exchange.DeltaPaths{}, control.Options{})
+				collections, err := connector.createExchangeCollections(
+					ctx,
+					scope,
+					exchange.TimeWindow{},
+					exchange.DeltaPaths{},
+					control.Options{},
+				)
 				require.NoError(t, err)
 
 				return collections
@@ -509,6 +539,7 @@ func (suite *ConnectorCreateExchangeCollectionIntegrationSuite) TestEventsSerial
 				collections, err := connector.createExchangeCollections(
 					ctx,
 					sel.Scopes()[0],
+					exchange.TimeWindow{},
 					exchange.DeltaPaths{},
 					control.Options{})
 				require.NoError(t, err)
@@ -526,6 +557,7 @@ func (suite *ConnectorCreateExchangeCollectionIntegrationSuite) TestEventsSerial
 				collections, err := connector.createExchangeCollections(
 					ctx,
 					sel.Scopes()[0],
+					exchange.TimeWindow{},
 					exchange.DeltaPaths{},
 					control.Options{})
 				require.NoError(t, err)
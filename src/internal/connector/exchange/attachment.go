@@ -15,8 +15,9 @@ import (
 const (
 	// Use large attachment logic for attachments > 3MB
 	// https://learn.microsoft.com/en-us/graph/outlook-large-attachments
-	largeAttachmentSize           = int32(3 * 1024 * 1024)
-	attachmentChunkSize           = 4 * 1024 * 1024
+	largeAttachmentSize = int32(3 * 1024 * 1024)
+	// Each request to an attachment upload session is limited to 4MB.
+	attachmentChunkSize           = 12 * uploadsession.ChunkSizeMultiple
 	fileAttachmentOdataValue      = "#microsoft.graph.fileAttachment"
 	itemAttachmentOdataValue      = "#microsoft.graph.itemAttachment"
 	referenceAttachmentOdataValue = "#microsoft.graph.referenceAttachment"
@@ -77,7 +78,7 @@ func uploadLargeAttachment(ctx context.Context, uploader attachmentUploadable,
 	}
 
 	url := *session.GetUploadUrl()
-	aw := uploadsession.NewWriter(uploader.getItemID(), url, size)
+	aw := uploadsession.NewWriter(ctx, uploader.getItemID(), url, size, attachmentChunkSize)
 	logger.Ctx(ctx).Debugf("Created an upload session for item %s. URL: %s", uploader.getItemID(), url)
 
 	// Upload the stream data
@@ -88,5 +89,9 @@ func uploadLargeAttachment(ctx context.Context, uploader attachmentUploadable,
 		return errors.Wrapf(err, "failed to upload attachment: item %s", uploader.getItemID())
 	}
 
+	if err := aw.Close(); err != nil {
+		return errors.Wrapf(err, "failed to upload attachment: item %s", uploader.getItemID())
+	}
+
 	return nil
 }
@@ -16,7 +16,7 @@ import (
 func MetadataFileNames(cat path.CategoryType) []string {
 	switch cat {
 	case path.EmailCategory, path.ContactsCategory:
-		return []string{graph.DeltaURLsFileName, graph.PreviousPathFileName}
+		return []string{graph.DeltaURLsFileName, graph.PreviousPathFileName, graph.TimeWindowFileName}
 	default:
 		return []string{graph.PreviousPathFileName}
 	}
@@ -49,6 +49,20 @@ func (dps DeltaPaths) AddPath(k, p string) {
 type DeltaPath struct {
 	delta string
 	path  string
+	// window is the time window the delta token was produced with.
+	window TimeWindow
+}
+
+// deltaFor returns the delta token to continue from when backing up the
+// items within tw.  A delta token only follows the items within the window
+// it was produced with, so once the window changes no token is returned,
+// and the folder is enumerated again to fetch the items newly inside it.
+func (dp DeltaPath) deltaFor(tw TimeWindow) string {
+	if !dp.window.equal(tw) {
+		return ""
+	}
+
+	return dp.delta
 }
 
 // ParseMetadataCollections produces a map of structs holding delta
@@ -64,6 +78,11 @@ func ParseMetadataCollections(
 		path.EventsCategory:   {},
 	}
 
+	// windows holds the time window each category's delta tokens were
+	// produced with.  Metadata without a window predates windows, and its
+	// tokens cover every item.
+	windows := map[path.CategoryType]TimeWindow{}
+
 	// found tracks the metadata we've loaded, to make sure we don't
 	// fetch overlapping copies.
 	found := map[path.CategoryType]map[string]struct{}{
@@ -122,6 +141,19 @@ func ParseMetadataCollections(
 					}
 
 					found[category]["delta"] = struct{}{}
+
+				case graph.TimeWindowFileName:
+					if _, ok := found[category]["window"]; ok {
+						return nil, errors.Errorf("multiple versions of %s time window metadata", category)
+					}
+
+					tw, err := timeWindowFromMetadata(m)
+					if err != nil {
+						return nil, errors.Wrapf(err, "parsing %s time window metadata", category)
+					}
+
+					windows[category] = tw
+					found[category]["window"] = struct{}{}
 				}
 
 				cdp[category] = cdps
@@ -136,11 +168,15 @@ func ParseMetadataCollections(
 	// Remove any entries that contain a path or a delta, but not both.
 	// That metadata is considered incomplete, and needs to incur a
 	// complete backup on the next run.
-	for _, dps := range cdp {
+	for cat, dps := range cdp {
 		for k, dp := range dps {
 			if len(dp.delta) == 0 || len(dp.path) == 0 {
 				delete(dps, k)
+				continue
 			}
+
+			dp.window = windows[cat]
+			dps[k] = dp
 		}
 	}
 
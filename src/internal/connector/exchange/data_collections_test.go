@@ -2,6 +2,7 @@ package exchange
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -184,3 +185,81 @@ func (suite *DataCollectionsUnitSuite) TestParseMetadataCollections() {
 		})
 	}
 }
+
+func (suite *DataCollectionsUnitSuite) TestParseMetadataCollections_timeWindow() {
+	var (
+		early = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+		late  = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	table := []struct {
+		name        string
+		stored      *TimeWindow
+		current     TimeWindow
+		expectDelta string
+	}{
+		{
+			name:        "no stored window, no window",
+			current:     TimeWindow{},
+			expectDelta: "delta-link",
+		},
+		{
+			name:        "no stored window, window added",
+			current:     TimeWindow{After: early},
+			expectDelta: "",
+		},
+		{
+			name:        "same window",
+			stored:      &TimeWindow{After: early, Before: late},
+			current:     TimeWindow{After: early.In(time.Local), Before: late},
+			expectDelta: "delta-link",
+		},
+		{
+			name:        "window widened",
+			stored:      &TimeWindow{After: late},
+			current:     TimeWindow{After: early},
+			expectDelta: "",
+		},
+		{
+			name:        "window removed",
+			stored:      &TimeWindow{After: early},
+			current:     TimeWindow{},
+			expectDelta: "",
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			ctx, flush := tester.NewContext()
+			defer flush()
+
+			entries := []graph.MetadataCollectionEntry{
+				graph.NewMetadataEntry(graph.DeltaURLsFileName, map[string]string{"key": "delta-link"}),
+				graph.NewMetadataEntry(graph.PreviousPathFileName, map[string]string{"key": "prev-path"}),
+			}
+
+			if test.stored != nil {
+				entries = append(entries, graph.NewMetadataEntry(graph.TimeWindowFileName, test.stored.metadata()))
+			}
+
+			coll, err := graph.MakeMetadataCollection(
+				"t", "u",
+				path.ExchangeService,
+				path.EmailCategory,
+				entries,
+				func(cos *support.ConnectorOperationStatus) {},
+			)
+			require.NoError(t, err)
+
+			cdps, err := ParseMetadataCollections(ctx, []data.Collection{coll})
+			require.NoError(t, err)
+
+			dp, ok := cdps[path.EmailCategory]["key"]
+			require.True(t, ok)
+
+			// the previous path is kept either way, so that the folder's
+			// items are still tracked across renames.
+			assert.Equal(t, "prev-path", dp.path)
+			assert.Equal(t, test.expectDelta, dp.deltaFor(test.current))
+		})
+	}
+}
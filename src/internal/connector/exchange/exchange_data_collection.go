@@ -29,15 +29,15 @@ import (
 )
 
 var (
-	_ data.Collection    = &Collection{}
-	_ data.Stream        = &Stream{}
-	_ data.StreamInfo    = &Stream{}
-	_ data.StreamModTime = &Stream{}
+	_ data.Collection          = &Collection{}
+	_ data.CollectionEstimator = &Collection{}
+	_ data.Stream              = &Stream{}
+	_ data.StreamInfo          = &Stream{}
+	_ data.StreamModTime       = &Stream{}
 )
 
 const (
 	collectionChannelBufferSize = 1000
-	numberOfRetries             = 4
 
 	// Outlooks expects max 4 concurrent requests
 	// https://learn.microsoft.com/en-us/graph/throttling-limits#outlook-service-limits
@@ -122,6 +122,19 @@ func (col *Collection) AddJob(objID string) {
 	col.jobs = append(col.jobs, objID)
 }
 
+// EstimateItems lists the items in the collection.  The size of exchange
+// items isn't known until they're retrieved, so each item's size is
+// reported as unknown, and dry runs fall back to the item's size in the
+// previous backup.
+func (col *Collection) EstimateItems() []data.ItemEstimate {
+	es := make([]data.ItemEstimate, 0, len(col.jobs))
+	for _, id := range col.jobs {
+		es = append(es, data.ItemEstimate{UUID: id, Size: -1})
+	}
+
+	return es
+}
+
 // Items utility function to asynchronously execute process to fill data channel with
 // M365 exchange objects and returns the data channel
 func (col *Collection) Items() <-chan data.Stream {
@@ -163,13 +176,17 @@ func (col Collection) State() data.CollectionState {
 }
 
 // populateByOptionIdentifier is a utility function that uses col.collectionType to be able to serialize
-// all the M365IDs defined in the jobs field. data channel is closed by this function
+// all the M365IDs defined in the jobs field. data channel is closed by this function.
+// Items are retrieved in groups of graph.MaxBatchSize items, using $batch
+// requests that keep within the mailbox's concurrency limit.
 func (col *Collection) populateByOptionIdentifier(ctx context.Context) {
 	var (
 		errs       error
+		failures   []details.Failure
 		success    int64
 		totalBytes int64
 		wg         sync.WaitGroup
+		mu         sync.Mutex
 
 		user = col.user
 	)
@@ -179,14 +196,16 @@ func (col *Collection) populateByOptionIdentifier(ctx context.Context) {
 
 	defer func() {
 		close(colProgress)
-		col.finishPopulation(ctx, int(success), totalBytes, errs)
+		col.finishPopulation(ctx, int(success), totalBytes, errs, failures)
 	}()
 
 	// get QueryBasedonIdentifier
 	// verify that it is the correct type in called function
 	// serializationFunction
-	query, serializeFunc := GetQueryAndSerializeFunc(col.collectionType)
-	if query == nil {
+	_, serializeFunc := GetQueryAndSerializeFunc(col.collectionType)
+	itemURL, factory := getBatchQuery(col.collectionType)
+
+	if serializeFunc == nil || itemURL == nil {
 		errs = fmt.Errorf("unrecognized collection type: %s", col.collectionType.String())
 		return
 	}
@@ -195,60 +214,90 @@ func (col *Collection) populateByOptionIdentifier(ctx context.Context) {
 	semaphoreCh := make(chan struct{}, urlPrefetchChannelBufferSize)
 	defer close(semaphoreCh)
 
-	errUpdater := func(user string, err error) {
-		errs = support.WrapAndAppend(user, err, errs)
+	// itemFailed records an item that couldn't be backed up.
+	itemFailed := func(id string, err error, retries int, class details.FailureClass) {
+		f := graph.NewItemFailure(col.fullPath.String(), id, err, retries)
+		if len(class) > 0 {
+			f.Class = class
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = support.WrapAndAppend(id, err, errs)
+		failures = append(failures, f)
 	}
 
-	for _, identifier := range col.jobs {
-		if col.ctrl.FailFast && errs != nil {
+	hasErrs := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return errs != nil
+	}
+
+	for start := 0; start < len(col.jobs); start += graph.MaxBatchSize {
+		if col.ctrl.FailFast && hasErrs() {
 			break
 		}
+
+		end := start + graph.MaxBatchSize
+		if end > len(col.jobs) {
+			end = len(col.jobs)
+		}
+
 		semaphoreCh <- struct{}{}
 
 		wg.Add(1)
 
-		go func(identifier string) {
+		go func(ids []string) {
 			defer wg.Done()
 			defer func() { <-semaphoreCh }()
 
-			var (
-				response absser.Parsable
-				err      error
-			)
-
-			for i := 1; i <= numberOfRetries; i++ {
-				response, err = query(ctx, col.service, user, identifier)
-				if err == nil {
-					break
-				}
-				// TODO: Tweak sleep times
-				if i < numberOfRetries {
-					time.Sleep(time.Duration(3*(i+1)) * time.Second)
-				}
+			urls := make([]string, 0, len(ids))
+			for _, id := range ids {
+				urls = append(urls, itemURL(user, id))
 			}
 
+			// Throttled and transient failures are retried by the graph client.
+			results, err := graph.BatchGet(ctx, col.service.Adapter(), user, urls)
 			if err != nil {
-				errUpdater(user, err)
-				return
-			}
+				for _, id := range ids {
+					itemFailed(id, err, 0, "")
+				}
 
-			byteCount, err := serializeFunc(
-				ctx,
-				col.service.Client(),
-				kioser.NewJsonSerializationWriter(),
-				col.data,
-				response,
-				user)
-			if err != nil {
-				errUpdater(user, err)
 				return
 			}
 
-			atomic.AddInt64(&success, 1)
-			atomic.AddInt64(&totalBytes, int64(byteCount))
+			for i, result := range results {
+				if result.Err != nil {
+					itemFailed(ids[i], result.Err, result.Retries, "")
+					continue
+				}
+
+				response, err := support.CreateFromBytes(result.Body, factory)
+				if err != nil {
+					itemFailed(ids[i], err, result.Retries, details.FailureInvalidData)
+					continue
+				}
 
-			colProgress <- struct{}{}
-		}(identifier)
+				byteCount, err := serializeFunc(
+					ctx,
+					col.service.Client(),
+					kioser.NewJsonSerializationWriter(),
+					col.data,
+					response,
+					user)
+				if err != nil {
+					itemFailed(ids[i], err, result.Retries, "")
+					continue
+				}
+
+				atomic.AddInt64(&success, 1)
+				atomic.AddInt64(&totalBytes, int64(byteCount))
+
+				colProgress <- struct{}{}
+			}
+		}(col.jobs[start:end])
 	}
 
 	wg.Wait()
@@ -256,7 +305,13 @@ func (col *Collection) populateByOptionIdentifier(ctx context.Context) {
 
 // terminatePopulateSequence is a utility function used to close a Collection's data channel
 // and to send the status update through the channel.
-func (col *Collection) finishPopulation(ctx context.Context, success int, totalBytes int64, errs error) {
+func (col *Collection) finishPopulation(
+	ctx context.Context,
+	success int,
+	totalBytes int64,
+	errs error,
+	failures []details.Failure,
+) {
 	close(col.data)
 	attempted := len(col.jobs)
 	status := support.CreateStatus(ctx,
@@ -269,6 +324,7 @@ func (col *Collection) finishPopulation(ctx context.Context, success int, totalB
 		},
 		errs,
 		col.fullPath.Folder())
+	status.Failures = failures
 	logger.Ctx(ctx).Debug(status.String())
 	col.statusUpdater(status)
 }
@@ -318,30 +374,21 @@ func eventToDataCollection(
 	}
 
 	if *event.GetHasAttachments() {
-		var retriesErr error
-
-		for count := 0; count < numberOfRetries; count++ {
-			attached, err := client.
-				UsersById(user).
-				EventsById(*event.GetId()).
-				Attachments().
-				Get(ctx, nil)
-			retriesErr = err
-
-			if err == nil && attached != nil {
-				event.SetAttachments(attached.GetValue())
-				break
-			}
-		}
-
-		if retriesErr != nil {
-			logger.Ctx(ctx).Debug("exceeded maximum retries")
-
+		attached, err := client.
+			UsersById(user).
+			EventsById(*event.GetId()).
+			Attachments().
+			Get(ctx, nil)
+		if err != nil {
 			return 0, support.WrapAndAppend(
 				*event.GetId(),
-				errors.Wrap(retriesErr, "attachment failed"),
+				errors.Wrap(err, "attachment failed"),
 				nil)
 		}
+
+		if attached != nil {
+			event.SetAttachments(attached.GetValue())
+		}
 	}
 
 	err = objectWriter.WriteObjectValue("", event)
@@ -427,27 +474,16 @@ func messageToDataCollection(
 	}
 
 	if *msg.GetHasAttachments() {
-		// getting all the attachments might take a couple attempts due to filesize
-		var retriesErr error
-
-		for count := 0; count < numberOfRetries; count++ {
-			attached, err := client.
-				UsersById(user).
-				MessagesById(*msg.GetId()).
-				Attachments().
-				Get(ctx, nil)
-			retriesErr = err
-
-			if err == nil {
-				msg.SetAttachments(attached.GetValue())
-				break
-			}
+		attached, err := client.
+			UsersById(user).
+			MessagesById(*msg.GetId()).
+			Attachments().
+			Get(ctx, nil)
+		if err != nil {
+			return 0, support.WrapAndAppend(*msg.GetId(), errors.Wrap(err, "attachment failed"), nil)
 		}
 
-		if retriesErr != nil {
-			logger.Ctx(ctx).Debug("exceeded maximum retries")
-			return 0, support.WrapAndAppend(*msg.GetId(), errors.Wrap(retriesErr, "attachment failed"), nil)
-		}
+		msg.SetAttachments(attached.GetValue())
 	}
 
 	err = objectWriter.WriteObjectValue("", msg)
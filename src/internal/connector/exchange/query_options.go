@@ -2,10 +2,13 @@ package exchange
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	msuser "github.com/microsoftgraph/msgraph-sdk-go/users"
 	"github.com/pkg/errors"
 
+	"github.com/alcionai/corso/src/internal/common"
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
@@ -67,17 +70,19 @@ var (
 		"webLink":           5,
 		"id":                6,
 		"isRead":            7,
+		"receivedDateTime":  8,
 	}
 
 	fieldsForContacts = map[string]int{
-		"id":             1,
-		"companyName":    2,
-		"department":     3,
-		"displayName":    4,
-		"fileAs":         5,
-		"givenName":      6,
-		"manager":        7,
-		"parentFolderId": 8,
+		"id":                   1,
+		"companyName":          2,
+		"department":           3,
+		"displayName":          4,
+		"fileAs":               5,
+		"givenName":            6,
+		"manager":              7,
+		"parentFolderId":       8,
+		"lastModifiedDateTime": 9,
 	}
 )
 
@@ -107,6 +112,109 @@ func CategoryToOptionIdentifier(category path.CategoryType) optionIdentifier {
 	}
 }
 
+// TimeWindow restricts a backup to the items within a span of time: mail
+// by the time it was received, events by their start time, and contacts by
+// the time they were last modified.  A zero time leaves that end of the
+// window open.
+type TimeWindow struct {
+	After  time.Time
+	Before time.Time
+}
+
+// includes returns true if t is within the window.  Items with an unknown
+// time are always included.
+func (tw TimeWindow) includes(t *time.Time) bool {
+	if t == nil {
+		return true
+	}
+
+	return (tw.After.IsZero() || t.After(tw.After)) &&
+		(tw.Before.IsZero() || t.Before(tw.Before))
+}
+
+// equal returns true if both windows span the same time.
+func (tw TimeWindow) equal(other TimeWindow) bool {
+	return tw.After.Equal(other.After) && tw.Before.Equal(other.Before)
+}
+
+const (
+	timeWindowAfterKey  = "after"
+	timeWindowBeforeKey = "before"
+)
+
+// metadata serializes the window for storage alongside delta tokens.  Open
+// ends of the window are left out.
+func (tw TimeWindow) metadata() map[string]string {
+	m := map[string]string{}
+
+	if !tw.After.IsZero() {
+		m[timeWindowAfterKey] = tw.After.UTC().Format(time.RFC3339Nano)
+	}
+
+	if !tw.Before.IsZero() {
+		m[timeWindowBeforeKey] = tw.Before.UTC().Format(time.RFC3339Nano)
+	}
+
+	return m
+}
+
+// timeWindowFromMetadata parses a window serialized by metadata.
+func timeWindowFromMetadata(m map[string]string) (TimeWindow, error) {
+	var (
+		tw  TimeWindow
+		err error
+	)
+
+	if v, ok := m[timeWindowAfterKey]; ok {
+		if tw.After, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return TimeWindow{}, errors.Wrap(err, "parsing window start")
+		}
+	}
+
+	if v, ok := m[timeWindowBeforeKey]; ok {
+		if tw.Before, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return TimeWindow{}, errors.Wrap(err, "parsing window end")
+		}
+	}
+
+	return tw, nil
+}
+
+// messagesDeltaFilter produces the $filter for a messages delta query.
+// The delta query only accepts a lower bound on receivedDateTime, so the
+// upper bound must be applied to the results instead.
+func messagesDeltaFilter(tw TimeWindow) *string {
+	if tw.After.IsZero() {
+		return nil
+	}
+
+	f := "receivedDateTime gt " + tw.After.UTC().Format(time.RFC3339)
+
+	return &f
+}
+
+// eventsFilter produces the $filter for an events query.  Event start
+// times are compared as UTC strings without a zone designator.
+func eventsFilter(tw TimeWindow) *string {
+	fs := []string{}
+
+	if !tw.After.IsZero() {
+		fs = append(fs, "start/dateTime gt '"+common.FormatTimeWith(tw.After, common.M365DateTimeTimeZone)+"'")
+	}
+
+	if !tw.Before.IsZero() {
+		fs = append(fs, "start/dateTime lt '"+common.FormatTimeWith(tw.Before, common.M365DateTimeTimeZone)+"'")
+	}
+
+	if len(fs) == 0 {
+		return nil
+	}
+
+	f := strings.Join(fs, " and ")
+
+	return &f
+}
+
 // -----------------------------------------------------------------------
 // exchange.Query Option Section
 // These functions can be used to filter a response on M365
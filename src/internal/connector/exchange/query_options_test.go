@@ -0,0 +1,97 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryOptionsUnitSuite struct {
+	suite.Suite
+}
+
+func TestQueryOptionsUnitSuite(t *testing.T) {
+	suite.Run(t, new(QueryOptionsUnitSuite))
+}
+
+var (
+	early = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	mid   = time.Date(2018, 6, 1, 12, 30, 0, 0, time.UTC)
+	late  = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func (suite *QueryOptionsUnitSuite) TestTimeWindow_Includes() {
+	table := []struct {
+		name   string
+		tw     TimeWindow
+		t      *time.Time
+		expect assert.BoolAssertionFunc
+	}{
+		{"open window", TimeWindow{}, &mid, assert.True},
+		{"unknown time", TimeWindow{After: early, Before: early}, nil, assert.True},
+		{"after the start", TimeWindow{After: early}, &mid, assert.True},
+		{"before the start", TimeWindow{After: late}, &mid, assert.False},
+		{"at the start", TimeWindow{After: mid}, &mid, assert.False},
+		{"before the end", TimeWindow{Before: late}, &mid, assert.True},
+		{"after the end", TimeWindow{Before: early}, &mid, assert.False},
+		{"within the window", TimeWindow{After: early, Before: late}, &mid, assert.True},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			test.expect(t, test.tw.includes(test.t))
+		})
+	}
+}
+
+func (suite *QueryOptionsUnitSuite) TestMessagesDeltaFilter() {
+	table := []struct {
+		name   string
+		tw     TimeWindow
+		expect string
+	}{
+		{"open window", TimeWindow{}, ""},
+		{"end only", TimeWindow{Before: late}, ""},
+		{"start", TimeWindow{After: mid, Before: late}, "receivedDateTime gt 2018-06-01T12:30:00Z"},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			f := messagesDeltaFilter(test.tw)
+			if len(test.expect) == 0 {
+				assert.Nil(t, f)
+				return
+			}
+
+			assert.Equal(t, test.expect, *f)
+		})
+	}
+}
+
+func (suite *QueryOptionsUnitSuite) TestEventsFilter() {
+	table := []struct {
+		name   string
+		tw     TimeWindow
+		expect string
+	}{
+		{"open window", TimeWindow{}, ""},
+		{"start only", TimeWindow{After: mid}, "start/dateTime gt '2018-06-01T12:30:00.000000'"},
+		{"end only", TimeWindow{Before: mid}, "start/dateTime lt '2018-06-01T12:30:00.000000'"},
+		{
+			"both",
+			TimeWindow{After: early, Before: late},
+			"start/dateTime gt '2015-01-01T00:00:00.000000' and start/dateTime lt '2022-01-01T00:00:00.000000'",
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			f := eventsFilter(test.tw)
+			if len(test.expect) == 0 {
+				assert.Nil(t, f)
+				return
+			}
+
+			assert.Equal(t, test.expect, *f)
+		})
+	}
+}
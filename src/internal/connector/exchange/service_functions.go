@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/alcionai/corso/src/internal/connector/graph"
+	"github.com/alcionai/corso/src/internal/connector/support"
 	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
@@ -38,11 +39,7 @@ func (es *exchangeService) Adapter() *msgraphsdk.GraphRequestAdapter {
 // iff the params for the entry are incorrect (e.g. len(TenantID) == 0, etc.)
 // NOTE: Incorrect account information will result in errors on subsequent queries.
 func createService(credentials account.M365Config) (*exchangeService, error) {
-	adapter, err := graph.CreateAdapter(
-		credentials.AzureTenantID,
-		credentials.AzureClientID,
-		credentials.AzureClientSecret,
-	)
+	adapter, err := graph.CreateAdapter(credentials)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating microsoft graph service for exchange")
 	}
@@ -125,6 +122,66 @@ func DeleteContactFolder(ctx context.Context, gs graph.Servicer, user, folderID
 	return gs.Client().UsersById(user).ContactFoldersById(folderID).Delete(ctx, nil)
 }
 
+// ItemExists returns true if an item with the given M365 ID is still present
+// in the user's account.  Items that were deleted or moved out of the mailbox
+// are reported as missing.
+func ItemExists(
+	ctx context.Context,
+	gs graph.Servicer,
+	category path.CategoryType,
+	user, itemID string,
+) (bool, error) {
+	var err error
+
+	switch category {
+	case path.EmailCategory:
+		_, err = gs.Client().UsersById(user).MessagesById(itemID).Get(ctx, nil)
+	case path.ContactsCategory:
+		_, err = gs.Client().UsersById(user).ContactsById(itemID).Get(ctx, nil)
+	case path.EventsCategory:
+		_, err = gs.Client().UsersById(user).EventsById(itemID).Get(ctx, nil)
+	default:
+		return false, fmt.Errorf("category: %s not supported for item lookup", category)
+	}
+
+	if err != nil {
+		if hasErrorCode(err, errItemNotFound) {
+			return false, nil
+		}
+
+		return false, errors.Wrap(err, support.ConnectorStackErrorTrace(err))
+	}
+
+	return true, nil
+}
+
+// DeleteItem removes the item with the corresponding M365 ID from the user's account.
+func DeleteItem(
+	ctx context.Context,
+	gs graph.Servicer,
+	category path.CategoryType,
+	user, itemID string,
+) error {
+	var err error
+
+	switch category {
+	case path.EmailCategory:
+		err = gs.Client().UsersById(user).MessagesById(itemID).Delete(ctx, nil)
+	case path.ContactsCategory:
+		err = gs.Client().UsersById(user).ContactsById(itemID).Delete(ctx, nil)
+	case path.EventsCategory:
+		err = gs.Client().UsersById(user).EventsById(itemID).Delete(ctx, nil)
+	default:
+		return fmt.Errorf("category: %s not supported for item deletion", category)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, support.ConnectorStackErrorTrace(err))
+	}
+
+	return nil
+}
+
 // PopulateExchangeContainerResolver gets a folder resolver if one is available for
 // this category of data. If one is not available, returns nil so that other
 // logic in the caller can complete as long as they check if the resolver is not
@@ -43,6 +43,7 @@ func hasErrorCode(err error, code string) bool {
 // that places the M365 object ids belonging to specific directories
 // into a Collection. Messages outside of those directories are omitted.
 // @param collection is filled with during this function.
+// Only items within the time window are placed in the collections.
 // Supports all exchange applications: Contacts, Events, and Mail
 func FilterContainersAndFillCollections(
 	ctx context.Context,
@@ -51,6 +52,7 @@ func FilterContainersAndFillCollections(
 	statusUpdater support.StatusUpdater,
 	resolver graph.ContainerResolver,
 	scope selectors.ExchangeScope,
+	tw TimeWindow,
 	dps DeltaPaths,
 	ctrlOpts control.Options,
 ) error {
@@ -96,7 +98,7 @@ func FilterContainersAndFillCollections(
 
 		var (
 			dp          = dps[cID]
-			prevDelta   = dp.delta
+			prevDelta   = dp.deltaFor(tw)
 			prevPathStr = dp.path
 			prevPath    path.Path
 		)
@@ -107,7 +109,7 @@ func FilterContainersAndFillCollections(
 			}
 		}
 
-		jobs, currDelta, err := getJobs(ctx, service, qp.ResourceOwner, cID, prevDelta)
+		jobs, currDelta, err := getJobs(ctx, service, qp.ResourceOwner, cID, prevDelta, tw)
 		if err != nil {
 			// race conditions happen, the container might get
 			// deleted while this process in flight.
@@ -178,7 +180,10 @@ func FilterContainersAndFillCollections(
 	}
 
 	if len(deltaURLs) > 0 {
-		entries = append(entries, graph.NewMetadataEntry(graph.DeltaURLsFileName, deltaURLs))
+		entries = append(
+			entries,
+			graph.NewMetadataEntry(graph.DeltaURLsFileName, deltaURLs),
+			graph.NewMetadataEntry(graph.TimeWindowFileName, tw.metadata()))
 	}
 
 	if col, err := graph.MakeMetadataCollection(
@@ -255,12 +260,13 @@ func IterativeCollectCalendarContainers(
 }
 
 // FetchIDFunc collection of helper functions which return a list of all item
-// IDs in the given container and a delta token for future requests if the
-// container supports fetching delta records.
+// IDs within the time window in the given container and a delta token for
+// future requests if the container supports fetching delta records.
 type FetchIDFunc func(
 	ctx context.Context,
 	gs graph.Servicer,
 	user, containerID, oldDeltaToken string,
+	tw TimeWindow,
 ) ([]string, string, error)
 
 func getFetchIDFunc(category path.CategoryType) (FetchIDFunc, error) {
@@ -281,6 +287,7 @@ func FetchEventIDsFromCalendar(
 	ctx context.Context,
 	gs graph.Servicer,
 	user, calendarID, oldDelta string,
+	tw TimeWindow,
 ) ([]string, string, error) {
 	var (
 		errs *multierror.Error
@@ -292,6 +299,8 @@ func FetchEventIDsFromCalendar(
 		return nil, "", err
 	}
 
+	options.QueryParameters.Filter = eventsFilter(tw)
+
 	builder := gs.Client().
 		UsersById(user).
 		CalendarsById(calendarID).
@@ -343,6 +352,7 @@ func FetchContactIDsFromDirectory(
 	ctx context.Context,
 	gs graph.Servicer,
 	user, directoryID, oldDelta string,
+	tw TimeWindow,
 ) ([]string, string, error) {
 	var (
 		errs     *multierror.Error
@@ -350,7 +360,9 @@ func FetchContactIDsFromDirectory(
 		deltaURL string
 	)
 
-	options, err := optionsForContactFoldersItemDelta([]string{"parentFolderId"})
+	// the contacts delta query doesn't support $filter, so the time window
+	// is applied to the results.
+	options, err := optionsForContactFoldersItemDelta([]string{"parentFolderId", "lastModifiedDateTime"})
 	if err != nil {
 		return nil, deltaURL, errors.Wrap(err, "getting query options")
 	}
@@ -392,6 +404,10 @@ func FetchContactIDsFromDirectory(
 				continue
 			}
 
+			if !tw.includes(item.GetLastModifiedDateTime()) {
+				continue
+			}
+
 			ids = append(ids, *item.GetId())
 		}
 
@@ -417,6 +433,7 @@ func FetchMessageIDsFromDirectory(
 	ctx context.Context,
 	gs graph.Servicer,
 	user, directoryID, oldDelta string,
+	tw TimeWindow,
 ) ([]string, string, error) {
 	var (
 		errs     *multierror.Error
@@ -424,11 +441,13 @@ func FetchMessageIDsFromDirectory(
 		deltaURL string
 	)
 
-	options, err := optionsForFolderMessagesDelta([]string{"isRead"})
+	options, err := optionsForFolderMessagesDelta([]string{"isRead", "receivedDateTime"})
 	if err != nil {
 		return nil, deltaURL, errors.Wrap(err, "getting query options")
 	}
 
+	options.QueryParameters.Filter = messagesDeltaFilter(tw)
+
 	builder := gs.Client().
 		UsersById(user).
 		MailFoldersById(directoryID).
@@ -466,6 +485,10 @@ func FetchMessageIDsFromDirectory(
 				continue
 			}
 
+			if !tw.includes(item.GetReceivedDateTime()) {
+				continue
+			}
+
 			ids = append(ids, *item.GetId())
 		}
 
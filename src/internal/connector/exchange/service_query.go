@@ -2,8 +2,10 @@ package exchange
 
 import (
 	"context"
+	"net/url"
 
 	absser "github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/internal/connector/graph"
 )
@@ -11,7 +13,7 @@ import (
 // GraphQuery represents functions which perform exchange-specific queries
 // into M365 backstore. Responses -> returned items will only contain the information
 // that is included in the options
-// TODO: use selector or path for granularity into specific folders or specific date ranges
+// TODO: use selector or path for granularity into specific folders
 type GraphQuery func(ctx context.Context, gs graph.Servicer, userID string) (absser.Parsable, error)
 
 // GetAllContactsForUser is a GraphQuery function for querying all the contacts in a user's account
@@ -107,3 +109,33 @@ func RetrieveEventDataForUser(ctx context.Context, gs graph.Servicer, user, m365
 func RetrieveMessageDataForUser(ctx context.Context, gs graph.Servicer, user, m365ID string) (absser.Parsable, error) {
 	return gs.Client().UsersById(user).MessagesById(m365ID).Get(ctx, nil)
 }
+
+// itemURLFunc returns the URL of an item, relative to the graph service root,
+// for use within a $batch request.
+type itemURLFunc func(user, m365ID string) string
+
+// getBatchQuery returns the functions used to retrieve items of the given
+// type with $batch requests: one that produces each item's URL, and the
+// factory that parses each response.  The responses hold the same fields as
+// the matching GraphRetrievalFunc.
+func getBatchQuery(optID optionIdentifier) (itemURLFunc, absser.ParsableFactory) {
+	var (
+		resource string
+		factory  absser.ParsableFactory
+	)
+
+	switch optID {
+	case contacts:
+		resource, factory = "contacts", models.CreateContactFromDiscriminatorValue
+	case events:
+		resource, factory = "events", models.CreateEventFromDiscriminatorValue
+	case messages:
+		resource, factory = "messages", models.CreateMessageFromDiscriminatorValue
+	default:
+		return nil, nil
+	}
+
+	return func(user, m365ID string) string {
+		return "/users/" + url.PathEscape(user) + "/" + resource + "/" + url.PathEscape(m365ID)
+	}, factory
+}
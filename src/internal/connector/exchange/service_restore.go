@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/trace"
+	"sync"
 
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/pkg/errors"
@@ -33,7 +34,11 @@ func RestoreExchangeObject(
 	service graph.Servicer,
 	destination, user string,
 ) (*details.ExchangeInfo, error) {
-	if policy != control.Copy {
+	// Collisions with existing items are resolved by the caller, so the
+	// object is always created as a new item.
+	switch policy {
+	case control.Copy:
+	default:
 		return nil, fmt.Errorf("restore policy: %s not supported for RestoreExchangeObject", policy)
 	}
 
@@ -282,56 +287,98 @@ func SendMailToBackStore(
 
 // RestoreExchangeDataCollections restores M365 objects in data.Collection to MSFT
 // store through GraphAPI.
-// @param dest:  container destination to M365
+// @param dest:  container destination to M365.  An empty ContainerName restores
+// items in place, into the folders they were backed up from.
+// @param opts: opts.Collision decides how items that still exist in M365 are handled.
 func RestoreExchangeDataCollections(
 	ctx context.Context,
 	gs graph.Servicer,
 	dest control.RestoreDestination,
+	opts control.Options,
 	dcs []data.Collection,
 	deets *details.Details,
 ) (*support.ConnectorOperationStatus, error) {
 	var (
-		// map of caches... but not yet...
-		directoryCaches = make(map[string]map[path.CategoryType]graph.ContainerResolver)
-		metrics         support.CollectionMetrics
-		errs            error
-		// TODO policy to be updated from external source after completion of refactoring
-		policy = control.Copy
+		metrics support.CollectionMetrics
+		errs    error
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		policy  = opts.Collision
+		limiter = support.NewRestoreLimiter(opts.RestoreParallelism)
+		// collections to restore, grouped by the user they're restored into
+		userDCs = map[string][]restoreTarget{}
 	)
 
+	// Collisions only happen when items are restored into their original
+	// location.  Restores into a new folder always create new items.
+	if policy == control.Unknown || !dest.InPlace() {
+		policy = control.Copy
+	}
+
 	errUpdater := func(id string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
 		errs = support.WrapAndAppend(id, err, errs)
 	}
 
 	for _, dc := range dcs {
-		userID := dc.FullPath().ResourceOwner()
-
-		userCaches := directoryCaches[userID]
-		if userCaches == nil {
-			directoryCaches[userID] = make(map[path.CategoryType]graph.ContainerResolver)
-			userCaches = directoryCaches[userID]
-		}
-
-		containerID, err := GetContainerIDFromCache(
-			ctx,
-			gs,
-			dc.FullPath(),
-			dest.ContainerName,
-			userCaches)
+		directory, err := restoreDirectory(dc.FullPath(), dest)
 		if err != nil {
 			errs = support.WrapAndAppend(dc.FullPath().ShortRef(), err, errs)
 			continue
 		}
 
-		temp, canceled := restoreCollection(ctx, gs, dc, containerID, policy, deets, errUpdater)
-
-		metrics.Combine(temp)
+		userID := directory.ResourceOwner()
+		userDCs[userID] = append(userDCs[userID], restoreTarget{dc, directory})
+	}
 
-		if canceled {
-			break
-		}
+	// Users are restored concurrently.  Each user's collections are restored
+	// one after another, since they share the user's container cache.
+	for userID, targets := range userDCs {
+		wg.Add(1)
+
+		go func(userID string, targets []restoreTarget) {
+			defer wg.Done()
+
+			userCaches := make(map[path.CategoryType]graph.ContainerResolver)
+
+			for _, rt := range targets {
+				containerID, err := GetContainerIDFromCache(
+					ctx,
+					gs,
+					rt.directory,
+					dest.ContainerName,
+					userCaches)
+				if err != nil {
+					errUpdater(rt.dc.FullPath().ShortRef(), err)
+					continue
+				}
+
+				temp, canceled := restoreCollection(
+					ctx,
+					gs,
+					rt.dc,
+					userID,
+					containerID,
+					policy,
+					limiter,
+					deets,
+					errUpdater)
+
+				mu.Lock()
+				metrics.Combine(temp)
+				mu.Unlock()
+
+				if canceled {
+					return
+				}
+			}
+		}(userID, targets)
 	}
 
+	wg.Wait()
+
 	status := support.CreateStatus(ctx,
 		support.Restore,
 		len(dcs),
@@ -342,13 +389,43 @@ func RestoreExchangeDataCollections(
 	return status, errs
 }
 
+// restoreTarget pairs a collection with the directory it's restored into.
+type restoreTarget struct {
+	dc        data.Collection
+	directory path.Path
+}
+
+// restoreDirectory returns the path the collection is restored to.  The
+// resource owner is swapped for dest.ResourceOwnerOverride if one is set.
+func restoreDirectory(directory path.Path, dest control.RestoreDestination) (path.Path, error) {
+	if len(dest.ResourceOwnerOverride) == 0 {
+		return directory, nil
+	}
+
+	p, err := path.Builder{}.
+		Append(directory.Folders()...).
+		ToDataLayerExchangePathForCategory(
+			directory.Tenant(),
+			dest.ResourceOwnerOverride,
+			directory.Category(),
+			false,
+		)
+	if err != nil {
+		return nil, errors.Wrap(err, "overriding restore resource owner")
+	}
+
+	return p, nil
+}
+
 // restoreCollection handles restoration of an individual collection.
+// @param user is the M365 ID of the user who receives the restored items.
 func restoreCollection(
 	ctx context.Context,
 	gs graph.Servicer,
 	dc data.Collection,
-	folderID string,
+	user, folderID string,
 	policy control.CollisionPolicy,
+	limiter *support.RestoreLimiter,
 	deets *details.Details,
 	errUpdater func(string, error),
 ) (support.CollectionMetrics, bool) {
@@ -357,77 +434,157 @@ func restoreCollection(
 
 	var (
 		metrics   support.CollectionMetrics
+		mu        sync.Mutex
+		wg        sync.WaitGroup
 		items     = dc.Items()
 		directory = dc.FullPath()
-		service   = directory.Service()
 		category  = directory.Category()
-		user      = directory.ResourceOwner()
 	)
 
 	colProgress, closer := observe.CollectionProgress(user, category.String(), directory.Folder())
 	defer closer()
 	defer close(colProgress)
 
+	// In-flight items are always waited on before returning, so that the
+	// metrics are complete and no progress is sent on a closed channel.
 	for {
 		select {
 		case <-ctx.Done():
 			errUpdater("context cancelled", ctx.Err())
+			wg.Wait()
+
 			return metrics, true
 
 		case itemData, ok := <-items:
 			if !ok {
+				wg.Wait()
 				return metrics, false
 			}
-			metrics.Objects++
-
-			trace.Log(ctx, "gc:exchange:restoreCollection:item", itemData.UUID())
 
-			buf := &bytes.Buffer{}
+			if err := limiter.Acquire(ctx, user); err != nil {
+				errUpdater("context cancelled", err)
+				wg.Wait()
 
-			_, err := buf.ReadFrom(itemData.ToReader())
-			if err != nil {
-				errUpdater(itemData.UUID()+": byteReadError during RestoreDataCollection", err)
-				continue
+				return metrics, true
 			}
 
-			byteArray := buf.Bytes()
+			mu.Lock()
+			metrics.Objects++
+			mu.Unlock()
+
+			wg.Add(1)
+
+			go func(itemData data.Stream) {
+				defer wg.Done()
+				defer limiter.Release(user)
+
+				byteCount, err := restoreItem(ctx, gs, dc, itemData, user, folderID, policy, deets, colProgress)
+				if err != nil {
+					errUpdater(itemData.UUID(), err)
+					return
+				}
+
+				if byteCount < 0 {
+					// skipped due to a collision
+					return
+				}
+
+				mu.Lock()
+				metrics.TotalBytes += byteCount
+				metrics.Successes++
+				mu.Unlock()
+			}(itemData)
+		}
+	}
+}
+
+// restoreItem restores a single item from the collection into folderID,
+// and records it in deets.  Returns the number of bytes restored, or -1 if
+// the item was skipped due to a collision.
+func restoreItem(
+	ctx context.Context,
+	gs graph.Servicer,
+	dc data.Collection,
+	itemData data.Stream,
+	user, folderID string,
+	policy control.CollisionPolicy,
+	deets *details.Details,
+	colProgress chan<- struct{},
+) (int64, error) {
+	var (
+		directory = dc.FullPath()
+		service   = directory.Service()
+		category  = directory.Category()
+	)
 
-			info, err := RestoreExchangeObject(ctx, byteArray, category, policy, gs, folderID, user)
-			if err != nil {
-				//  More information to be here
-				errUpdater(
-					itemData.UUID()+": failed to upload RestoreExchangeObject: "+service.String()+"-"+category.String(),
-					err)
+	trace.Log(ctx, "gc:exchange:restoreCollection:item", itemData.UUID())
 
-				continue
-			}
+	buf := &bytes.Buffer{}
 
-			metrics.TotalBytes += int64(len(byteArray))
-			metrics.Successes++
+	_, err := buf.ReadFrom(itemData.ToReader())
+	if err != nil {
+		return 0, errors.Wrap(err, "byteReadError during RestoreDataCollection")
+	}
 
-			itemPath, err := dc.FullPath().Append(itemData.UUID(), true)
-			if err != nil {
-				logger.Ctx(ctx).DPanicw("transforming item to full path", "error", err)
-				continue
-			}
+	byteArray := buf.Bytes()
 
-			deets.Add(
-				itemPath.String(),
-				itemPath.ShortRef(),
-				"",
-				true,
-				details.ItemInfo{
-					Exchange: info,
-				})
+	var replace bool
 
+	if policy != control.Copy {
+		exists, err := ItemExists(ctx, gs, category, user, itemData.UUID())
+		if err != nil {
+			return 0, errors.Wrap(err, "resolving restore collision")
+		}
+
+		if exists && policy == control.Skip {
 			colProgress <- struct{}{}
+			return -1, nil
 		}
+
+		replace = exists && policy == control.Replace
+	}
+
+	// Collisions are resolved here, so the object is always created as a
+	// new item.
+	info, err := RestoreExchangeObject(ctx, byteArray, category, control.Copy, gs, folderID, user)
+	if err != nil {
+		//  More information to be here
+		return 0, errors.Wrap(
+			err,
+			"failed to upload RestoreExchangeObject: "+service.String()+"-"+category.String())
+	}
+
+	// The original is only removed once its replacement exists, so that a
+	// failed restore never loses it.
+	if replace {
+		if err := DeleteItem(ctx, gs, category, user, itemData.UUID()); err != nil {
+			return 0, errors.Wrap(err, "deleting replaced item")
+		}
+	}
+
+	itemPath, err := dc.FullPath().Append(itemData.UUID(), true)
+	if err != nil {
+		logger.Ctx(ctx).DPanicw("transforming item to full path", "error", err)
+	} else {
+		deets.Add(
+			itemPath.String(),
+			itemPath.ShortRef(),
+			"",
+			true,
+			details.ItemInfo{
+				Exchange: info,
+			})
 	}
+
+	colProgress <- struct{}{}
+
+	return int64(len(byteArray)), nil
 }
 
-// generateRestoreContainerFunc utility function that holds logic for creating
-// Root Directory or necessary functions based on path.CategoryType
-// Assumption: collisionPolicy == COPY
+// GetContainerIDFromCache utility function that holds logic for creating
+// Root Directory or necessary functions based on path.CategoryType.
+// If destination is empty the original container hierarchy of the directory
+// is used, and any missing containers are recreated.
 func GetContainerIDFromCache(
 	ctx context.Context,
 	gs graph.Servicer,
@@ -441,21 +598,64 @@ func GetContainerIDFromCache(
 		category       = directory.Category()
 		directoryCache = caches[category]
 		newPathFolders = append([]string{destination}, directory.Folders()...)
+		cacheRoot      string
 	)
 
-	switch category {
-	case path.EmailCategory:
-		if directoryCache == nil {
-			mfc := &mailFolderCache{
+	if directoryCache == nil {
+		switch category {
+		case path.EmailCategory:
+			directoryCache = &mailFolderCache{
+				userID: user,
+				gs:     gs,
+			}
+			cacheRoot = rootFolderAlias
+
+		case path.ContactsCategory:
+			directoryCache = &contactFolderCache{
+				userID: user,
+				gs:     gs,
+			}
+			cacheRoot = DefaultContactFolder
+
+		case path.EventsCategory:
+			directoryCache = &eventCalendarCache{
 				userID: user,
 				gs:     gs,
 			}
+			cacheRoot = DefaultCalendar
 
-			caches[category] = mfc
-			newCache = true
-			directoryCache = mfc
+		default:
+			return "", fmt.Errorf("category: %s not support for exchange cache", category)
 		}
 
+		caches[category] = directoryCache
+		newCache = true
+	}
+
+	// In place restores resolve the original folders against the user's
+	// existing hierarchy, so the cache must be filled before any lookups.
+	if len(destination) == 0 {
+		newPathFolders = directory.Folders()
+
+		if newCache {
+			if err := directoryCache.Populate(ctx, cacheRoot); err != nil {
+				return "", errors.Wrap(err, "populating cache for in place restore")
+			}
+
+			newCache = false
+		}
+
+		// The default contact folder is the root of the contact cache, which
+		// does not have a path of its own.
+		if category == path.ContactsCategory &&
+			len(newPathFolders) > 0 &&
+			newPathFolders[0] == DefaultContactFolder {
+			return DefaultContactFolder, nil
+		}
+	}
+
+	switch category {
+	case path.EmailCategory:
 		return establishMailRestoreLocation(
 			ctx,
 			newPathFolders,
@@ -464,16 +664,6 @@ func GetContainerIDFromCache(
 			gs,
 			newCache)
 	case path.ContactsCategory:
-		if directoryCache == nil {
-			cfc := &contactFolderCache{
-				userID: user,
-				gs:     gs,
-			}
-			caches[category] = cfc
-			newCache = true
-			directoryCache = cfc
-		}
-
 		return establishContactsRestoreLocation(
 			ctx,
 			newPathFolders,
@@ -482,16 +672,6 @@ func GetContainerIDFromCache(
 			gs,
 			newCache)
 	case path.EventsCategory:
-		if directoryCache == nil {
-			ecc := &eventCalendarCache{
-				userID: user,
-				gs:     gs,
-			}
-			caches[category] = ecc
-			newCache = true
-			directoryCache = ecc
-		}
-
 		return establishEventsRestoreLocation(
 			ctx,
 			newPathFolders,
@@ -591,10 +771,11 @@ func establishContactsRestoreLocation(
 		if err := cfc.Populate(ctx, folderID, folders[0]); err != nil {
 			return "", errors.Wrap(err, "populating contact cache")
 		}
+	}
 
-		if err = cfc.AddToCache(ctx, temp); err != nil {
-			return "", errors.Wrap(err, "adding contact folder to cache")
-		}
+	// NOOP if the folder is already in the cache.
+	if err = cfc.AddToCache(ctx, temp); err != nil {
+		return "", errors.Wrap(err, "adding contact folder to cache")
 	}
 
 	return folderID, nil
@@ -624,11 +805,12 @@ func establishEventsRestoreLocation(
 		if err = ecc.Populate(ctx, folderID, folders[0]); err != nil {
 			return "", errors.Wrap(err, "populating event cache")
 		}
+	}
 
-		transform := CreateCalendarDisplayable(temp)
-		if err = ecc.AddToCache(ctx, transform); err != nil {
-			return "", errors.Wrap(err, "adding new calendar to cache")
-		}
+	// NOOP if the calendar is already in the cache.
+	transform := CreateCalendarDisplayable(temp)
+	if err = ecc.AddToCache(ctx, transform); err != nil {
+		return "", errors.Wrap(err, "adding new calendar to cache")
 	}
 
 	return folderID, nil
@@ -0,0 +1,64 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+type RestoreUnitSuite struct {
+	suite.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, new(RestoreUnitSuite))
+}
+
+func (suite *RestoreUnitSuite) TestRestoreDirectory() {
+	t := suite.T()
+
+	directory, err := path.Builder{}.
+		Append("Inbox", "foo").
+		ToDataLayerExchangePathForCategory("tenant", "user", path.EmailCategory, false)
+	require.NoError(t, err)
+
+	table := []struct {
+		name        string
+		dest        control.RestoreDestination
+		expectOwner string
+	}{
+		{
+			name:        "no override",
+			dest:        control.RestoreDestination{ContainerName: "Corso_Restore"},
+			expectOwner: "user",
+		},
+		{
+			name: "override",
+			dest: control.RestoreDestination{
+				ContainerName:         "Corso_Restore",
+				ResourceOwnerOverride: "manager",
+			},
+			expectOwner: "manager",
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			result, err := restoreDirectory(directory, test.dest)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectOwner, result.ResourceOwner())
+			assert.Equal(t, directory.Tenant(), result.Tenant())
+			assert.Equal(t, directory.Category(), result.Category())
+			assert.Equal(t, directory.Folders(), result.Folders())
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	abs "github.com/microsoft/kiota-abstractions-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/connector/support"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+const (
+	// MaxBatchSize is the max number of requests Graph accepts in a single $batch.
+	// https://learn.microsoft.com/en-us/graph/json-batching
+	MaxBatchSize = 20
+
+	// MailboxConcurrency is the max number of concurrent requests Outlook
+	// accepts for a mailbox.  Graph runs the requests within a batch
+	// concurrently, so each of them counts against the limit.
+	// https://learn.microsoft.com/en-us/graph/throttling-limits#outlook-service-limits
+	MailboxConcurrency = 4
+
+	batchMaxRetries = 4
+)
+
+var (
+	mailboxLocksMu sync.Mutex
+	mailboxLocks   = map[string]chan struct{}{}
+)
+
+// mailboxLock returns the lock held while a batch of requests for the
+// mailbox is in flight.
+func mailboxLock(mailbox string) chan struct{} {
+	mailboxLocksMu.Lock()
+	defer mailboxLocksMu.Unlock()
+
+	l, ok := mailboxLocks[mailbox]
+	if !ok {
+		l = make(chan struct{}, 1)
+		mailboxLocks[mailbox] = l
+	}
+
+	return l
+}
+
+// BatchResult is the response to a single request within a batch.  Only
+// one of Body or Err is populated.
+type BatchResult struct {
+	Body []byte
+	Err  error
+	// Retries counts the times the request was retried within later batches.
+	Retries int
+}
+
+type batchRequest struct {
+	Requests []batchRequestItem `json:"requests"`
+}
+
+type batchRequestItem struct {
+	ID     string `json:"id"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+type batchResponse struct {
+	Responses []batchResponseItem `json:"responses"`
+}
+
+type batchResponseItem struct {
+	ID      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// header returns the value of the response header, ignoring case.
+func (bri batchResponseItem) header(key string) string {
+	for k, v := range bri.Headers {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// BatchGet fetches each of the urls in mailbox, relative to the graph
+// service root (ex: `/users/{id}/messages/{id}`), using $batch requests of
+// up to MailboxConcurrency urls.  Only one batch is in flight for each
+// mailbox at a time, across all callers.  Each request in a batch counts
+// against the tenant's rate limit.  Results are returned in the same order
+// as urls.
+// Requests within a batch that are throttled or fail transiently are
+// retried in a smaller batch.  Failures of individual requests are
+// reported in their BatchResult; the returned error is only populated if a
+// whole batch fails.
+func BatchGet(ctx context.Context, adapter abs.RequestAdapter, mailbox string, urls []string) ([]BatchResult, error) {
+	var (
+		results = make([]BatchResult, len(urls))
+		lock    = mailboxLock(mailbox)
+	)
+
+	for start := 0; start < len(urls); start += MailboxConcurrency {
+		end := start + MailboxConcurrency
+		if end > len(urls) {
+			end = len(urls)
+		}
+
+		if err := batchGet(ctx, adapter, lock, urls[start:end], results[start:end]); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// batchGet fetches up to MailboxConcurrency urls, retrying failed requests.
+// lock is held while each batch is in flight.
+func batchGet(
+	ctx context.Context,
+	adapter abs.RequestAdapter,
+	lock chan struct{},
+	urls []string,
+	results []BatchResult,
+) error {
+	// indexes of the urls that haven't been fetched yet
+	pending := make([]int, len(urls))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for retry := 0; len(pending) > 0; retry++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case lock <- struct{}{}:
+		}
+
+		resps, err := sendBatch(ctx, adapter, urls, pending)
+
+		<-lock
+
+		if err != nil {
+			return err
+		}
+
+		var (
+			retryable []int
+			delay     time.Duration
+			canRetry  = retry < batchMaxRetries
+		)
+
+		for _, i := range pending {
+			resp, ok := resps[strconv.Itoa(i)]
+
+			switch {
+			case ok && resp.Status/100 == 2:
+				results[i] = BatchResult{Body: resp.Body, Retries: retry}
+
+			case canRetry && (!ok || isRetryableStatus(resp.Status)):
+				retryable = append(retryable, i)
+
+				if ra := parseRetryAfter(resp.header(retryAfterHeader)); ra > delay {
+					delay = ra
+				}
+
+			case !ok:
+				results[i] = BatchResult{Err: errors.New("request missing from batch response"), Retries: retry}
+
+			default:
+				results[i] = BatchResult{Err: batchItemError(resp), Retries: retry}
+			}
+		}
+
+		if len(retryable) == 0 {
+			return nil
+		}
+
+		if delay == 0 {
+			delay = backoff(throttleBaseDelay, retry)
+		}
+
+		logger.Ctx(ctx).Debugw(
+			"retrying batch requests",
+			"count", len(retryable),
+			"retry", retry+1,
+			"delay", delay)
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+
+		pending = retryable
+	}
+
+	return nil
+}
+
+// sendBatch sends a $batch request for the pending urls, identified by their
+// index.  Returns the responses keyed by ID.
+func sendBatch(
+	ctx context.Context,
+	adapter abs.RequestAdapter,
+	urls []string,
+	pending []int,
+) (map[string]batchResponseItem, error) {
+	br := batchRequest{Requests: make([]batchRequestItem, 0, len(pending))}
+
+	for _, i := range pending {
+		br.Requests = append(br.Requests, batchRequestItem{
+			ID:     strconv.Itoa(i),
+			Method: nethttp.MethodGet,
+			URL:    urls[i],
+		})
+	}
+
+	bs, err := json.Marshal(br)
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing batch request")
+	}
+
+	ri := abs.NewRequestInformation()
+	ri.Method = abs.POST
+	ri.UrlTemplate = "{+baseurl}/$batch"
+	ri.PathParameters["baseurl"] = adapter.GetBaseUrl()
+	ri.Headers.Add("Content-Type", "application/json")
+	ri.Content = bs
+
+	errorMapping := abs.ErrorMappings{
+		"4XX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+		"5XX": odataerrors.CreateODataErrorFromDiscriminatorValue,
+	}
+
+	resp, err := adapter.SendPrimitiveAsync(withRequestCost(ctx, len(pending)), ri, "[]byte", errorMapping)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending batch request: %s", support.ConnectorStackErrorTrace(err))
+	}
+
+	body, ok := resp.([]byte)
+	if !ok {
+		return nil, errors.New("empty batch response")
+	}
+
+	bresp := batchResponse{}
+	if err := json.Unmarshal(body, &bresp); err != nil {
+		return nil, errors.Wrap(err, "parsing batch response")
+	}
+
+	resps := make(map[string]batchResponseItem, len(bresp.Responses))
+	for _, r := range bresp.Responses {
+		resps[r.ID] = r
+	}
+
+	return resps, nil
+}
+
+func isRetryableStatus(status int) bool {
+	return isThrottled(status) || status == nethttp.StatusGatewayTimeout
+}
+
+// batchItemError produces the error for a failed request within a batch.
+// The OData error in the response body is returned when available, so that
+// callers can inspect it the same way as the errors of single requests.
+func batchItemError(resp batchResponseItem) error {
+	if len(resp.Body) > 0 {
+		p, err := support.CreateFromBytes(resp.Body, odataerrors.CreateODataErrorFromDiscriminatorValue)
+		if oerr, ok := p.(*odataerrors.ODataError); err == nil && ok && oerr.GetError() != nil {
+			return errors.Wrapf(oerr, "status %d: %s", resp.Status, support.ConnectorStackErrorTrace(oerr))
+		}
+	}
+
+	return &StatusError{Status: resp.Status, Msg: "batch request failed"}
+}
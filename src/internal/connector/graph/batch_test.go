@@ -0,0 +1,270 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/microsoft/kiota-abstractions-go/authentication"
+	msgraphsdk "github.com/microsoftgraph/msgraph-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type BatchUnitSuite struct {
+	suite.Suite
+}
+
+func TestBatchUnitSuite(t *testing.T) {
+	suite.Run(t, new(BatchUnitSuite))
+}
+
+// batchServer answers $batch requests.  respond produces the status of each
+// request, given the number of times its url has been requested.
+func batchServer(
+	t *testing.T,
+	respond func(url string, attempt int) int,
+) (*httptest.Server, func() []int) {
+	var (
+		mu       sync.Mutex
+		attempts = map[string]int{}
+		sizes    []int
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasSuffix(r.URL.Path, "/$batch"), r.URL.Path)
+
+		br := batchRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&br))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		sizes = append(sizes, len(br.Requests))
+		resp := batchResponse{}
+
+		for _, req := range br.Requests {
+			status := respond(req.URL, attempts[req.URL])
+			attempts[req.URL]++
+
+			item := batchResponseItem{ID: req.ID, Status: status}
+
+			switch {
+			case status == http.StatusOK:
+				item.Body = json.RawMessage(fmt.Sprintf(`{"id":%q}`, req.URL))
+			case status == http.StatusTooManyRequests:
+				item.Headers = map[string]string{"retry-after": "0"}
+			default:
+				item.Body = json.RawMessage(`{"error":{"code":"ErrorItemNotFound","message":"not found"}}`)
+			}
+
+			resp.Responses = append(resp.Responses, item)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+
+	return ts, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return sizes
+	}
+}
+
+func testAdapter(t *testing.T, baseURL string) *msgraphsdk.GraphRequestAdapter {
+	adapter, err := msgraphsdk.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient(
+		&authentication.AnonymousAuthenticationProvider{},
+		nil,
+		nil,
+		&http.Client{})
+	require.NoError(t, err)
+
+	adapter.SetBaseUrl(baseURL)
+
+	return adapter
+}
+
+func urls(n int) []string {
+	us := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		us = append(us, fmt.Sprintf("/users/u/messages/%d", i))
+	}
+
+	return us
+}
+
+func (suite *BatchUnitSuite) TestBatchGet() {
+	const missing = "/users/u/messages/3"
+
+	table := []struct {
+		name          string
+		urls          []string
+		respond       func(url string, attempt int) int
+		expectSizes   []int
+		expectFailed  []string
+		expectRetries int
+	}{
+		{
+			name:        "single batch",
+			urls:        urls(4),
+			respond:     func(string, int) int { return http.StatusOK },
+			expectSizes: []int{4},
+		},
+		{
+			name:        "split into batches",
+			urls:        urls(10),
+			respond:     func(string, int) int { return http.StatusOK },
+			expectSizes: []int{4, 4, 2},
+		},
+		{
+			name: "throttled requests retried",
+			urls: urls(4),
+			respond: func(url string, attempt int) int {
+				if url == missing && attempt == 0 {
+					return http.StatusTooManyRequests
+				}
+
+				return http.StatusOK
+			},
+			expectSizes:   []int{4, 1},
+			expectRetries: 1,
+		},
+		{
+			name: "failed request",
+			urls: urls(4),
+			respond: func(url string, _ int) int {
+				if url == missing {
+					return http.StatusNotFound
+				}
+
+				return http.StatusOK
+			},
+			expectSizes:  []int{4},
+			expectFailed: []string{missing},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			ctx, flush := tester.NewContext()
+			defer flush()
+
+			ts, sizes := batchServer(t, test.respond)
+			defer ts.Close()
+
+			results, err := BatchGet(ctx, testAdapter(t, ts.URL), "u", test.urls)
+			require.NoError(t, err)
+			require.Len(t, results, len(test.urls))
+
+			assert.Equal(t, test.expectSizes, sizes())
+
+			for i, r := range results {
+				failed := false
+
+				for _, f := range test.expectFailed {
+					failed = failed || f == test.urls[i]
+				}
+
+				if failed {
+					assert.Error(t, r.Err, test.urls[i])
+					assert.Empty(t, r.Body, test.urls[i])
+					assert.Equal(t, details.FailureNotFound, ClassifyError(r.Err), test.urls[i])
+
+					continue
+				}
+
+				if test.urls[i] == missing {
+					assert.Equal(t, test.expectRetries, r.Retries)
+				}
+
+				assert.NoError(t, r.Err, test.urls[i])
+				assert.JSONEq(t, fmt.Sprintf(`{"id":%q}`, test.urls[i]), string(r.Body))
+			}
+		})
+	}
+}
+
+func (suite *BatchUnitSuite) TestBatchGet_batchFails() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	t := suite.T()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	_, err := BatchGet(ctx, testAdapter(t, ts.URL), "u", urls(3))
+	assert.Error(t, err)
+}
+
+func (suite *BatchUnitSuite) TestBatchGet_oneBatchPerMailbox() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	var (
+		t                   = suite.T()
+		inFlight, maxFlight int32
+		wg                  sync.WaitGroup
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxFlight, m, n) {
+				break
+			}
+		}
+
+		br := batchRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&br))
+		assert.LessOrEqual(t, len(br.Requests), MailboxConcurrency)
+
+		time.Sleep(10 * time.Millisecond)
+
+		resp := batchResponse{}
+		for _, req := range br.Requests {
+			resp.Responses = append(resp.Responses, batchResponseItem{
+				ID:     req.ID,
+				Status: http.StatusOK,
+				Body:   json.RawMessage(`{}`),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer ts.Close()
+
+	adapter := testAdapter(t, ts.URL)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := BatchGet(ctx, adapter, "one-batch-per-mailbox", urls(8))
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), maxFlight)
+}
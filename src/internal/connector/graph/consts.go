@@ -24,4 +24,8 @@ const (
 	// PreviousPathFileName is the name of the file containing previous path(s) for a
 	// given endpoint.
 	PreviousPathFileName = "previouspath"
+
+	// TimeWindowFileName is the name of the file containing the time window
+	// the delta token(s) were produced with.
+	TimeWindowFileName = "timewindow"
 )
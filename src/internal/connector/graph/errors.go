@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"strings"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+// StatusError is returned for requests that failed with an http status,
+// when the response didn't describe the failure any further.
+type StatusError struct {
+	Status int
+	Msg    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: status %d", e.Msg, e.Status)
+}
+
+// graph error codes, by failure class.  Codes are compared case-insensitively.
+// https://learn.microsoft.com/en-us/graph/errors
+var errorCodeClasses = map[string]details.FailureClass{
+	"erroritemnotfound":            details.FailureNotFound,
+	"itemnotfound":                 details.FailureNotFound,
+	"resourcenotfound":             details.FailureNotFound,
+	"erroraccessdenied":            details.FailureAccessDenied,
+	"accessdenied":                 details.FailureAccessDenied,
+	"authorization_requestdenied":  details.FailureAccessDenied,
+	"errorinsufficientpermissions": details.FailureAccessDenied,
+	"toomanyrequests":              details.FailureThrottled,
+	"applicationthrottled":         details.FailureThrottled,
+	"activitylimitreached":         details.FailureThrottled,
+	"errorserverbusy":              details.FailureThrottled,
+	"errortimeoutexpired":          details.FailureTimeout,
+	"timeout":                      details.FailureTimeout,
+}
+
+// ClassifyError categorizes the cause of an item failure.
+func ClassifyError(err error) details.FailureClass {
+	if err == nil {
+		return details.FailureUnknown
+	}
+
+	var oerr odataerrors.ODataErrorable
+	if errors.As(err, &oerr) && oerr.GetError() != nil {
+		if code := oerr.GetError().GetCode(); code != nil {
+			if c, ok := errorCodeClasses[strings.ToLower(*code)]; ok {
+				return c
+			}
+		}
+
+		return details.FailureService
+	}
+
+	var se *StatusError
+	if errors.As(err, &se) {
+		return classifyStatus(se.Status)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || isTimeout(err) {
+		return details.FailureTimeout
+	}
+
+	return details.FailureUnknown
+}
+
+func classifyStatus(status int) details.FailureClass {
+	switch {
+	case status == nethttp.StatusNotFound || status == nethttp.StatusGone:
+		return details.FailureNotFound
+	case status == nethttp.StatusUnauthorized || status == nethttp.StatusForbidden:
+		return details.FailureAccessDenied
+	case isThrottled(status):
+		return details.FailureThrottled
+	case status == nethttp.StatusGatewayTimeout || status == nethttp.StatusRequestTimeout:
+		return details.FailureTimeout
+	case status/100 == 5:
+		return details.FailureService
+	default:
+		return details.FailureUnknown
+	}
+}
+
+// NewItemFailure records the failure of the item with the given M365 ID,
+// within the collection at path.
+func NewItemFailure(path, itemID string, err error, retries int) details.Failure {
+	return details.Failure{
+		Path:    path,
+		ItemID:  itemID,
+		Class:   ClassifyError(err),
+		Error:   err.Error(),
+		Retries: retries,
+	}
+}
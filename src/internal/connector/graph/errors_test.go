@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"context"
+	nethttp "net/http"
+	"testing"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type ErrorsUnitSuite struct {
+	suite.Suite
+}
+
+func TestErrorsUnitSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsUnitSuite))
+}
+
+func odataErr(code string) error {
+	odErr := odataerrors.NewODataError()
+	merr := odataerrors.NewMainError()
+	merr.SetCode(&code)
+	odErr.SetError(merr)
+
+	return odErr
+}
+
+func (suite *ErrorsUnitSuite) TestClassifyError() {
+	table := []struct {
+		name   string
+		err    error
+		expect details.FailureClass
+	}{
+		{
+			name:   "nil",
+			expect: details.FailureUnknown,
+		},
+		{
+			name:   "unknown",
+			err:    errors.New("fnords"),
+			expect: details.FailureUnknown,
+		},
+		{
+			name:   "odata not found",
+			err:    errors.Wrap(odataErr("ErrorItemNotFound"), "getting item"),
+			expect: details.FailureNotFound,
+		},
+		{
+			name:   "odata access denied",
+			err:    odataErr("ErrorAccessDenied"),
+			expect: details.FailureAccessDenied,
+		},
+		{
+			name:   "odata unknown code",
+			err:    odataErr("ErrorSmarf"),
+			expect: details.FailureService,
+		},
+		{
+			name:   "status not found",
+			err:    &StatusError{Status: nethttp.StatusNotFound},
+			expect: details.FailureNotFound,
+		},
+		{
+			name:   "status forbidden",
+			err:    &StatusError{Status: nethttp.StatusForbidden},
+			expect: details.FailureAccessDenied,
+		},
+		{
+			name:   "status throttled",
+			err:    errors.Wrap(&StatusError{Status: nethttp.StatusTooManyRequests}, "downloading"),
+			expect: details.FailureThrottled,
+		},
+		{
+			name:   "status server error",
+			err:    &StatusError{Status: nethttp.StatusInternalServerError},
+			expect: details.FailureService,
+		},
+		{
+			name:   "deadline exceeded",
+			err:    errors.Wrap(context.DeadlineExceeded, "getting item"),
+			expect: details.FailureTimeout,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, ClassifyError(test.err))
+		})
+	}
+}
@@ -12,7 +12,7 @@ import (
 // AllMetadataFileNames produces the standard set of filenames used to store graph
 // metadata such as delta tokens and folderID->path references.
 func AllMetadataFileNames() []string {
-	return []string{DeltaURLsFileName, PreviousPathFileName}
+	return []string{DeltaURLsFileName, PreviousPathFileName, TimeWindowFileName}
 }
 
 type QueryParams struct {
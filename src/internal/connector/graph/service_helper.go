@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	az "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	ka "github.com/microsoft/kiota-authentication-azure-go"
 	khttp "github.com/microsoft/kiota-http-go"
@@ -14,47 +16,174 @@ import (
 	msgraphgocore "github.com/microsoftgraph/msgraph-sdk-go-core"
 	"github.com/pkg/errors"
 
+	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
 const (
 	logGraphRequestsEnvKey = "LOG_GRAPH_REQUESTS"
+	requestTimeout         = 90 * time.Second
 )
 
 // CreateAdapter uses provided credentials to log into M365 using Kiota Azure Library
 // with Azure identity package. An adapter object is a necessary to component
 // to create  *msgraphsdk.GraphServiceClient
-func CreateAdapter(tenant, client, secret string) (*msgraphsdk.GraphRequestAdapter, error) {
-	// Client Provider: Uses Secret for access to tenant-level data
-	cred, err := az.NewClientSecretCredential(tenant, client, secret, nil)
+func CreateAdapter(creds account.M365Config) (*msgraphsdk.GraphRequestAdapter, error) {
+	ce, err := endpointsFor(creds.Cloud())
 	if err != nil {
-		return nil, errors.Wrap(err, "creating m365 client secret credentials")
+		return nil, err
 	}
 
-	auth, err := ka.NewAzureIdentityAuthenticationProviderWithScopes(
+	cred, err := createCredential(creds, ce)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := ka.NewAzureIdentityAuthenticationProviderWithScopesAndValidHosts(
 		cred,
-		[]string{"https://graph.microsoft.com/.default"},
+		[]string{ce.graphHost + "/.default"},
+		[]string{strings.TrimPrefix(ce.graphHost, "https://")},
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "creating new AzureIdentityAuthentication")
 	}
 
-	httpClient := CreateHTTPClient()
+	httpClient := CreateHTTPClient(creds.AzureTenantID)
 
-	return msgraphsdk.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient(
+	adapter, err := msgraphsdk.NewGraphRequestAdapterWithParseNodeFactoryAndSerializationWriterFactoryAndHttpClient(
 		auth, nil, nil, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// the graph service client only falls back to the public cloud when
+	// the base url is unset.
+	adapter.SetBaseUrl(ce.graphHost + graphVersionPath)
+
+	return adapter, nil
+}
+
+// createCredential produces the credential the app uses to access the tenant:
+// a certificate, if one is configured, otherwise the client secret.
+func createCredential(creds account.M365Config, ce cloudEndpoints) (azcore.TokenCredential, error) {
+	clientOpts := azcore.ClientOptions{Cloud: ce.authority}
+
+	if !creds.UsesCertificate() {
+		// Client Provider: Uses Secret for access to tenant-level data
+		cred, err := az.NewClientSecretCredential(
+			creds.AzureTenantID,
+			creds.AzureClientID,
+			creds.AzureClientSecret,
+			&az.ClientSecretCredentialOptions{ClientOptions: clientOpts})
+		if err != nil {
+			return nil, errors.Wrap(err, "creating m365 client secret credentials")
+		}
+
+		return cred, nil
+	}
+
+	certData, err := os.ReadFile(creds.AzureClientCertPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading m365 client certificate")
+	}
+
+	var password []byte
+	if len(creds.AzureClientCertPassword) > 0 {
+		password = []byte(creds.AzureClientCertPassword)
+	}
+
+	// handles both PEM and PKCS#12 encodings
+	certs, key, err := az.ParseCertificates(certData, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing m365 client certificate")
+	}
+
+	cred, err := az.NewClientCertificateCredential(
+		creds.AzureTenantID,
+		creds.AzureClientID,
+		certs,
+		key,
+		&az.ClientCertificateCredentialOptions{ClientOptions: clientOpts})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating m365 client certificate credentials")
+	}
+
+	return cred, nil
+}
+
+// ---------------------------------------------------------------------------
+// national clouds
+// ---------------------------------------------------------------------------
+
+const graphVersionPath = "/v1.0"
+
+// cloudEndpoints are the hosts used to authenticate and to reach graph
+// within an azure cloud.
+// https://learn.microsoft.com/en-us/graph/deployments
+type cloudEndpoints struct {
+	authority cloud.Configuration
+	graphHost string
+}
+
+var clouds = map[string]cloudEndpoints{
+	account.AzureCloudPublic: {
+		authority: cloud.AzurePublic,
+		graphHost: "https://graph.microsoft.com",
+	},
+	account.AzureCloudUSGovGCCHigh: {
+		authority: cloud.AzureGovernment,
+		graphHost: "https://graph.microsoft.us",
+	},
+	account.AzureCloudUSGovDoD: {
+		authority: cloud.AzureGovernment,
+		graphHost: "https://dod-graph.microsoft.us",
+	},
+	account.AzureCloudChina: {
+		authority: cloud.AzureChina,
+		graphHost: "https://microsoftgraph.chinacloudapi.cn",
+	},
 }
 
-// CreateHTTPClient creates the httpClient with middlewares and timeout configured
-func CreateHTTPClient() *nethttp.Client {
+func endpointsFor(azureCloud string) (cloudEndpoints, error) {
+	ce, ok := clouds[azureCloud]
+	if !ok {
+		return cloudEndpoints{}, errors.Errorf("unknown azure cloud: %s", azureCloud)
+	}
+
+	return ce, nil
+}
+
+// CreateHTTPClient creates the httpClient with middlewares and timeout configured.
+// Requests share the rate limit of all other clients for the tenant, and are
+// retried when throttled.
+func CreateHTTPClient(tenant string) *nethttp.Client {
+	return createHTTPClient(tenant, requestTimeout)
+}
+
+// CreateDownloadClient creates an httpClient for downloading item content from
+// pre-authenticated URLs.  Downloads aren't rate limited and have no timeout,
+// since large files can take arbitrarily long to transfer.
+func CreateDownloadClient() *nethttp.Client {
+	return createHTTPClient("", 0)
+}
+
+func createHTTPClient(tenant string, timeout time.Duration) *nethttp.Client {
 	clientOptions := msgraphsdk.GetDefaultClientOptions()
-	middlewares := msgraphgocore.GetDefaultMiddlewaresWithOptions(&clientOptions)
-	middlewares = append(middlewares, &LoggingMiddleware{})
-	httpClient := msgraphgocore.GetDefaultClient(&clientOptions, middlewares...)
-	httpClient.Timeout = time.Second * 90
 
-	return httpClient
+	// Replaces the default kiota retry handler, which ignores the tenant
+	// wide rate limit and retries without jitter.  The timeout is applied
+	// per attempt, so that waiting for retries doesn't count against it.
+	middlewares := []khttp.Middleware{
+		msgraphgocore.NewGraphTelemetryHandler(&clientOptions),
+		NewThrottlingMiddleware(tenant, timeout),
+		khttp.NewRedirectHandler(),
+		khttp.NewCompressionHandler(),
+		khttp.NewParametersNameDecodingHandler(),
+		&LoggingMiddleware{},
+	}
+
+	return msgraphgocore.GetDefaultClient(&clientOptions, middlewares...)
 }
 
 // LoggingMiddleware can be used to log the http request sent by the graph client
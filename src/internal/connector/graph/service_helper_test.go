@@ -0,0 +1,175 @@
+package graph
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	az "github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type ServiceHelperUnitSuite struct {
+	suite.Suite
+}
+
+func TestServiceHelperUnitSuite(t *testing.T) {
+	suite.Run(t, new(ServiceHelperUnitSuite))
+}
+
+// writeTestCert writes a self-signed certificate and its private key to a
+// PEM file in dir.
+func writeTestCert(t *testing.T, dir string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "corso-test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})...)
+
+	fp := filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(fp, data, 0o600))
+
+	return fp
+}
+
+func (suite *ServiceHelperUnitSuite) TestCreateCredential() {
+	dir := suite.T().TempDir()
+	certPath := writeTestCert(suite.T(), dir)
+
+	badCertPath := filepath.Join(dir, "bad.pem")
+	require.NoError(suite.T(), os.WriteFile(badCertPath, []byte("not a cert"), 0o600))
+
+	cfg := func(secret, certPath string) account.M365Config {
+		return account.M365Config{
+			M365: credentials.M365{
+				AzureClientID:       "cid",
+				AzureClientSecret:   secret,
+				AzureClientCertPath: certPath,
+			},
+			AzureTenantID: "tid",
+		}
+	}
+
+	table := []struct {
+		name       string
+		creds      account.M365Config
+		expectErr  assert.ErrorAssertionFunc
+		expectCert bool
+	}{
+		{
+			name:      "client secret",
+			creds:     cfg("secret", ""),
+			expectErr: assert.NoError,
+		},
+		{
+			name:       "certificate",
+			creds:      cfg("", certPath),
+			expectErr:  assert.NoError,
+			expectCert: true,
+		},
+		{
+			name:       "certificate preferred over secret",
+			creds:      cfg("secret", certPath),
+			expectErr:  assert.NoError,
+			expectCert: true,
+		},
+		{
+			name:      "missing certificate",
+			creds:     cfg("", filepath.Join(dir, "missing.pem")),
+			expectErr: assert.Error,
+		},
+		{
+			name:      "invalid certificate",
+			creds:     cfg("", badCertPath),
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			cred, err := createCredential(test.creds, clouds[account.AzureCloudPublic])
+			test.expectErr(t, err)
+
+			if err != nil {
+				return
+			}
+
+			_, isCert := cred.(*az.ClientCertificateCredential)
+			assert.Equal(t, test.expectCert, isCert)
+		})
+	}
+}
+
+func (suite *ServiceHelperUnitSuite) TestCreateAdapter_clouds() {
+	table := []struct {
+		cloud         string
+		expectBaseURL string
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			cloud:         "",
+			expectBaseURL: "https://graph.microsoft.com/v1.0",
+			expectErr:     assert.NoError,
+		},
+		{
+			cloud:         account.AzureCloudUSGovGCCHigh,
+			expectBaseURL: "https://graph.microsoft.us/v1.0",
+			expectErr:     assert.NoError,
+		},
+		{
+			cloud:         account.AzureCloudUSGovDoD,
+			expectBaseURL: "https://dod-graph.microsoft.us/v1.0",
+			expectErr:     assert.NoError,
+		},
+		{
+			cloud:         account.AzureCloudChina,
+			expectBaseURL: "https://microsoftgraph.chinacloudapi.cn/v1.0",
+			expectErr:     assert.NoError,
+		},
+		{
+			cloud:     "moon",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.cloud, func(t *testing.T) {
+			adapter, err := CreateAdapter(account.M365Config{
+				M365:          credentials.M365{AzureClientID: "cid", AzureClientSecret: "secret"},
+				AzureTenantID: "tid",
+				AzureCloud:    test.cloud,
+			})
+			test.expectErr(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expectBaseURL, adapter.GetBaseUrl())
+			assert.Equal(t, test.expectBaseURL, NewService(adapter).Adapter().GetBaseUrl())
+		})
+	}
+}
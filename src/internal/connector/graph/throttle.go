@@ -0,0 +1,420 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	nethttp "net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	khttp "github.com/microsoft/kiota-http-go"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+const (
+	retryAfterHeader   = "Retry-After"
+	retryAttemptHeader = "Retry-Attempt"
+
+	throttleMaxRetries = 6
+	throttleBaseDelay  = 2 * time.Second
+	throttleMaxDelay   = 2 * time.Minute
+
+	// Graph limits each application to a few thousand requests per tenant
+	// every 10 seconds.  Staying well below that leaves room for other
+	// applications and avoids being throttled in the first place.
+	// https://learn.microsoft.com/en-us/graph/throttling-limits
+	tenantRequestsPerSecond = 100
+	tenantRequestBurst      = 200
+)
+
+// ---------------------------------------------------------------------------
+// tenant rate limiting
+// ---------------------------------------------------------------------------
+
+// tokenBucket rate limits requests.  Tokens are refilled continuously at
+// rate per second, up to burst.  Requests may borrow tokens that haven't
+// been refilled yet, in which case they wait until the refill catches up.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// reserve takes n tokens from the bucket and returns how long the caller
+// must wait before using them.
+func (tb *tokenBucket) reserve(n int) time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := time.Now()
+
+	tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+
+	tb.last = now
+	tb.tokens -= float64(n)
+
+	if tb.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+}
+
+// throttler holds the request rate limit and throttling counters shared by
+// all clients for a tenant.
+type throttler struct {
+	bucket *tokenBucket
+
+	throttled     int64
+	retries       int64
+	retryWait     int64
+	rateLimitWait int64
+}
+
+var (
+	throttlersMu sync.Mutex
+	throttlers   = map[string]*throttler{}
+)
+
+// tenantThrottler returns the throttler for the tenant.  Requests without a
+// tenant, such as downloads from pre-authenticated URLs, aren't rate limited.
+func tenantThrottler(tenant string) *throttler {
+	if len(tenant) == 0 {
+		return &throttler{}
+	}
+
+	throttlersMu.Lock()
+	defer throttlersMu.Unlock()
+
+	t, ok := throttlers[tenant]
+	if !ok {
+		t = &throttler{bucket: newTokenBucket(tenantRequestsPerSecond, tenantRequestBurst)}
+		throttlers[tenant] = t
+	}
+
+	return t
+}
+
+// wait blocks until the tenant's rate limit allows another request.  The
+// request costs as many tokens as the requests it carries.
+func (t *throttler) wait(ctx context.Context) error {
+	if t.bucket == nil {
+		return nil
+	}
+
+	d := t.bucket.reserve(requestCost(ctx))
+	if d <= 0 {
+		return nil
+	}
+
+	atomic.AddInt64(&t.rateLimitWait, int64(d))
+
+	return sleep(ctx, d)
+}
+
+type requestCostKey struct{}
+
+// withRequestCost marks the requests made with ctx as carrying n requests,
+// such as a $batch, each of which counts against the tenant's rate limit.
+func withRequestCost(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, requestCostKey{}, n)
+}
+
+// requestCost returns the number of requests carried by a request made with
+// ctx.
+func requestCost(ctx context.Context) int {
+	if n, ok := ctx.Value(requestCostKey{}).(int); ok && n > 0 {
+		return n
+	}
+
+	return 1
+}
+
+func (t *throttler) stats() stats.Throttling {
+	return stats.Throttling{
+		ThrottledRequests: int(atomic.LoadInt64(&t.throttled)),
+		Retries:           int(atomic.LoadInt64(&t.retries)),
+		RetryWait:         time.Duration(atomic.LoadInt64(&t.retryWait)),
+		RateLimitWait:     time.Duration(atomic.LoadInt64(&t.rateLimitWait)),
+	}
+}
+
+// ThrottleStats returns the running totals of throttled requests and
+// retries for all clients of the tenant.
+func ThrottleStats(tenant string) stats.Throttling {
+	return tenantThrottler(tenant).stats()
+}
+
+// ---------------------------------------------------------------------------
+// middleware
+// ---------------------------------------------------------------------------
+
+// ThrottlingMiddleware rate limits requests for a tenant, and retries
+// requests that fail due to throttling or transient errors.  The delay
+// requested by the service in the Retry-After header is honored, otherwise
+// retries back off exponentially with jitter.
+type ThrottlingMiddleware struct {
+	throttler *throttler
+	// attemptTimeout bounds each attempt, unless it's zero.
+	attemptTimeout time.Duration
+	maxRetries     int
+	baseDelay      time.Duration
+}
+
+// NewThrottlingMiddleware produces a middleware sharing the rate limit of
+// the tenant.  attemptTimeout bounds each attempt at the request, including
+// reading the response body; zero disables the timeout.
+func NewThrottlingMiddleware(tenant string, attemptTimeout time.Duration) *ThrottlingMiddleware {
+	return &ThrottlingMiddleware{
+		throttler:      tenantThrottler(tenant),
+		attemptTimeout: attemptTimeout,
+		maxRetries:     throttleMaxRetries,
+		baseDelay:      throttleBaseDelay,
+	}
+}
+
+// Intercept implements the kiota Middleware interface.
+func (mw *ThrottlingMiddleware) Intercept(
+	pipeline khttp.Pipeline,
+	middlewareIndex int,
+	req *nethttp.Request,
+) (*nethttp.Response, error) {
+	ctx := req.Context()
+
+	// Bodies need to be re-sent on every retry.  Graph request bodies are
+	// serialized in memory anyway, so buffering them costs little.
+	if req.Body != nil && req.Body != nethttp.NoBody && req.GetBody == nil {
+		bs, err := io.ReadAll(req.Body)
+		req.Body.Close()
+
+		if err != nil {
+			return nil, errors.Wrap(err, "reading request body")
+		}
+
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(bs)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+
+	for retry := 0; ; retry++ {
+		if err := mw.throttler.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := mw.attempt(pipeline, middlewareIndex, req)
+
+		throttled := resp != nil && isThrottled(resp.StatusCode)
+		if throttled {
+			atomic.AddInt64(&mw.throttler.throttled, 1)
+		}
+
+		if retry >= mw.maxRetries || !mw.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := backoff(mw.baseDelay, retry)
+		if ra := retryAfter(resp); ra > 0 {
+			delay = ra
+		}
+
+		logger.Ctx(ctx).Debugw(
+			"retrying graph request",
+			"method", req.Method,
+			"url", req.URL,
+			"retry", retry+1,
+			"delay", delay,
+			"throttled", throttled,
+			"error", err)
+
+		if resp != nil {
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		atomic.AddInt64(&mw.throttler.retries, 1)
+		atomic.AddInt64(&mw.throttler.retryWait, int64(delay))
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, errors.Wrap(err, "rewinding request body")
+			}
+
+			req.Body = body
+		}
+
+		req.Header.Set(retryAttemptHeader, strconv.Itoa(retry+1))
+	}
+}
+
+// attempt sends the request once, bounded by the attempt timeout.
+func (mw *ThrottlingMiddleware) attempt(
+	pipeline khttp.Pipeline,
+	middlewareIndex int,
+	req *nethttp.Request,
+) (*nethttp.Response, error) {
+	if mw.attemptTimeout == 0 {
+		return pipeline.Next(req, middlewareIndex)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), mw.attemptTimeout)
+
+	resp, err := pipeline.Next(req.WithContext(ctx), middlewareIndex)
+	if err != nil || resp == nil {
+		cancel()
+
+		if err != nil && ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			err = &attemptTimeoutError{err}
+		}
+
+		return resp, err
+	}
+
+	// the timeout must keep running until the caller finishes reading the body
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// retryable returns true if the request failed in a way that may succeed
+// when sent again.
+func (mw *ThrottlingMiddleware) retryable(req *nethttp.Request, resp *nethttp.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		// The service may have processed a request that timed out, so only
+		// requests without side effects are retried.
+		return isTimeout(err) && (req.Method == nethttp.MethodGet || req.Method == nethttp.MethodHead)
+	}
+
+	return isThrottled(resp.StatusCode) || resp.StatusCode == nethttp.StatusGatewayTimeout
+}
+
+func isThrottled(status int) bool {
+	return status == nethttp.StatusTooManyRequests || status == nethttp.StatusServiceUnavailable
+}
+
+func isTimeout(err error) bool {
+	var ate *attemptTimeoutError
+	if errors.As(err, &ate) {
+		return true
+	}
+
+	var ne net.Error
+
+	return errors.As(err, &ne) && ne.Timeout()
+}
+
+// attemptTimeoutError marks a request that exceeded the attempt timeout.
+type attemptTimeoutError struct {
+	err error
+}
+
+func (e *attemptTimeoutError) Error() string {
+	return "request timed out: " + e.err.Error()
+}
+
+func (e *attemptTimeoutError) Unwrap() error {
+	return e.err
+}
+
+func (e *attemptTimeoutError) Timeout() bool {
+	return true
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// retryAfter returns the delay requested in the Retry-After header of resp,
+// given either in seconds or as a date.
+func retryAfter(resp *nethttp.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+
+	return parseRetryAfter(resp.Header.Get(retryAfterHeader))
+}
+
+// parseRetryAfter returns the delay in a Retry-After header value.
+func parseRetryAfter(ra string) time.Duration {
+	if len(ra) == 0 {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(ra); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := nethttp.ParseTime(ra); err == nil {
+		return time.Until(t)
+	}
+
+	return 0
+}
+
+// backoff doubles the delay after each retry, up to throttleMaxDelay.
+// A random jitter of up to half the delay keeps concurrent requests from
+// retrying in lockstep.
+func backoff(base time.Duration, retry int) time.Duration {
+	d := base << retry
+	if d <= 0 || d > throttleMaxDelay {
+		d = throttleMaxDelay
+	}
+
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+
+	//nolint:gosec // jitter doesn't need a secure random source
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
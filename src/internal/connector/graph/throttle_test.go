@@ -0,0 +1,335 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	khttp "github.com/microsoft/kiota-http-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ThrottlingMiddlewareUnitSuite struct {
+	suite.Suite
+}
+
+func TestThrottlingMiddlewareUnitSuite(t *testing.T) {
+	suite.Run(t, new(ThrottlingMiddlewareUnitSuite))
+}
+
+// testClient produces a client that only runs the middleware, using a
+// throttler that isn't shared with any other test.
+func testClient(attemptTimeout time.Duration) (*http.Client, *throttler) {
+	mw := &ThrottlingMiddleware{
+		throttler:      &throttler{},
+		attemptTimeout: attemptTimeout,
+		maxRetries:     3,
+		baseDelay:      time.Millisecond,
+	}
+
+	return &http.Client{Transport: khttp.NewCustomTransport(mw)}, mw.throttler
+}
+
+// statusServer responds with each of the statuses in turn, then with 200s.
+func statusServer(t *testing.T, statuses ...int) (*httptest.Server, func() []string) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bs, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		mu.Lock()
+		attempt := len(bodies)
+		bodies = append(bodies, string(bs))
+		mu.Unlock()
+
+		if attempt < len(statuses) {
+			w.Header().Set(retryAfterHeader, "0")
+			w.WriteHeader(statuses[attempt])
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return ts, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return bodies
+	}
+}
+
+func (suite *ThrottlingMiddlewareUnitSuite) TestIntercept() {
+	table := []struct {
+		name            string
+		statuses        []int
+		expectStatus    int
+		expectAttempts  int
+		expectThrottled int
+	}{
+		{
+			name:           "success",
+			expectStatus:   http.StatusOK,
+			expectAttempts: 1,
+		},
+		{
+			name:            "too many requests",
+			statuses:        []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			expectStatus:    http.StatusOK,
+			expectAttempts:  3,
+			expectThrottled: 2,
+		},
+		{
+			name:            "service unavailable",
+			statuses:        []int{http.StatusServiceUnavailable},
+			expectStatus:    http.StatusOK,
+			expectAttempts:  2,
+			expectThrottled: 1,
+		},
+		{
+			name:           "gateway timeout",
+			statuses:       []int{http.StatusGatewayTimeout},
+			expectStatus:   http.StatusOK,
+			expectAttempts: 2,
+		},
+		{
+			name:           "not retried",
+			statuses:       []int{http.StatusBadRequest},
+			expectStatus:   http.StatusBadRequest,
+			expectAttempts: 1,
+		},
+		{
+			name: "out of retries",
+			statuses: []int{
+				http.StatusTooManyRequests,
+				http.StatusTooManyRequests,
+				http.StatusTooManyRequests,
+				http.StatusTooManyRequests,
+			},
+			expectStatus:    http.StatusTooManyRequests,
+			expectAttempts:  4,
+			expectThrottled: 4,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			ts, bodies := statusServer(t, test.statuses...)
+			defer ts.Close()
+
+			client, thr := testClient(0)
+
+			req, err := http.NewRequest(http.MethodPost, ts.URL, nil)
+			require.NoError(t, err)
+
+			// kiota sets bodies that can't be rewound
+			req.Body = io.NopCloser(bytes.NewReader([]byte("body")))
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, test.expectStatus, resp.StatusCode)
+
+			attempts := bodies()
+			assert.Len(t, attempts, test.expectAttempts)
+
+			for _, b := range attempts {
+				assert.Equal(t, "body", b)
+			}
+
+			st := thr.stats()
+			assert.Equal(t, test.expectThrottled, st.ThrottledRequests)
+			assert.Equal(t, test.expectAttempts-1, st.Retries)
+		})
+	}
+}
+
+func (suite *ThrottlingMiddlewareUnitSuite) TestIntercept_attemptTimeout() {
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		first := attempts == 1
+		mu.Unlock()
+
+		if first {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	table := []struct {
+		name           string
+		method         string
+		expectErr      assert.ErrorAssertionFunc
+		expectAttempts int
+	}{
+		{
+			name:           "get is retried",
+			method:         http.MethodGet,
+			expectErr:      assert.NoError,
+			expectAttempts: 2,
+		},
+		{
+			name:           "post is not retried",
+			method:         http.MethodPost,
+			expectErr:      assert.Error,
+			expectAttempts: 1,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			mu.Lock()
+			attempts = 0
+			mu.Unlock()
+
+			client, _ := testClient(50 * time.Millisecond)
+
+			req, err := http.NewRequest(test.method, ts.URL, nil)
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			test.expectErr(t, err)
+
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			assert.Equal(t, test.expectAttempts, attempts)
+		})
+	}
+}
+
+func (suite *ThrottlingMiddlewareUnitSuite) TestIntercept_cancelled() {
+	t := suite.T()
+
+	ts, _ := statusServer(t, http.StatusTooManyRequests)
+	defer ts.Close()
+
+	mw := &ThrottlingMiddleware{
+		throttler:  &throttler{},
+		maxRetries: 3,
+		baseDelay:  time.Hour,
+	}
+	client := &http.Client{Transport: khttp.NewCustomTransport(mw)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func (suite *ThrottlingMiddlewareUnitSuite) TestTokenBucket() {
+	t := suite.T()
+	tb := newTokenBucket(10, 2)
+
+	assert.Zero(t, tb.reserve(1))
+	assert.Zero(t, tb.reserve(1))
+
+	// the third request borrows a token that refills in 1/10th of a second
+	d := tb.reserve(1)
+	assert.Greater(t, d, 50*time.Millisecond)
+	assert.LessOrEqual(t, d, 100*time.Millisecond)
+
+	// a batch of 5 borrows 5 more tokens
+	d = tb.reserve(5)
+	assert.Greater(t, d, 550*time.Millisecond)
+	assert.LessOrEqual(t, d, 600*time.Millisecond)
+}
+
+func (suite *ThrottlingMiddlewareUnitSuite) TestRequestCost() {
+	t := suite.T()
+	ctx := context.Background()
+
+	assert.Equal(t, 1, requestCost(ctx))
+	assert.Equal(t, 20, requestCost(withRequestCost(ctx, 20)))
+	assert.Equal(t, 1, requestCost(withRequestCost(ctx, 0)))
+}
+
+func (suite *ThrottlingMiddlewareUnitSuite) TestRetryAfter() {
+	table := []struct {
+		name   string
+		header string
+		expect func(*testing.T, time.Duration)
+	}{
+		{
+			name:   "missing",
+			header: "",
+			expect: func(t *testing.T, d time.Duration) { assert.Zero(t, d) },
+		},
+		{
+			name:   "seconds",
+			header: "7",
+			expect: func(t *testing.T, d time.Duration) { assert.Equal(t, 7*time.Second, d) },
+		},
+		{
+			name:   "date",
+			header: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat),
+			expect: func(t *testing.T, d time.Duration) {
+				assert.Greater(t, d, 50*time.Second)
+				assert.LessOrEqual(t, d, time.Minute)
+			},
+		},
+		{
+			name:   "invalid",
+			header: "soon",
+			expect: func(t *testing.T, d time.Duration) { assert.Zero(t, d) },
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if len(test.header) > 0 {
+				resp.Header.Set(retryAfterHeader, test.header)
+			}
+
+			test.expect(t, retryAfter(resp))
+		})
+	}
+}
+
+func (suite *ThrottlingMiddlewareUnitSuite) TestBackoff() {
+	t := suite.T()
+
+	for retry := 0; retry < 10; retry++ {
+		d := backoff(time.Second, retry)
+		full := time.Second << retry
+
+		if full > throttleMaxDelay {
+			full = throttleMaxDelay
+		}
+
+		assert.GreaterOrEqual(t, d, full/2, "retry %d", retry)
+		assert.LessOrEqual(t, d, full, "retry %d", retry)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/alcionai/corso/src/internal/connector/support"
 	"github.com/alcionai/corso/src/internal/data"
 	D "github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
@@ -49,6 +50,9 @@ type GraphConnector struct {
 	// mutex used to synchronize updates to `status`
 	mu     sync.Mutex
 	status support.ConnectorOperationStatus // contains the status of the last run status
+
+	// throttling of the tenant's requests when the connector was created
+	throttleBaseline stats.Throttling
 }
 
 type resource int
@@ -67,10 +71,11 @@ func NewGraphConnector(ctx context.Context, acct account.Account, r resource) (*
 	}
 
 	gc := GraphConnector{
-		tenant:      m365.AzureTenantID,
-		Users:       make(map[string]string, 0),
-		wg:          &sync.WaitGroup{},
-		credentials: m365,
+		tenant:           m365.AzureTenantID,
+		Users:            make(map[string]string, 0),
+		wg:               &sync.WaitGroup{},
+		credentials:      m365,
+		throttleBaseline: graph.ThrottleStats(m365.AzureTenantID),
 	}
 
 	gService, err := gc.createService()
@@ -101,11 +106,7 @@ func NewGraphConnector(ctx context.Context, acct account.Account, r resource) (*
 
 // createService constructor for graphService component
 func (gc *GraphConnector) createService() (*graph.Service, error) {
-	adapter, err := graph.CreateAdapter(
-		gc.credentials.AzureTenantID,
-		gc.credentials.AzureClientID,
-		gc.credentials.AzureClientSecret,
-	)
+	adapter, err := graph.CreateAdapter(gc.credentials)
 	if err != nil {
 		return &graph.Service{}, err
 	}
@@ -271,6 +272,7 @@ func (gc *GraphConnector) RestoreDataCollections(
 	ctx context.Context,
 	selector selectors.Selector,
 	dest control.RestoreDestination,
+	opts control.Options,
 	dcs []data.Collection,
 ) (*details.Details, error) {
 	ctx, end := D.Span(ctx, "connector:restore")
@@ -284,11 +286,11 @@ func (gc *GraphConnector) RestoreDataCollections(
 
 	switch selector.Service {
 	case selectors.ServiceExchange:
-		status, err = exchange.RestoreExchangeDataCollections(ctx, gc.Service, dest, dcs, deets)
+		status, err = exchange.RestoreExchangeDataCollections(ctx, gc.Service, dest, opts, dcs, deets)
 	case selectors.ServiceOneDrive:
-		status, err = onedrive.RestoreCollections(ctx, gc.Service, dest, dcs, deets)
+		status, err = onedrive.RestoreCollections(ctx, gc.Service, dest, opts, dcs, deets)
 	case selectors.ServiceSharePoint:
-		status, err = sharepoint.RestoreCollections(ctx, gc.Service, dest, dcs, deets)
+		status, err = sharepoint.RestoreCollections(ctx, gc.Service, dest, opts, dcs, deets)
 	default:
 		err = errors.Errorf("restore data from service %s not supported", selector.Service.String())
 	}
@@ -299,6 +301,13 @@ func (gc *GraphConnector) RestoreDataCollections(
 	return deets, err
 }
 
+// ThrottleStats returns the throttling of requests to the tenant since the
+// connector was created.  All clients for a tenant share a rate limit, so
+// this includes requests made concurrently by other connectors.
+func (gc *GraphConnector) ThrottleStats() stats.Throttling {
+	return graph.ThrottleStats(gc.tenant).Since(gc.throttleBaseline)
+}
+
 // AwaitStatus waits for all gc tasks to complete and then returns status
 func (gc *GraphConnector) AwaitStatus() *support.ConnectorOperationStatus {
 	defer func() {
@@ -265,7 +265,7 @@ func (suite *GraphConnectorIntegrationSuite) TestEmptyCollections() {
 			ctx, flush := tester.NewContext()
 			defer flush()
 
-			deets, err := suite.connector.RestoreDataCollections(ctx, test.sel, dest, test.col)
+			deets, err := suite.connector.RestoreDataCollections(ctx, test.sel, dest, control.Options{}, test.col)
 			require.NoError(t, err)
 			assert.NotNil(t, deets)
 
@@ -351,7 +351,7 @@ func runRestoreBackupTest(
 
 	restoreGC := loadConnector(ctx, t, test.resource)
 	restoreSel := getSelectorWith(test.service)
-	deets, err := restoreGC.RestoreDataCollections(ctx, restoreSel, dest, collections)
+	deets, err := restoreGC.RestoreDataCollections(ctx, restoreSel, dest, control.Options{}, collections)
 	require.NoError(t, err)
 	assert.NotNil(t, deets)
 
@@ -835,7 +835,7 @@ func (suite *GraphConnectorIntegrationSuite) TestMultiFolderBackupDifferentNames
 				)
 
 				restoreGC := loadConnector(ctx, t, test.resource)
-				deets, err := restoreGC.RestoreDataCollections(ctx, restoreSel, dest, collections)
+				deets, err := restoreGC.RestoreDataCollections(ctx, restoreSel, dest, control.Options{}, collections)
 				require.NoError(t, err)
 				require.NotNil(t, deets)
 
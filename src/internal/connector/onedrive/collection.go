@@ -4,10 +4,8 @@ package onedrive
 import (
 	"context"
 	"io"
-	"net/url"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
@@ -28,16 +26,13 @@ const (
 
 	// TODO: Tune this later along with collectionChannelBufferSize
 	urlPrefetchChannelBufferSize = 5
-
-	// Max number of retries to get doc from M365
-	// Seems to timeout at times because of multiple requests
-	maxRetries = 4 // 1 + 3 retries
 )
 
 var (
-	_ data.Collection = &Collection{}
-	_ data.Stream     = &Item{}
-	_ data.StreamInfo = &Item{}
+	_ data.Collection          = &Collection{}
+	_ data.CollectionEstimator = &Collection{}
+	_ data.Stream              = &Item{}
+	_ data.StreamInfo          = &Item{}
 	// TODO(ashmrtn): Uncomment when #1702 is resolved.
 	//_ data.StreamModTime = &Item{}
 )
@@ -102,6 +97,27 @@ func (oc *Collection) Add(item models.DriveItemable) {
 	oc.driveItems = append(oc.driveItems, item)
 }
 
+// EstimateItems lists the files in the collection, with their sizes.
+func (oc *Collection) EstimateItems() []data.ItemEstimate {
+	es := make([]data.ItemEstimate, 0, len(oc.driveItems))
+
+	for _, item := range oc.driveItems {
+		e := data.ItemEstimate{Size: -1}
+
+		if item.GetName() != nil {
+			e.UUID = *item.GetName()
+		}
+
+		if item.GetSize() != nil {
+			e.Size = *item.GetSize()
+		}
+
+		es = append(es, e)
+	}
+
+	return es
+}
+
 // Items() returns the channel containing M365 Exchange objects
 func (oc *Collection) Items() <-chan data.Stream {
 	go oc.populateItems(context.Background())
@@ -156,24 +172,12 @@ func (od *Item) Info() details.ItemInfo {
 //	return od.info.Modified
 //}
 
-// isTimeoutErr is used to determine if the Graph error returned is
-// because of Timeout. This is used to restrict retries to just
-// timeouts as other errors are handled within a middleware in the
-// client.
-func isTimeoutErr(err error) bool {
-	switch err := err.(type) {
-	case *url.Error:
-		return err.Timeout()
-	default:
-		return false
-	}
-}
-
 // populateItems iterates through items added to the collection
 // and uses the collection `itemReader` to read the item
 func (oc *Collection) populateItems(ctx context.Context) {
 	var (
 		errs      error
+		failures  []details.Failure
 		byteCount int64
 		itemsRead int64
 		wg        sync.WaitGroup
@@ -184,7 +188,7 @@ func (oc *Collection) populateItems(ctx context.Context) {
 	// `details.OneDriveInfo`
 	parentPathString, err := getDriveFolderPath(oc.folderPath)
 	if err != nil {
-		oc.reportAsCompleted(ctx, 0, 0, err)
+		oc.reportAsCompleted(ctx, 0, 0, err, nil)
 		return
 	}
 
@@ -199,14 +203,26 @@ func (oc *Collection) populateItems(ctx context.Context) {
 	semaphoreCh := make(chan struct{}, urlPrefetchChannelBufferSize)
 	defer close(semaphoreCh)
 
-	errUpdater := func(id string, err error) {
+	// itemFailed records an item that couldn't be backed up.
+	itemFailed := func(id string, err error) {
+		f := graph.NewItemFailure(oc.folderPath.String(), id, err, 0)
+
 		m.Lock()
+		defer m.Unlock()
+
 		errs = support.WrapAndAppend(id, err, errs)
-		m.Unlock()
+		failures = append(failures, f)
+	}
+
+	hasErrs := func() bool {
+		m.Lock()
+		defer m.Unlock()
+
+		return errs != nil
 	}
 
 	for _, item := range oc.driveItems {
-		if oc.ctrl.FailFast && errs != nil {
+		if oc.ctrl.FailFast && hasErrs() {
 			break
 		}
 
@@ -218,31 +234,11 @@ func (oc *Collection) populateItems(ctx context.Context) {
 			defer wg.Done()
 			defer func() { <-semaphoreCh }()
 
-			// Read the item
-			var (
-				itemInfo details.ItemInfo
-				itemData io.ReadCloser
-				err      error
-			)
-
-			for i := 1; i <= maxRetries; i++ {
-				itemInfo, itemData, err = oc.itemReader(ctx, item)
-
-				// We only retry if it is a timeout error. Other
-				// errors like throttling are already handled within
-				// the graph client via a retry middleware.
-				// https://github.com/microsoftgraph/msgraph-sdk-go/issues/302
-				if err == nil || !isTimeoutErr(err) {
-					break
-				}
-
-				if i < maxRetries {
-					time.Sleep(1 * time.Second)
-				}
-			}
-
+			// Read the item.  Throttled and timed out requests are
+			// retried by the graph client.
+			itemInfo, itemData, err := oc.itemReader(ctx, item)
 			if err != nil {
-				errUpdater(*item.GetId(), err)
+				itemFailed(*item.GetId(), err)
 				return
 			}
 
@@ -281,10 +277,16 @@ func (oc *Collection) populateItems(ctx context.Context) {
 
 	wg.Wait()
 
-	oc.reportAsCompleted(ctx, int(itemsRead), byteCount, errs)
+	oc.reportAsCompleted(ctx, int(itemsRead), byteCount, errs, failures)
 }
 
-func (oc *Collection) reportAsCompleted(ctx context.Context, itemsRead int, byteCount int64, errs error) {
+func (oc *Collection) reportAsCompleted(
+	ctx context.Context,
+	itemsRead int,
+	byteCount int64,
+	errs error,
+	failures []details.Failure,
+) {
 	close(oc.data)
 
 	status := support.CreateStatus(ctx, support.Backup,
@@ -297,6 +299,7 @@ func (oc *Collection) reportAsCompleted(ctx context.Context, itemsRead int, byte
 		errs,
 		oc.folderPath.Folder(), // Additional details
 	)
+	status.Failures = failures
 	logger.Ctx(ctx).Debug(status.String())
 	oc.statusUpdater(status)
 }
@@ -20,6 +20,7 @@ import (
 
 var (
 	errFolderNotFound = errors.New("folder not found")
+	errItemNotFound   = errors.New("item not found")
 
 	// nolint:lll
 	// OneDrive associated SKUs located at:
@@ -59,11 +60,18 @@ var (
 const (
 	// nextLinkKey is used to find the next link in a paged
 	// graph response
-	nextLinkKey           = "@odata.nextLink"
-	itemChildrenRawURLFmt = "https://graph.microsoft.com/v1.0/drives/%s/items/%s/children"
-	itemByPathRawURLFmt   = "https://graph.microsoft.com/v1.0/drives/%s/items/%s:/%s"
+	nextLinkKey = "@odata.nextLink"
+	// raw urls are prefixed with the base url of the service's cloud
+	itemChildrenRawURLFmt = "%s/drives/%s/items/%s/children"
+	itemByPathRawURLFmt   = "%s/drives/%s/items/%s:/%s"
 	itemNotFoundErrorCode = "itemNotFound"
 	userDoesNotHaveDrive  = "BadRequest Unable to retrieve user's mysite URL"
+	// nameAlreadyExistsErrorCode is returned when creating an item that
+	// collides with an existing one and the conflict behavior is "fail".
+	nameAlreadyExistsErrorCode = "nameAlreadyExists"
+	// conflictBehaviorKey sets how graph resolves name collisions when
+	// creating a drive item.
+	conflictBehaviorKey = "@microsoft.graph.conflictBehavior"
 )
 
 // Enumerates the drives for the specified user
@@ -193,44 +201,96 @@ func collectItems(
 	return nil
 }
 
+func hasErrorCode(err error, code string) bool {
+	var oDataError *odataerrors.ODataError
+	if !errors.As(err, &oDataError) {
+		return false
+	}
+
+	return oDataError.GetError() != nil &&
+		oDataError.GetError().GetCode() != nil &&
+		*oDataError.GetError().GetCode() == code
+}
+
 // getFolder will lookup the specified folder name under `parentFolderID`
 func getFolder(
 	ctx context.Context,
 	service graph.Servicer,
 	driveID, parentFolderID, folderName string,
 ) (models.DriveItemable, error) {
-	// The `Children().Get()` API doesn't yet support $filter, so using that to find a folder
+	foundItem, err := getItem(ctx, service, driveID, parentFolderID, folderName)
+	if err != nil {
+		if errors.Is(err, errItemNotFound) {
+			return nil, errors.WithStack(errFolderNotFound)
+		}
+
+		return nil, err
+	}
+
+	// Check if the item found is a folder, fail the call if not
+	if foundItem.GetFolder() == nil {
+		return nil, errors.WithStack(errFolderNotFound)
+	}
+
+	return foundItem, nil
+}
+
+// getItem will lookup the specified item name under `parentFolderID`
+func getItem(
+	ctx context.Context,
+	service graph.Servicer,
+	driveID, parentFolderID, itemName string,
+) (models.DriveItemable, error) {
+	// The `Children().Get()` API doesn't yet support $filter, so using that to find an item
 	// will be sub-optimal.
 	// Instead, we leverage OneDrive path-based addressing -
 	// https://learn.microsoft.com/en-us/graph/onedrive-addressing-driveitems#path-based-addressing
 	// - which allows us to lookup an item by its path relative to the parent ID
-	rawURL := fmt.Sprintf(itemByPathRawURLFmt, driveID, parentFolderID, folderName)
+	rawURL := fmt.Sprintf(
+		itemByPathRawURLFmt,
+		service.Adapter().GetBaseUrl(),
+		driveID,
+		parentFolderID,
+		itemName)
 	builder := msdrive.NewItemsDriveItemItemRequestBuilder(rawURL, service.Adapter())
 
 	foundItem, err := builder.Get(ctx, nil)
 	if err != nil {
-		var oDataError *odataerrors.ODataError
-		if errors.As(err, &oDataError) &&
-			oDataError.GetError() != nil &&
-			oDataError.GetError().GetCode() != nil &&
-			*oDataError.GetError().GetCode() == itemNotFoundErrorCode {
-			return nil, errors.WithStack(errFolderNotFound)
+		if hasErrorCode(err, itemNotFoundErrorCode) {
+			return nil, errors.WithStack(errItemNotFound)
 		}
 
 		return nil, errors.Wrapf(err,
-			"failed to get folder %s/%s. details: %s",
+			"failed to get item %s/%s. details: %s",
 			parentFolderID,
-			folderName,
+			itemName,
 			support.ConnectorStackErrorTrace(err),
 		)
 	}
 
-	// Check if the item found is a folder, fail the call if not
-	if foundItem.GetFolder() == nil {
-		return nil, errors.WithStack(errFolderNotFound)
+	return foundItem, nil
+}
+
+// renameItem renames the item within its folder.
+func renameItem(
+	ctx context.Context,
+	service graph.Servicer,
+	driveID, itemID, name string,
+) (models.DriveItemable, error) {
+	update := models.NewDriveItem()
+	update.SetName(&name)
+
+	renamed, err := service.Client().DrivesById(driveID).ItemsById(itemID).Patch(ctx, update, nil)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"failed to rename item %s. details: %s",
+			itemID,
+			support.ConnectorStackErrorTrace(err),
+		)
 	}
 
-	return foundItem, nil
+	return renamed, nil
 }
 
 // Create a new item in the specified folder
@@ -242,7 +302,7 @@ func createItem(
 ) (models.DriveItemable, error) {
 	// Graph SDK doesn't yet provide a POST method for `/children` so we set the `rawUrl` ourselves as recommended
 	// here: https://github.com/microsoftgraph/msgraph-sdk-go/issues/155#issuecomment-1136254310
-	rawURL := fmt.Sprintf(itemChildrenRawURLFmt, driveID, parentFolderID)
+	rawURL := fmt.Sprintf(itemChildrenRawURLFmt, service.Adapter().GetBaseUrl(), driveID, parentFolderID)
 
 	builder := msdrive.NewItemsRequestBuilder(rawURL, service.Adapter())
 
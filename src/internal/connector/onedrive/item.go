@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 
 	msdrives "github.com/microsoftgraph/msgraph-sdk-go/drives"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -77,14 +78,23 @@ func driveItemReader(
 	ctx context.Context,
 	url string,
 ) (io.ReadCloser, error) {
-	httpClient := graph.CreateHTTPClient()
-	httpClient.Timeout = 0 // infinite timeout for pulling large files
+	httpClient := graph.CreateDownloadClient()
 
-	resp, err := httpClient.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating download request")
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to download file from %s", url)
 	}
 
+	if resp.StatusCode/100 != 2 {
+		resp.Body.Close()
+		return nil, &graph.StatusError{Status: resp.StatusCode, Msg: "failed to download file from " + url}
+	}
+
 	return resp.Body, nil
 }
 
@@ -151,7 +161,7 @@ func sharePointItemInfo(di models.DriveItemable, itemSize int64) *details.ShareP
 	}
 }
 
-// driveItemWriter is used to initialize and return an io.Writer to upload data for the specified item
+// driveItemWriter is used to initialize and return an io.WriteCloser to upload data for the specified item
 // It does so by creating an upload session and using that URL to initialize an `itemWriter`
 // TODO: @vkamra verify if var session is the desired input
 func driveItemWriter(
@@ -159,7 +169,7 @@ func driveItemWriter(
 	service graph.Servicer,
 	driveID, itemID string,
 	itemSize int64,
-) (io.Writer, error) {
+) (io.WriteCloser, error) {
 	session := msdrives.NewItemItemsItemCreateUploadSessionPostRequestBody()
 
 	r, err := service.Client().DrivesById(driveID).ItemsById(itemID).CreateUploadSession().Post(ctx, session, nil)
@@ -176,5 +186,5 @@ func driveItemWriter(
 
 	logger.Ctx(ctx).Debugf("Created an upload session for item %s. URL: %s", itemID, url)
 
-	return uploadsession.NewWriter(itemID, url, itemSize), nil
+	return uploadsession.NewWriter(ctx, itemID, url, itemSize, uploadsession.DefaultChunkSize), nil
 }
@@ -60,7 +60,7 @@ func (suite *ItemIntegrationSuite) SetupSuite() {
 	m365, err := a.M365Config()
 	require.NoError(t, err)
 
-	adapter, err := graph.CreateAdapter(m365.AzureTenantID, m365.AzureClientID, m365.AzureClientSecret)
+	adapter, err := graph.CreateAdapter(m365)
 	require.NoError(t, err)
 
 	suite.client = msgraphsdk.NewGraphServiceClient(adapter)
@@ -198,6 +198,7 @@ func (suite *ItemIntegrationSuite) TestItemWriter() {
 
 			size, err := io.CopyBuffer(w, td, copyBuffer)
 			require.NoError(suite.T(), err)
+			require.NoError(suite.T(), w.Close())
 
 			require.Equal(suite.T(), writeSize, size)
 		})
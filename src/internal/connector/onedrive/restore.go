@@ -2,13 +2,18 @@ package onedrive
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"runtime/trace"
+	"sync"
 
+	"github.com/google/uuid"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/pkg/errors"
 
 	"github.com/alcionai/corso/src/internal/connector/graph"
 	"github.com/alcionai/corso/src/internal/connector/support"
+	"github.com/alcionai/corso/src/internal/connector/uploadsession"
 	"github.com/alcionai/corso/src/internal/data"
 	D "github.com/alcionai/corso/src/internal/diagnostics"
 	"github.com/alcionai/corso/src/internal/observe"
@@ -22,9 +27,47 @@ const (
 	// copyBufferSize is used for chunked upload
 	// Microsoft recommends 5-10MB buffers
 	// https://docs.microsoft.com/en-us/graph/api/driveitem-createuploadsession?view=graph-rest-1.0#best-practices
-	copyBufferSize = 5 * 1024 * 1024
+	copyBufferSize = uploadsession.DefaultChunkSize
+	// tempItemNameFmt names the item that replacement content is uploaded
+	// to before it's swapped in for the original.
+	tempItemNameFmt = "%s.%s.corso-restore"
 )
 
+// errItemSkipped is returned when an item isn't restored because an item
+// with the same name already exists and the collision policy is Skip.
+var errItemSkipped = errors.New("item skipped")
+
+// copyBufferPool reuses copy buffers across concurrently restored items.
+var copyBufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, copyBufferSize)
+		return &b
+	},
+}
+
+// collisionPolicy returns the policy used to restore items into dest.  Only
+// in place restores can collide with the original items; restores into a
+// new folder always keep both copies.
+func collisionPolicy(dest control.RestoreDestination, policy control.CollisionPolicy) control.CollisionPolicy {
+	if !dest.InPlace() {
+		return control.Copy
+	}
+
+	return policy
+}
+
+// conflictBehavior maps the collision policy onto the graph conflict
+// resolution used when creating a drive item.  Replaced items are created
+// under a unique temporary name, and must never overwrite the original.
+func conflictBehavior(policy control.CollisionPolicy) string {
+	switch policy {
+	case control.Skip, control.Replace:
+		return "fail"
+	default:
+		return "rename"
+	}
+}
+
 // drivePath is used to represent path components
 // of an item within the drive i.e.
 // Given `drives/b!X_8Z2zuXpkKkXZsr7gThk9oJpuj0yXVGnK5_VjRRPK-q725SX_8ZQJgFDK8PlFxA/root:/Folder1/Folder2/file`
@@ -47,34 +90,76 @@ func toOneDrivePath(p path.Path) (*drivePath, error) {
 	return &drivePath{driveID: folders[1], folders: folders[3:]}, nil
 }
 
-// RestoreCollections will restore the specified data collections into OneDrive
+// RestoreCollections will restore the specified data collections into OneDrive.
+// Collections belonging to different resource owners are restored
+// concurrently, within the limits of opts.RestoreParallelism.
 func RestoreCollections(
 	ctx context.Context,
 	service graph.Servicer,
 	dest control.RestoreDestination,
+	opts control.Options,
 	dcs []data.Collection,
 	deets *details.Details,
 ) (*support.ConnectorOperationStatus, error) {
 	var (
 		restoreMetrics support.CollectionMetrics
 		restoreErrors  error
+		mu             sync.Mutex
+		wg             sync.WaitGroup
+		limiter        = support.NewRestoreLimiter(opts.RestoreParallelism)
+		drives         = NewDriveIDs(service, OneDriveSource)
 	)
 
 	errUpdater := func(id string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
 		restoreErrors = support.WrapAndAppend(id, err, restoreErrors)
 	}
 
-	// Iterate through the data collections and restore the contents of each
-	for _, dc := range dcs {
-		temp, canceled := RestoreCollection(ctx, service, dc, OneDriveSource, dest.ContainerName, deets, errUpdater)
+	groups := data.GroupByResourceOwner(dcs)
 
-		restoreMetrics.Combine(temp)
+	// All collections are written into the same drive when restoring to
+	// another owner, so restore them one after another to avoid racing
+	// to create the same folders.
+	if len(dest.ResourceOwnerOverride) > 0 {
+		groups = map[string][]data.Collection{dest.ResourceOwnerOverride: dcs}
+	}
 
-		if canceled {
-			break
-		}
+	// Iterate through the data collections of each resource owner and
+	// restore the contents of each
+	for _, ownerDCs := range groups {
+		wg.Add(1)
+
+		go func(ownerDCs []data.Collection) {
+			defer wg.Done()
+
+			for _, dc := range ownerDCs {
+				temp, canceled := RestoreCollection(
+					ctx,
+					service,
+					dc,
+					OneDriveSource,
+					drives,
+					dest,
+					opts,
+					limiter,
+					deets,
+					errUpdater)
+
+				mu.Lock()
+				restoreMetrics.Combine(temp)
+				mu.Unlock()
+
+				if canceled {
+					return
+				}
+			}
+		}(ownerDCs)
 	}
 
+	wg.Wait()
+
 	return support.CreateStatus(
 			ctx,
 			support.Restore,
@@ -86,6 +171,13 @@ func RestoreCollections(
 }
 
 // RestoreCollection handles restoration of an individual collection.
+// drives provides the drive to restore into when dest overrides the
+// resource owner.  If dest has no container name the items are restored in place, into
+// the folder they were backed up from, and name collisions with existing
+// files are resolved according to opts.Collision.  Otherwise both copies
+// are kept.
+// Items are restored concurrently, bounded by limiter.  errUpdater may be
+// called from multiple goroutines.
 // returns:
 // - the collection's item and byte count metrics
 // - the context cancellation state (true if the context is cancelled)
@@ -94,7 +186,10 @@ func RestoreCollection(
 	service graph.Servicer,
 	dc data.Collection,
 	source driveSource,
-	restoreContainerName string,
+	drives *DriveIDs,
+	dest control.RestoreDestination,
+	opts control.Options,
+	limiter *support.RestoreLimiter,
 	deets *details.Details,
 	errUpdater func(string, error),
 ) (support.CollectionMetrics, bool) {
@@ -102,12 +197,14 @@ func RestoreCollection(
 	defer end()
 
 	var (
-		metrics    = support.CollectionMetrics{}
-		copyBuffer = make([]byte, copyBufferSize)
-		directory  = dc.FullPath()
+		metrics   = support.CollectionMetrics{}
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		directory = dc.FullPath()
+		policy    = collisionPolicy(dest, opts.Collision)
 	)
 
-	drivePath, err := toOneDrivePath(directory)
+	drivePath, owner, err := restoreDrivePath(ctx, directory, dest, drives)
 	if err != nil {
 		errUpdater(directory.String(), err)
 		return metrics, false
@@ -116,8 +213,13 @@ func RestoreCollection(
 	// Assemble folder hierarchy we're going to restore into (we recreate the folder hierarchy
 	// from the backup under this the restore folder instead of root)
 	// i.e. Restore into `<drive>/root:/<restoreContainerName>/<original folder path>`
+	// In place restores skip the restore folder and write back into
+	// `<drive>/root:/<original folder path>`.
+	restoreFolderElements := []string{}
+	if !dest.InPlace() {
+		restoreFolderElements = append(restoreFolderElements, dest.ContainerName)
+	}
 
-	restoreFolderElements := []string{restoreContainerName}
 	restoreFolderElements = append(restoreFolderElements, drivePath.folders...)
 
 	trace.Log(ctx, "gc:oneDrive:restoreCollection", directory.String())
@@ -130,55 +232,191 @@ func RestoreCollection(
 		return metrics, false
 	}
 
-	// Restore items from the collection
+	restore := func(itemData data.Stream) {
+		copyBuffer := copyBufferPool.Get().(*[]byte)
+		defer copyBufferPool.Put(copyBuffer)
+
+		itemInfo, err := restoreItem(ctx,
+			service,
+			itemData,
+			drivePath.driveID,
+			restoreFolderID,
+			*copyBuffer,
+			source,
+			policy)
+		if err != nil {
+			if !errors.Is(err, errItemSkipped) {
+				errUpdater(itemData.UUID(), err)
+			}
+
+			return
+		}
+
+		itemPath, err := dc.FullPath().Append(itemData.UUID(), true)
+		if err != nil {
+			logger.Ctx(ctx).DPanicw("transforming item to full path", "error", err)
+			errUpdater(itemData.UUID(), err)
+
+			return
+		}
+
+		deets.Add(
+			itemPath.String(),
+			itemPath.ShortRef(),
+			"",
+			true,
+			itemInfo)
+
+		mu.Lock()
+		metrics.Successes++
+		mu.Unlock()
+	}
+
+	// Restore items from the collection.  In-flight items are always
+	// waited on before returning, so that the metrics are complete.
 	items := dc.Items()
 
 	for {
 		select {
 		case <-ctx.Done():
 			errUpdater("context canceled", ctx.Err())
+			wg.Wait()
+
 			return metrics, true
 
 		case itemData, ok := <-items:
 			if !ok {
+				wg.Wait()
 				return metrics, false
 			}
-			metrics.Objects++
 
-			metrics.TotalBytes += int64(len(copyBuffer))
+			if err := limiter.Acquire(ctx, owner); err != nil {
+				errUpdater("context canceled", err)
+				wg.Wait()
 
-			itemInfo, err := restoreItem(ctx,
-				service,
-				itemData,
-				drivePath.driveID,
-				restoreFolderID,
-				copyBuffer,
-				source)
-			if err != nil {
-				errUpdater(itemData.UUID(), err)
-				continue
+				return metrics, true
 			}
 
-			itemPath, err := dc.FullPath().Append(itemData.UUID(), true)
-			if err != nil {
-				logger.Ctx(ctx).DPanicw("transforming item to full path", "error", err)
-				errUpdater(itemData.UUID(), err)
+			mu.Lock()
+			metrics.Objects++
+			metrics.TotalBytes += int64(copyBufferSize)
+			mu.Unlock()
 
-				continue
-			}
+			wg.Add(1)
 
-			deets.Add(
-				itemPath.String(),
-				itemPath.ShortRef(),
-				"",
-				true,
-				itemInfo)
+			go func(itemData data.Stream) {
+				defer wg.Done()
+				defer limiter.Release(owner)
 
-			metrics.Successes++
+				restore(itemData)
+			}(itemData)
 		}
 	}
 }
 
+// restoreDrivePath returns the drive path, and the owner of the drive,
+// that the collection at directory is restored into.  Drive IDs are unique
+// to each owner, so restoring to another user or site means writing into
+// that owner's default drive instead.
+func restoreDrivePath(
+	ctx context.Context,
+	directory path.Path,
+	dest control.RestoreDestination,
+	drives *DriveIDs,
+) (*drivePath, string, error) {
+	drivePath, err := toOneDrivePath(directory)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(dest.ResourceOwnerOverride) == 0 {
+		return drivePath, directory.ResourceOwner(), nil
+	}
+
+	drivePath.driveID, err = drives.Get(ctx, dest.ResourceOwnerOverride)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return drivePath, dest.ResourceOwnerOverride, nil
+}
+
+// DriveIDs looks up the IDs of the default drives of the users or sites
+// that items are restored into.  Each owner's drive is only looked up
+// once.  Safe for concurrent use.
+type DriveIDs struct {
+	mu     sync.Mutex
+	ids    map[string]string
+	lookup func(ctx context.Context, resourceOwner string) (models.Driveable, error)
+}
+
+// NewDriveIDs creates a DriveIDs that looks up the drives of the source's
+// users or sites.
+func NewDriveIDs(service graph.Servicer, source driveSource) *DriveIDs {
+	return &DriveIDs{
+		ids: map[string]string{},
+		lookup: func(ctx context.Context, resourceOwner string) (models.Driveable, error) {
+			return defaultDrive(ctx, service, source, resourceOwner)
+		},
+	}
+}
+
+// Get returns the ID of the default drive for the user or site.
+func (di *DriveIDs) Get(ctx context.Context, resourceOwner string) (string, error) {
+	di.mu.Lock()
+	defer di.mu.Unlock()
+
+	if id, ok := di.ids[resourceOwner]; ok {
+		return id, nil
+	}
+
+	d, err := di.lookup(ctx, resourceOwner)
+	if err != nil {
+		return "", err
+	}
+
+	if d == nil || d.GetId() == nil {
+		return "", errors.Errorf("no default drive ID returned for %s", resourceOwner)
+	}
+
+	di.ids[resourceOwner] = *d.GetId()
+
+	return *d.GetId(), nil
+}
+
+// defaultDrive returns the default drive for the user or site.
+func defaultDrive(
+	ctx context.Context,
+	service graph.Servicer,
+	source driveSource,
+	resourceOwner string,
+) (models.Driveable, error) {
+	var (
+		d   models.Driveable
+		err error
+	)
+
+	switch source {
+	case OneDriveSource:
+		d, err = service.Client().UsersById(resourceOwner).Drive().Get(ctx, nil)
+	case SharePointSource:
+		d, err = service.Client().SitesById(resourceOwner).Drive().Get(ctx, nil)
+	default:
+		return nil, errors.Errorf("unrecognized drive data source")
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"failed to get default drive for %s. details: %s",
+			resourceOwner,
+			support.ConnectorStackErrorTrace(err),
+		)
+	}
+
+	return d, nil
+}
+
 // createRestoreFolders creates the restore folder hieararchy in the specified drive and returns the folder ID
 // of the last folder entry in the hiearchy
 func createRestoreFolders(ctx context.Context, service graph.Servicer, driveID string, restoreFolders []string,
@@ -232,6 +470,7 @@ func restoreItem(
 	driveID, parentFolderID string,
 	copyBuffer []byte,
 	source driveSource,
+	policy control.CollisionPolicy,
 ) (details.ItemInfo, error) {
 	ctx, end := D.Span(ctx, "gc:oneDrive:restoreItem", D.Label("item_uuid", itemData.UUID()))
 	defer end()
@@ -245,37 +484,112 @@ func restoreItem(
 		return details.ItemInfo{}, errors.Errorf("item %q does not implement DataStreamInfo", itemName)
 	}
 
+	// Replaced items are uploaded under a temporary name and only swapped
+	// in for the original once the upload is complete, so that a failed
+	// upload never destroys the original.
+	createName := itemName
+	if policy == control.Replace {
+		createName = fmt.Sprintf(tempItemNameFmt, itemName, uuid.NewString())
+	}
+
 	// Create Item
-	newItem, err := createItem(ctx, service, driveID, parentFolderID, newItem(itemData.UUID(), false))
+	item := newItem(createName, false)
+	item.SetAdditionalData(map[string]any{conflictBehaviorKey: conflictBehavior(policy)})
+
+	newItem, err := createItem(ctx, service, driveID, parentFolderID, item)
 	if err != nil {
+		if policy == control.Skip && hasErrorCode(err, nameAlreadyExistsErrorCode) {
+			logger.Ctx(ctx).Debugf("Skipping restore of existing item %s", itemName)
+			return details.ItemInfo{}, errors.WithStack(errItemSkipped)
+		}
+
 		return details.ItemInfo{}, errors.Wrapf(err, "failed to create item %s", itemName)
 	}
 
+	written, err := uploadItem(ctx, service, itemData, driveID, *newItem.GetId(), ss.Size(), copyBuffer)
+	if err != nil {
+		if policy == control.Replace {
+			if derr := DeleteItem(ctx, service, driveID, *newItem.GetId()); derr != nil {
+				logger.Ctx(ctx).Errorw("deleting incomplete replacement", "item", itemName, "error", derr)
+			}
+		}
+
+		return details.ItemInfo{}, err
+	}
+
+	if policy == control.Replace {
+		newItem, err = swapItem(ctx, service, driveID, parentFolderID, *newItem.GetId(), itemName)
+		if err != nil {
+			return details.ItemInfo{}, err
+		}
+	}
+
+	dii := details.ItemInfo{}
+
+	switch source {
+	case SharePointSource:
+		dii.SharePoint = sharePointItemInfo(newItem, written)
+	default:
+		dii.OneDrive = oneDriveItemInfo(newItem, written)
+	}
+
+	return dii, nil
+}
+
+// uploadItem uploads the item's data into the drive item with itemID.
+func uploadItem(
+	ctx context.Context,
+	service graph.Servicer,
+	itemData data.Stream,
+	driveID, itemID string,
+	size int64,
+	copyBuffer []byte,
+) (int64, error) {
+	itemName := itemData.UUID()
+
 	// Get a drive item writer
-	w, err := driveItemWriter(ctx, service, driveID, *newItem.GetId(), ss.Size())
+	w, err := driveItemWriter(ctx, service, driveID, itemID, size)
 	if err != nil {
-		return details.ItemInfo{}, errors.Wrapf(err, "failed to create item upload session %s", itemName)
+		return 0, errors.Wrapf(err, "failed to create item upload session %s", itemName)
 	}
 
 	iReader := itemData.ToReader()
-	progReader, closer := observe.ItemProgress(iReader, observe.ItemRestoreMsg, itemName, ss.Size())
+	progReader, closer := observe.ItemProgress(iReader, observe.ItemRestoreMsg, itemName, size)
 
 	go closer()
 
 	// Upload the stream data
 	written, err := io.CopyBuffer(w, progReader, copyBuffer)
 	if err != nil {
-		return details.ItemInfo{}, errors.Wrapf(err, "failed to upload data: item %s", itemName)
+		return 0, errors.Wrapf(err, "failed to upload data: item %s", itemName)
 	}
 
-	dii := details.ItemInfo{}
+	if err := w.Close(); err != nil {
+		return 0, errors.Wrapf(err, "failed to upload data: item %s", itemName)
+	}
 
-	switch source {
-	case SharePointSource:
-		dii.SharePoint = sharePointItemInfo(newItem, written)
-	default:
-		dii.OneDrive = oneDriveItemInfo(newItem, written)
+	return written, nil
+}
+
+// swapItem replaces the file named name in parentFolderID with the
+// uploaded item itemID.  The original is deleted before the rename; the
+// restored content is kept under its temporary name if the rename fails.
+func swapItem(
+	ctx context.Context,
+	service graph.Servicer,
+	driveID, parentFolderID, itemID, name string,
+) (models.DriveItemable, error) {
+	original, err := getItem(ctx, service, driveID, parentFolderID, name)
+	if err != nil && !errors.Is(err, errItemNotFound) {
+		return nil, err
 	}
 
-	return dii, nil
+	// folders are never replaced by files, the rename fails instead.
+	if original != nil && original.GetFolder() == nil {
+		if err := DeleteItem(ctx, service, driveID, *original.GetId()); err != nil {
+			return nil, errors.Wrapf(err, "replacing item %s", name)
+		}
+	}
+
+	return renameItem(ctx, service, driveID, itemID, name)
 }
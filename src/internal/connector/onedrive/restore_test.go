@@ -1,12 +1,16 @@
 package onedrive
 
 import (
+	"context"
 	"testing"
 
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
@@ -57,3 +61,154 @@ func (suite *OneDriveRestoreSuite) Test_toOneDrivePath() {
 		})
 	}
 }
+
+func (suite *OneDriveRestoreSuite) Test_conflictBehavior() {
+	table := []struct {
+		policy   control.CollisionPolicy
+		expected string
+	}{
+		{control.Unknown, "rename"},
+		{control.Copy, "rename"},
+		{control.Skip, "fail"},
+		{control.Replace, "fail"},
+	}
+	for _, test := range table {
+		suite.T().Run(test.policy.String(), func(t *testing.T) {
+			assert.Equal(t, test.expected, conflictBehavior(test.policy))
+		})
+	}
+}
+
+func (suite *OneDriveRestoreSuite) Test_collisionPolicy() {
+	table := []struct {
+		name     string
+		dest     control.RestoreDestination
+		policy   control.CollisionPolicy
+		expected control.CollisionPolicy
+	}{
+		{
+			name:     "in place replace",
+			policy:   control.Replace,
+			expected: control.Replace,
+		},
+		{
+			name:     "in place skip",
+			policy:   control.Skip,
+			expected: control.Skip,
+		},
+		{
+			name:     "new folder replace",
+			dest:     control.RestoreDestination{ContainerName: "restore"},
+			policy:   control.Replace,
+			expected: control.Copy,
+		},
+		{
+			name:     "new folder skip",
+			dest:     control.RestoreDestination{ContainerName: "restore"},
+			policy:   control.Skip,
+			expected: control.Copy,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, collisionPolicy(test.dest, test.policy))
+		})
+	}
+}
+
+// stubDriveIDs returns a DriveIDs whose drives have the ID "<owner>-drive",
+// and counts the lookups made for each owner.
+func stubDriveIDs(lookups map[string]int) *DriveIDs {
+	return &DriveIDs{
+		ids: map[string]string{},
+		lookup: func(_ context.Context, resourceOwner string) (models.Driveable, error) {
+			lookups[resourceOwner]++
+
+			d := models.NewDrive()
+			id := resourceOwner + "-drive"
+			d.SetId(&id)
+
+			return d, nil
+		},
+	}
+}
+
+func (suite *OneDriveRestoreSuite) Test_restoreDrivePath() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	p, err := path.Builder{}.
+		Append("drive", "driveID", "root:", "folder").
+		ToDataLayerOneDrivePath("tenant", "user", false)
+	require.NoError(suite.T(), err)
+
+	table := []struct {
+		name          string
+		dest          control.RestoreDestination
+		expectDriveID string
+		expectOwner   string
+		expectLookups map[string]int
+	}{
+		{
+			name:          "original owner",
+			dest:          control.RestoreDestination{},
+			expectDriveID: "driveID",
+			expectOwner:   "user",
+			expectLookups: map[string]int{},
+		},
+		{
+			name:          "owner override",
+			dest:          control.RestoreDestination{ResourceOwnerOverride: "other"},
+			expectDriveID: "other-drive",
+			expectOwner:   "other",
+			expectLookups: map[string]int{"other": 1},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			lookups := map[string]int{}
+			drives := stubDriveIDs(lookups)
+
+			// restoring a second collection reuses the drive looked up for the first
+			for i := 0; i < 2; i++ {
+				dp, owner, err := restoreDrivePath(ctx, p, test.dest, drives)
+				require.NoError(t, err)
+
+				assert.Equal(t, test.expectDriveID, dp.driveID)
+				assert.Equal(t, []string{"folder"}, dp.folders)
+				assert.Equal(t, test.expectOwner, owner)
+			}
+
+			assert.Equal(t, test.expectLookups, lookups)
+		})
+	}
+}
+
+func (suite *OneDriveRestoreSuite) TestDriveIDs_Get_missingID() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	table := []struct {
+		name  string
+		drive models.Driveable
+		err   error
+	}{
+		{name: "no drive"},
+		{name: "no drive ID", drive: models.NewDrive()},
+		{name: "lookup error", err: assert.AnError},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			drives := &DriveIDs{
+				ids: map[string]string{},
+				lookup: func(context.Context, string) (models.Driveable, error) {
+					return test.drive, test.err
+				},
+			}
+
+			_, err := drives.Get(ctx, "user")
+			assert.Error(t, err)
+			assert.Empty(t, drives.ids)
+		})
+	}
+}
@@ -39,11 +39,7 @@ func (ods *oneDriveService) Adapter() *msgraphsdk.GraphRequestAdapter {
 }
 
 func NewOneDriveService(credentials account.M365Config) (*oneDriveService, error) {
-	adapter, err := graph.CreateAdapter(
-		credentials.AzureTenantID,
-		credentials.AzureClientID,
-		credentials.AzureClientSecret,
-	)
+	adapter, err := graph.CreateAdapter(credentials)
 	if err != nil {
 		return nil, err
 	}
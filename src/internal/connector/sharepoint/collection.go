@@ -28,10 +28,11 @@ const (
 )
 
 var (
-	_ data.Collection    = &Collection{}
-	_ data.Stream        = &Item{}
-	_ data.StreamInfo    = &Item{}
-	_ data.StreamModTime = &Item{}
+	_ data.Collection          = &Collection{}
+	_ data.CollectionEstimator = &Collection{}
+	_ data.Stream              = &Item{}
+	_ data.StreamInfo          = &Item{}
+	_ data.StreamModTime       = &Item{}
 )
 
 type Collection struct {
@@ -81,6 +82,17 @@ func (sc Collection) State() data.CollectionState {
 	return data.NewState
 }
 
+// EstimateItems lists the items in the collection.  The size of list items
+// isn't known until they're retrieved.
+func (sc *Collection) EstimateItems() []data.ItemEstimate {
+	es := make([]data.ItemEstimate, 0, len(sc.jobs))
+	for _, id := range sc.jobs {
+		es = append(es, data.ItemEstimate{UUID: id, Size: -1})
+	}
+
+	return es
+}
+
 func (sc *Collection) Items() <-chan data.Stream {
 	go sc.populate(context.TODO())
 	return sc.data
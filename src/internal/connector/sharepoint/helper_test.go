@@ -49,11 +49,7 @@ func (ts *testService) Adapter() *msgraphsdk.GraphRequestAdapter {
 
 func createTestService(credentials account.M365Config) (*testService, error) {
 	{
-		adapter, err := graph.CreateAdapter(
-			credentials.AzureTenantID,
-			credentials.AzureClientID,
-			credentials.AzureClientSecret,
-		)
+		adapter, err := graph.CreateAdapter(credentials)
 		if err != nil {
 			return nil, errors.Wrap(err, "creating microsoft graph service for exchange")
 		}
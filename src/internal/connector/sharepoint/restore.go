@@ -2,6 +2,7 @@ package sharepoint
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -19,15 +20,23 @@ func RestoreCollections(
 	ctx context.Context,
 	service graph.Servicer,
 	dest control.RestoreDestination,
+	opts control.Options,
 	dcs []data.Collection,
 	deets *details.Details,
 ) (*support.ConnectorOperationStatus, error) {
 	var (
 		restoreMetrics support.CollectionMetrics
 		restoreErrors  error
+		mu             sync.Mutex
+		limiter        = support.NewRestoreLimiter(opts.RestoreParallelism)
+		drives         = onedrive.NewDriveIDs(service, onedrive.SharePointSource)
 	)
 
+	// items within a collection are restored concurrently
 	errUpdater := func(id string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
 		restoreErrors = support.WrapAndAppend(id, err, restoreErrors)
 	}
 
@@ -44,8 +53,11 @@ func RestoreCollections(
 				ctx,
 				service,
 				dc,
-				onedrive.OneDriveSource,
-				dest.ContainerName,
+				onedrive.SharePointSource,
+				drives,
+				dest,
+				opts,
+				limiter,
 				deets,
 				errUpdater)
 		default:
@@ -0,0 +1,70 @@
+package support
+
+import (
+	"context"
+	"sync"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+// RestoreLimiter bounds the number of items restored concurrently, both
+// into each resource owner and across all resource owners.
+type RestoreLimiter struct {
+	perOwner int
+	global   chan struct{}
+
+	mu     sync.Mutex
+	owners map[string]chan struct{}
+}
+
+// NewRestoreLimiter produces a RestoreLimiter for the parallelism limits.
+func NewRestoreLimiter(rp control.RestoreParallelism) *RestoreLimiter {
+	perOwner, global := rp.Limits()
+
+	return &RestoreLimiter{
+		perOwner: perOwner,
+		global:   make(chan struct{}, global),
+		owners:   map[string]chan struct{}{},
+	}
+}
+
+func (rl *RestoreLimiter) ownerCh(owner string) chan struct{} {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	ch, ok := rl.owners[owner]
+	if !ok {
+		ch = make(chan struct{}, rl.perOwner)
+		rl.owners[owner] = ch
+	}
+
+	return ch
+}
+
+// Acquire blocks until another item can be restored into owner.  Every
+// successful Acquire must be paired with a call to Release.  Returns the
+// context error if ctx is cancelled while waiting.
+func (rl *RestoreLimiter) Acquire(ctx context.Context, owner string) error {
+	ownerCh := rl.ownerCh(owner)
+
+	select {
+	case ownerCh <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case rl.global <- struct{}{}:
+	case <-ctx.Done():
+		<-ownerCh
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// Release frees the slot held by an item restored into owner.
+func (rl *RestoreLimiter) Release(owner string) {
+	<-rl.global
+	<-rl.ownerCh(owner)
+}
@@ -0,0 +1,126 @@
+package support
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type RestoreLimiterSuite struct {
+	suite.Suite
+}
+
+func TestRestoreLimiterSuite(t *testing.T) {
+	suite.Run(t, new(RestoreLimiterSuite))
+}
+
+func (suite *RestoreLimiterSuite) TestLimits() {
+	table := []struct {
+		name           string
+		rp             control.RestoreParallelism
+		expectPerOwner int
+		expectGlobal   int
+	}{
+		{
+			name:           "defaults",
+			rp:             control.RestoreParallelism{},
+			expectPerOwner: 4,
+			expectGlobal:   16,
+		},
+		{
+			name:           "configured",
+			rp:             control.RestoreParallelism{PerResourceOwner: 2, Global: 3},
+			expectPerOwner: 2,
+			expectGlobal:   3,
+		},
+		{
+			name:           "owner capped by global",
+			rp:             control.RestoreParallelism{PerResourceOwner: 8, Global: 2},
+			expectPerOwner: 2,
+			expectGlobal:   2,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			perOwner, global := test.rp.Limits()
+			assert.Equal(t, test.expectPerOwner, perOwner)
+			assert.Equal(t, test.expectGlobal, global)
+		})
+	}
+}
+
+func (suite *RestoreLimiterSuite) TestAcquire() {
+	var (
+		t       = suite.T()
+		ctx     = context.Background()
+		limiter = NewRestoreLimiter(control.RestoreParallelism{PerResourceOwner: 2, Global: 3})
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		active  = map[string]int{}
+		total   int
+		maxes   = map[string]int{}
+		maxAll  int
+	)
+
+	for _, owner := range []string{"a", "a", "a", "a", "b", "b", "b", "b"} {
+		wg.Add(1)
+
+		go func(owner string) {
+			defer wg.Done()
+
+			require.NoError(t, limiter.Acquire(ctx, owner))
+			defer limiter.Release(owner)
+
+			mu.Lock()
+			active[owner]++
+			if active[owner] > maxes[owner] {
+				maxes[owner] = active[owner]
+			}
+
+			total++
+			if total > maxAll {
+				maxAll = total
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			active[owner]--
+			total--
+			mu.Unlock()
+		}(owner)
+	}
+
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxes["a"], 2)
+	assert.LessOrEqual(t, maxes["b"], 2)
+	assert.LessOrEqual(t, maxAll, 3)
+}
+
+func (suite *RestoreLimiterSuite) TestAcquire_cancelled() {
+	var (
+		t           = suite.T()
+		ctx, cancel = context.WithCancel(context.Background())
+		limiter     = NewRestoreLimiter(control.RestoreParallelism{PerResourceOwner: 1, Global: 1})
+	)
+
+	require.NoError(t, limiter.Acquire(ctx, "a"))
+
+	cancel()
+
+	assert.Error(t, limiter.Acquire(ctx, "a"))
+	assert.Error(t, limiter.Acquire(ctx, "b"))
+
+	// the cancelled acquisitions didn't hold onto any slots
+	limiter.Release("a")
+	assert.NoError(t, limiter.Acquire(context.Background(), "b"))
+}
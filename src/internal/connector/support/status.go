@@ -6,6 +6,7 @@ import (
 
 	bytesize "github.com/inhies/go-bytesize"
 
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/logger"
 )
 
@@ -25,6 +26,8 @@ type ConnectorOperationStatus struct {
 	incompleteReason  string
 	additionalDetails string
 	bytes             int64
+	// Failures records each item that couldn't be processed.
+	Failures []details.Failure
 }
 
 type CollectionMetrics struct {
@@ -119,6 +122,7 @@ func MergeStatus(one, two ConnectorOperationStatus) ConnectorOperationStatus {
 		incomplete:        hasErrors,
 		incompleteReason:  one.incompleteReason + ", " + two.incompleteReason,
 		additionalDetails: one.additionalDetails + ", " + two.additionalDetails,
+		Failures:          append(append([]details.Failure{}, one.Failures...), two.Failures...),
 	}
 
 	return status
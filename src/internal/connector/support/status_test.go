@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 )
 
 type GCStatusTestSuite struct {
@@ -154,3 +155,29 @@ func (suite *GCStatusTestSuite) TestMergeStatus() {
 		})
 	}
 }
+
+func (suite *GCStatusTestSuite) TestMergeStatus_failures() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	var (
+		t   = suite.T()
+		one = *CreateStatus(ctx, Backup, 1, CollectionMetrics{2, 1, 0}, errors.New("a"), "")
+		two = *CreateStatus(ctx, Backup, 1, CollectionMetrics{2, 1, 0}, errors.New("b"), "")
+	)
+
+	one.Failures = []details.Failure{{ItemID: "a", Class: details.FailureNotFound}}
+	two.Failures = []details.Failure{{ItemID: "b", Class: details.FailureThrottled}}
+
+	returned := MergeStatus(one, two)
+	assert.Equal(
+		t,
+		[]details.Failure{
+			{ItemID: "a", Class: details.FailureNotFound},
+			{ItemID: "b", Class: details.FailureThrottled},
+		},
+		returned.Failures)
+
+	// merging doesn't modify the original statuses
+	assert.Len(t, one.Failures, 1)
+}
@@ -3,7 +3,12 @@ package uploadsession
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"gopkg.in/resty.v1"
@@ -16,56 +21,291 @@ const (
 	// Format for Content-Range is "bytes <start>-<end>/<total>"
 	contentRangeHeaderValueFmt = "bytes %d-%d/%d"
 	contentLengthHeaderKey     = "Content-Length"
+	retryAfterHeaderKey        = "Retry-After"
+
+	// ChunkSizeMultiple is the granularity of upload chunks.  Graph requires
+	// every chunk except the last to be a multiple of 320 KiB.
+	// https://learn.microsoft.com/en-us/graph/api/driveitem-createuploadsession#upload-bytes-to-the-upload-session
+	ChunkSizeMultiple = 320 * 1024
+	// DefaultChunkSize (5 MiB) is within the 5-10 MiB range recommended by
+	// Microsoft for uploads on reliable connections.
+	DefaultChunkSize = 16 * ChunkSizeMultiple
+
+	maxRetries     = 5
+	initialBackoff = 1 * time.Second
+	maxBackoff     = 30 * time.Second
 )
 
-// Writer implements an io.Writer for a M365
-// UploadSession URL
+// errUploadRetryable marks failures that may succeed when the chunk is sent again.
+var errUploadRetryable = errors.New("retryable upload failure")
+
+// writer implements an io.WriteCloser for a M365 UploadSession URL.
+// Written data is buffered and uploaded in chunks of a fixed size.  Failed
+// chunks are retried with exponential backoff, resuming from the next range
+// the upload session expects.  Close reports uploads that ended early.
 type writer struct {
+	// ctx is held by the writer since io.Writer has no context parameter.
+	ctx context.Context
 	// Identifier
 	id string
 	// Upload URL for this item
 	url string
 	// Tracks how much data will be written
 	contentLength int64
-	// Last item offset that was written to
+	// Offset of the first byte that hasn't been uploaded yet
 	lastWrittenOffset int64
-	client            *resty.Client
+	// Number of bytes sent in each request
+	chunkSize int64
+	// Data that is waiting to be uploaded
+	buf []byte
+	// backoff returns the delay before the given retry
+	backoff func(retry int) time.Duration
+	client  *resty.Client
 }
 
-func NewWriter(id, url string, size int64) *writer {
-	return &writer{id: id, url: url, contentLength: size, client: resty.New()}
+// NewWriter produces an io.WriteCloser that uploads size bytes to the upload
+// session at url.  chunkSize is rounded down to a multiple of
+// ChunkSizeMultiple, and defaults to DefaultChunkSize.
+func NewWriter(ctx context.Context, id, url string, size, chunkSize int64) *writer {
+	chunkSize -= chunkSize % ChunkSizeMultiple
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	return &writer{
+		ctx:           ctx,
+		id:            id,
+		url:           url,
+		contentLength: size,
+		chunkSize:     chunkSize,
+		backoff:       exponentialBackoff,
+		client:        resty.New(),
+	}
 }
 
-// Write will upload the provided data to M365. It sets the `Content-Length` and `Content-Range` headers based on
-// https://docs.microsoft.com/en-us/graph/api/driveitem-createuploadsession
-func (iw *writer) Write(p []byte) (n int, err error) {
-	rangeLength := len(p)
-	logger.Ctx(context.Background()).Debugf("WRITE for %s. Size:%d, Offset: %d, TotalSize: %d",
-		iw.id, rangeLength, iw.lastWrittenOffset, iw.contentLength)
+// Write buffers the provided data, uploading each full chunk to M365. The
+// final chunk is uploaded once all size bytes have been written.
+func (iw *writer) Write(p []byte) (int, error) {
+	remaining := iw.contentLength - iw.lastWrittenOffset - int64(len(iw.buf))
+	if int64(len(p)) > remaining {
+		return 0, errors.Errorf(
+			"writing %d bytes to item %s exceeds the upload size of %d bytes",
+			len(p), iw.id, iw.contentLength)
+	}
+
+	var written int
+
+	for len(p) > 0 {
+		n := int(iw.chunkSize) - len(iw.buf)
+		if n > len(p) {
+			n = len(p)
+		}
+
+		iw.buf = append(iw.buf, p[:n]...)
+		p = p[n:]
+
+		if int64(len(iw.buf)) == iw.chunkSize ||
+			iw.lastWrittenOffset+int64(len(iw.buf)) == iw.contentLength {
+			if err := iw.uploadChunk(); err != nil {
+				return written, err
+			}
+		}
+
+		written += n
+	}
+
+	return written, nil
+}
+
+// Close checks that all size bytes were written.  The upload session only
+// completes once the final chunk is uploaded, so a shorter write leaves the
+// item without its content.
+func (iw *writer) Close() error {
+	if written := iw.lastWrittenOffset + int64(len(iw.buf)); written != iw.contentLength {
+		return errors.Errorf(
+			"upload of item %s incomplete: wrote %d of %d bytes",
+			iw.id, written, iw.contentLength)
+	}
+
+	return nil
+}
+
+// uploadChunk sends the buffered data, retrying transient failures.
+func (iw *writer) uploadChunk() error {
+	var (
+		chunkStart = iw.lastWrittenOffset
+		chunkEnd   = chunkStart + int64(len(iw.buf))
+		start      = chunkStart
+		err        error
+	)
+
+	for retry := 0; ; retry++ {
+		var retryAfter time.Duration
+
+		retryAfter, err = iw.put(start, iw.buf[start-chunkStart:])
+		if err == nil || !errors.Is(err, errUploadRetryable) || retry >= maxRetries {
+			break
+		}
+
+		delay := iw.backoff(retry)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+
+		logger.Ctx(iw.ctx).Debugw(
+			"retrying upload chunk",
+			"item", iw.id,
+			"offset", start,
+			"retry", retry+1,
+			"delay", delay,
+			"error", err)
+
+		select {
+		case <-iw.ctx.Done():
+			return errors.Wrapf(iw.ctx.Err(), "uploading item %s", iw.id)
+		case <-time.After(delay):
+		}
+
+		// Some of the chunk may have been stored before the failure. Ask
+		// the session where to resume, falling back to resending from the
+		// same offset if the session can't be queried.
+		next, qerr := iw.nextExpectedOffset()
+		if qerr != nil {
+			logger.Ctx(iw.ctx).Debugw("querying upload session", "item", iw.id, "error", qerr)
+			continue
+		}
+
+		if next < chunkStart {
+			return errors.Errorf(
+				"upload session for item %s expects offset %d, before the current chunk at %d",
+				iw.id, next, chunkStart)
+		}
+
+		if next >= chunkEnd {
+			err = nil
+			break
+		}
+
+		start = next
+	}
+
+	if err != nil {
+		return errors.Wrapf(
+			err,
+			"failed to upload item %s. Upload failed at Size:%d, Offset: %d, TotalSize: %d",
+			iw.id, len(iw.buf), chunkStart, iw.contentLength)
+	}
+
+	iw.lastWrittenOffset = chunkEnd
+	iw.buf = iw.buf[:0]
 
-	endOffset := iw.lastWrittenOffset + int64(rangeLength)
+	return nil
+}
+
+// put uploads p, starting at offset.  It sets the `Content-Length` and
+// `Content-Range` headers based on
+// https://docs.microsoft.com/en-us/graph/api/driveitem-createuploadsession
+// Returns the delay requested by the server, if any, when the upload fails.
+func (iw *writer) put(offset int64, p []byte) (time.Duration, error) {
+	logger.Ctx(iw.ctx).Debugf("WRITE for %s. Size:%d, Offset: %d, TotalSize: %d",
+		iw.id, len(p), offset, iw.contentLength)
 
 	// PUT the request - set headers `Content-Range`to describe total size and `Content-Length` to describe size of
 	// data in the current request
 	resp, err := iw.client.R().
+		SetContext(iw.ctx).
 		SetHeaders(map[string]string{
 			contentRangeHeaderKey: fmt.Sprintf(contentRangeHeaderValueFmt,
-				iw.lastWrittenOffset,
-				endOffset-1,
+				offset,
+				offset+int64(len(p))-1,
 				iw.contentLength),
-			contentLengthHeaderKey: fmt.Sprintf("%d", rangeLength),
+			contentLengthHeaderKey: fmt.Sprintf("%d", len(p)),
 		}).
-		SetBody(bytes.NewReader(p)).Put(iw.url)
+		SetBody(bytes.NewReader(p)).
+		Put(iw.url)
 	if err != nil {
-		return 0, errors.Wrapf(err,
-			"failed to upload item %s. Upload failed at Size:%d, Offset: %d, TotalSize: %d ",
-			iw.id, rangeLength, iw.lastWrittenOffset, iw.contentLength)
+		if iw.ctx.Err() != nil {
+			return 0, err
+		}
+
+		// transport failures, such as dropped connections, are transient
+		return 0, errors.Wrap(errUploadRetryable, err.Error())
 	}
 
-	// Update last offset
-	iw.lastWrittenOffset = endOffset
+	logger.Ctx(iw.ctx).Debugf("Response: %s", resp.String())
+
+	return retryAfter(resp), statusError(resp.StatusCode())
+}
+
+// statusError classifies the status code of an upload response.
+func statusError(status int) error {
+	switch {
+	case status >= 200 && status < 300:
+		return nil
+	case status == http.StatusTooManyRequests,
+		status == http.StatusRequestedRangeNotSatisfiable,
+		status == http.StatusRequestTimeout,
+		status >= 500:
+		return errors.Wrapf(errUploadRetryable, "status %d", status)
+	default:
+		return errors.Errorf("upload rejected with status %d", status)
+	}
+}
+
+// retryAfter returns the delay requested in the Retry-After header of resp.
+func retryAfter(resp *resty.Response) time.Duration {
+	secs, err := strconv.Atoi(resp.Header().Get(retryAfterHeaderKey))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+// sessionStatus is the upload session state reported by Graph.
+type sessionStatus struct {
+	NextExpectedRanges []string `json:"nextExpectedRanges"`
+}
 
-	logger.Ctx(context.Background()).Debugf("Response: %s", resp.String())
+// nextExpectedOffset queries the upload session for the first byte it
+// still needs.  A session without any expected ranges has received all
+// the data.
+func (iw *writer) nextExpectedOffset() (int64, error) {
+	resp, err := iw.client.R().SetContext(iw.ctx).Get(iw.url)
+	if err != nil {
+		return 0, errors.Wrap(err, "getting upload session status")
+	}
+
+	if err := statusError(resp.StatusCode()); err != nil {
+		return 0, errors.Wrap(err, "getting upload session status")
+	}
+
+	ss := sessionStatus{}
+	if err := json.Unmarshal(resp.Body(), &ss); err != nil {
+		return 0, errors.Wrap(err, "parsing upload session status")
+	}
+
+	if len(ss.NextExpectedRanges) == 0 {
+		return iw.contentLength, nil
+	}
+
+	// ranges are formatted as "<start>-<end>", with an optional end
+	start := strings.SplitN(ss.NextExpectedRanges[0], "-", 2)[0]
+
+	next, err := strconv.ParseInt(start, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "parsing expected range %q", ss.NextExpectedRanges[0])
+	}
+
+	return next, nil
+}
+
+// exponentialBackoff doubles the delay after each retry, up to maxBackoff.
+func exponentialBackoff(retry int) time.Duration {
+	d := initialBackoff << retry
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
 
-	return rangeLength, nil
+	return d
 }
@@ -2,6 +2,7 @@ package uploadsession
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,8 +28,8 @@ func TestUploadSessionSuite(t *testing.T) {
 func (suite *UploadSessionSuite) TestWriter() {
 	t := suite.T()
 
-	// Initialize a 100KB mockDataProvider
-	td, writeSize := mockDataReader(int64(100 * 1024))
+	// Initialize a 1MB mockDataProvider
+	td, writeSize := mockDataReader(int64(1024 * 1024))
 
 	// Expected Content-Range value format
 	contentRangeRegex := regexp.MustCompile(`^bytes (?P<rangestart>\d+)-(?P<rangeend>\d+)/(?P<length>\d+)$`)
@@ -58,20 +60,154 @@ func (suite *UploadSessionSuite) TestWriter() {
 		// Validate the "Content-Length" header
 		assert.Equal(t, fmt.Sprintf("%d", (rangeEnd+1)-rangeStart), r.Header[contentLengthHeaderKey][0])
 
+		// All chunks but the last must be a multiple of 320KB
+		if rangeEnd+1 < length {
+			assert.Zero(t, (rangeEnd+1-rangeStart)%ChunkSizeMultiple)
+		}
+
 		nextOffset = rangeEnd
 	}))
 	defer ts.Close()
 
-	writer := NewWriter("item", ts.URL, writeSize)
+	writer := NewWriter(context.Background(), "item", ts.URL, writeSize, ChunkSizeMultiple)
 
-	// Using a 32 KB buffer for the copy allows us to validate the
-	// multi-part upload. `io.CopyBuffer` will only write 32 KB at
-	// a time
+	// Using a 32 KB buffer for the copy allows us to validate that
+	// writes are buffered into chunks. `io.CopyBuffer` will only
+	// write 32 KB at a time
 	copyBuffer := make([]byte, 32*1024)
 
 	size, err := io.CopyBuffer(writer, td, copyBuffer)
 	require.NoError(suite.T(), err)
 	require.Equal(suite.T(), writeSize, size)
+	assert.Equal(t, int(writeSize)-1, nextOffset)
+	assert.NoError(t, writer.Close())
+}
+
+func (suite *UploadSessionSuite) TestWriter_retries() {
+	writeSize := int64(2 * ChunkSizeMultiple)
+
+	table := []struct {
+		name string
+		// handles the upload of the first chunk, on the given attempt
+		put func(w http.ResponseWriter, attempt int)
+		// session status reported after a failed upload
+		status      string
+		expectStart int
+		expectErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "throttled",
+			put: func(w http.ResponseWriter, attempt int) {
+				if attempt == 0 {
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			},
+			status:      `{"nextExpectedRanges":["0-"]}`,
+			expectStart: 0,
+			expectErr:   assert.NoError,
+		},
+		{
+			name: "resume from expected range",
+			put: func(w http.ResponseWriter, attempt int) {
+				if attempt == 0 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			},
+			status:      `{"nextExpectedRanges":["1024-"]}`,
+			expectStart: 1024,
+			expectErr:   assert.NoError,
+		},
+		{
+			name: "always unavailable",
+			put: func(w http.ResponseWriter, attempt int) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			status:      `{"nextExpectedRanges":["0-"]}`,
+			expectStart: 0,
+			expectErr:   assert.Error,
+		},
+		{
+			name: "rejected",
+			put: func(w http.ResponseWriter, attempt int) {
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			var (
+				attempts int
+				starts   []int
+			)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					fmt.Fprint(w, test.status)
+					return
+				}
+
+				var start, end, length int
+
+				_, err := fmt.Sscanf(r.Header.Get(contentRangeHeaderKey), contentRangeHeaderValueFmt, &start, &end, &length)
+				require.NoError(t, err)
+
+				if start >= ChunkSizeMultiple {
+					return
+				}
+
+				starts = append(starts, start)
+
+				test.put(w, attempts)
+				attempts++
+			}))
+			defer ts.Close()
+
+			writer := NewWriter(context.Background(), "item", ts.URL, writeSize, ChunkSizeMultiple)
+			writer.backoff = func(int) time.Duration { return 0 }
+
+			td, _ := mockDataReader(writeSize)
+
+			_, err := io.Copy(writer, td)
+			test.expectErr(t, err)
+
+			if err != nil {
+				return
+			}
+
+			require.Len(t, starts, 2)
+			assert.Equal(t, test.expectStart, starts[1])
+		})
+	}
+}
+
+func (suite *UploadSessionSuite) TestWriter_exceedsSize() {
+	writer := NewWriter(context.Background(), "item", "http://localhost", 10, ChunkSizeMultiple)
+
+	_, err := writer.Write(make([]byte, 11))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *UploadSessionSuite) TestWriter_shortReader() {
+	t := suite.T()
+
+	var puts int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		puts++
+	}))
+	defer ts.Close()
+
+	// the reader holds a chunk and a half, but the session expects two
+	// chunks.
+	td, readSize := mockDataReader(int64(ChunkSizeMultiple + ChunkSizeMultiple/2))
+	writer := NewWriter(context.Background(), "item", ts.URL, 2*ChunkSizeMultiple, ChunkSizeMultiple)
+
+	size, err := io.CopyBuffer(writer, td, make([]byte, 32*1024))
+	require.NoError(t, err)
+	assert.Equal(t, readSize, size)
+	assert.Equal(t, 1, puts, "only the full chunk is uploaded")
+	assert.Error(t, writer.Close())
 }
 
 func mockDataReader(size int64) (io.Reader, int64) {
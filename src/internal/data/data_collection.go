@@ -78,6 +78,21 @@ type StreamModTime interface {
 	ModTime() time.Time
 }
 
+// ItemEstimate describes an item in a Collection without retrieving it.
+type ItemEstimate struct {
+	// UUID matches the UUID of the Stream produced for the item.
+	UUID string
+	// Size is the size of the item in bytes, or -1 if it isn't known until
+	// the item is retrieved.
+	Size int64
+}
+
+// CollectionEstimator is implemented by Collections that can list their
+// items before producing them, for estimating the size of a backup.
+type CollectionEstimator interface {
+	EstimateItems() []ItemEstimate
+}
+
 // ------------------------------------------------------------------------------------------------
 // functionality
 // ------------------------------------------------------------------------------------------------
@@ -100,3 +115,16 @@ func ResourceOwnerSet(cs []Collection) []string {
 
 	return rss
 }
+
+// GroupByResourceOwner splits the slice of Collections by resource owner.
+// Collections keep their relative order within each group.
+func GroupByResourceOwner(cs []Collection) map[string][]Collection {
+	groups := map[string][]Collection{}
+
+	for _, c := range cs {
+		ro := c.FullPath().ResourceOwner()
+		groups[ro] = append(groups[ro], c)
+	}
+
+	return groups
+}
@@ -91,3 +91,53 @@ func (suite *CollectionSuite) TestResourceOwnerSet() {
 		})
 	}
 }
+
+func (suite *CollectionSuite) TestGroupByResourceOwner() {
+	t := suite.T()
+	toColl := func(t *testing.T, resource, folder string) Collection {
+		p, err := path.Builder{}.
+			Append(folder).
+			ToDataLayerExchangePathForCategory("tid", resource, path.EventsCategory, false)
+		require.NoError(t, err)
+
+		return mockColl{p}
+	}
+
+	var (
+		fnordsFoo = toColl(t, "fnords", "foo")
+		fnordsBar = toColl(t, "fnords", "bar")
+		smarfsFoo = toColl(t, "smarfs", "foo")
+	)
+
+	table := []struct {
+		name   string
+		input  []Collection
+		expect map[string][]Collection
+	}{
+		{
+			name:   "nil",
+			input:  nil,
+			expect: map[string][]Collection{},
+		},
+		{
+			name:  "single resource",
+			input: []Collection{fnordsFoo, fnordsBar},
+			expect: map[string][]Collection{
+				"fnords": {fnordsFoo, fnordsBar},
+			},
+		},
+		{
+			name:  "multiple resources",
+			input: []Collection{fnordsBar, smarfsFoo, fnordsFoo},
+			expect: map[string][]Collection{
+				"fnords": {fnordsBar, fnordsFoo},
+				"smarfs": {smarfsFoo},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, GroupByResourceOwner(test.input))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// RFC 5545 and RFC 6350 limit content lines to 75 octets, excluding the
+	// line break.
+	maxLineLen = 75
+
+	// UTC date-time values in vCard and iCalendar.
+	utcDateTimeFormat = "20060102T150405Z"
+	dateFormat        = "20060102"
+)
+
+// contentLines builds a vCard or iCalendar object.  Both formats share the
+// content line syntax: `name *(";" param) ":" value`, folded at 75 octets.
+type contentLines struct {
+	sb strings.Builder
+}
+
+// raw adds a property whose value is already formatted.  Properties with
+// empty values are skipped.
+func (cl *contentLines) raw(name, value string, params ...string) {
+	if len(value) == 0 {
+		return
+	}
+
+	line := name
+	if len(params) > 0 {
+		line += ";" + strings.Join(params, ";")
+	}
+
+	cl.fold(line + ":" + value)
+}
+
+// text adds a property with an escaped text value.
+func (cl *contentLines) text(name, value string, params ...string) {
+	cl.raw(name, escapeText(value), params...)
+}
+
+// fold writes line, breaking it into continuation lines that begin with a
+// single space.  Multi-octet characters are never split.
+func (cl *contentLines) fold(line string) {
+	limit := maxLineLen
+
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+
+		cl.sb.WriteString(line[:i] + crlf + " ")
+		line = line[i:]
+
+		// the leading space counts against the length of continuation lines
+		limit = maxLineLen - 1
+	}
+
+	cl.sb.WriteString(line + crlf)
+}
+
+func (cl *contentLines) write(w io.Writer) error {
+	_, err := io.WriteString(w, cl.sb.String())
+	return errors.Wrap(err, "writing content lines")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escapeText escapes a text value.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// structured joins the escaped components of a structured value.
+func structured(components ...string) string {
+	for i, c := range components {
+		components[i] = escapeText(c)
+	}
+
+	v := strings.Join(components, ";")
+	if len(strings.Trim(v, ";")) == 0 {
+		return ""
+	}
+
+	return v
+}
+
+// textList joins the escaped values of a multi-valued text property.
+func textList(values []string) string {
+	escaped := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if len(v) > 0 {
+			escaped = append(escaped, escapeText(v))
+		}
+	}
+
+	return strings.Join(escaped, ",")
+}
+
+// param formats a property parameter, quoting the value when needed.
+func param(name, value string) string {
+	value = strings.ReplaceAll(value, `"`, "'")
+
+	if strings.ContainsAny(value, ":;,") {
+		value = `"` + value + `"`
+	}
+
+	return name + "=" + value
+}
+
+// utcDateTime formats a time as a UTC date-time value, or returns the
+// empty string for nil or zero times.
+func utcDateTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(utcDateTimeFormat)
+}
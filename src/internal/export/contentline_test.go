@@ -0,0 +1,65 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContentLineUnitSuite struct {
+	suite.Suite
+}
+
+func TestContentLineUnitSuite(t *testing.T) {
+	suite.Run(t, new(ContentLineUnitSuite))
+}
+
+func (suite *ContentLineUnitSuite) TestEscapeText() {
+	assert.Equal(suite.T(), `a\\b\;c\,d\ne\nf`, escapeText("a\\b;c,d\r\ne\nf"))
+}
+
+func (suite *ContentLineUnitSuite) TestStructured() {
+	t := suite.T()
+
+	assert.Equal(t, `a\;b;;c`, structured("a;b", "", "c"))
+	assert.Empty(t, structured("", "", ""))
+}
+
+func (suite *ContentLineUnitSuite) TestParam() {
+	t := suite.T()
+
+	assert.Equal(t, "CN=Alice", param("CN", "Alice"))
+	assert.Equal(t, `CN="Smith, Alice"`, param("CN", "Smith, Alice"))
+	assert.Equal(t, `CN='Al'`, param("CN", `"Al"`))
+}
+
+func (suite *ContentLineUnitSuite) TestFold() {
+	t := suite.T()
+	cl := &contentLines{}
+
+	value := strings.Repeat("é", 100)
+	cl.text("NOTE", value)
+	cl.text("EMPTY", "")
+
+	out := cl.sb.String()
+	lines := strings.Split(strings.TrimSuffix(out, crlf), crlf)
+
+	assert.Greater(t, len(lines), 1)
+
+	var unfolded strings.Builder
+
+	for i, l := range lines {
+		assert.LessOrEqual(t, len(l), maxLineLen, "line %d", i)
+
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(l, " "), "line %d", i)
+			l = l[1:]
+		}
+
+		unfolded.WriteString(l)
+	}
+
+	assert.Equal(t, "NOTE:"+value, unfolded.String())
+}
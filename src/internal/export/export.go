@@ -0,0 +1,324 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/connector/support"
+	"github.com/alcionai/corso/src/internal/data"
+	D "github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// Collections writes the items in each collection to w.  The location of
+// each file is derived from the item's repository path.  entries holds the
+// backed up details of each item keyed by RepoRef, and is used to fill in
+// file metadata and the returned details.
+// Exchange mail is converted to mailFormat, contacts to vCards, and events
+// to iCalendar files.  Items that fail to export are
+// reported in the returned error without stopping the export of the
+// remaining items.  Returns the details of the exported items, and the
+// number of items read from the collections, including those that failed.
+func Collections(
+	ctx context.Context,
+	w Writer,
+	mailFormat control.MailFormat,
+	dcs []data.Collection,
+	entries map[string]details.DetailsEntry,
+) (*details.Details, int, error) {
+	ctx, end := D.Span(ctx, "export:collections")
+	defer end()
+
+	var (
+		deets     = &details.Details{}
+		itemsRead int64
+		errs      error
+	)
+
+	for _, dc := range dcs {
+		var (
+			err  error
+			done = make(chan struct{})
+		)
+
+		dc = countedCollection{Collection: dc, count: &itemsRead, done: done}
+
+		switch dc.FullPath().Service() {
+		case path.ExchangeService:
+			err = exchangeCollection(ctx, w, mailFormat, dc, entries, deets)
+		case path.OneDriveService:
+			err = driveCollection(ctx, w, dc, entries, deets)
+		case path.SharePointService:
+			err = sharePointCollection(ctx, w, dc, entries, deets)
+		default:
+			err = errors.Errorf("export of service %s not supported", dc.FullPath().Service())
+		}
+
+		close(done)
+
+		if err != nil {
+			errs = support.WrapAndAppend(dc.FullPath().ShortRef(), err, errs)
+		}
+
+		if ctx.Err() != nil {
+			return deets, int(atomic.LoadInt64(&itemsRead)), support.WrapAndAppend("context cancelled", ctx.Err(), errs)
+		}
+	}
+
+	return deets, int(atomic.LoadInt64(&itemsRead)), errs
+}
+
+// countedCollection counts the items read from the collection.  Items stop
+// being handed out once done is closed, so readers may stop early.
+type countedCollection struct {
+	data.Collection
+	count *int64
+	done  <-chan struct{}
+}
+
+func (cc countedCollection) Items() <-chan data.Stream {
+	items := make(chan data.Stream)
+
+	go func() {
+		defer close(items)
+
+		for item := range cc.Collection.Items() {
+			atomic.AddInt64(cc.count, 1)
+
+			select {
+			case items <- item:
+			case <-cc.done:
+				return
+			}
+		}
+	}()
+
+	return items
+}
+
+// driveCollection exports the files in a OneDrive or SharePoint collection,
+// recreating the folder hierarchy under a directory for the resource owner.
+// i.e. `drives/<driveID>/root:/a/b/file` is written to `<owner>/a/b/file`.
+func driveCollection(
+	ctx context.Context,
+	w Writer,
+	dc data.Collection,
+	entries map[string]details.DetailsEntry,
+	deets *details.Details,
+) error {
+	var (
+		errs      error
+		directory = dc.FullPath()
+		folders   = directory.Folders()
+	)
+
+	// Must be at least `drives/<driveID>/root:`
+	if len(folders) < 3 {
+		return errors.Errorf("folder path doesn't match expected format for drive items: %s", directory.Folder())
+	}
+
+	dirName := strings.Join(append([]string{directory.ResourceOwner()}, folders[3:]...), "/")
+
+	for itemData := range dc.Items() {
+		if err := exportItem(ctx, w, directory, dirName, itemData, entries, deets); err != nil {
+			errs = support.WrapAndAppend(itemData.UUID(), err, errs)
+		}
+	}
+
+	return errs
+}
+
+// sharePointCollection exports the items in a SharePoint collection.  Only
+// document library files are supported.
+func sharePointCollection(
+	ctx context.Context,
+	w Writer,
+	dc data.Collection,
+	entries map[string]details.DetailsEntry,
+	deets *details.Details,
+) error {
+	switch dc.FullPath().Category() {
+	case path.LibrariesCategory:
+		return driveCollection(ctx, w, dc, entries, deets)
+	default:
+		return errors.Errorf("export of category %s not supported", dc.FullPath().Category())
+	}
+}
+
+// exportItem writes a single item to w under dirName.
+func exportItem(
+	ctx context.Context,
+	w Writer,
+	directory path.Path,
+	dirName string,
+	itemData data.Stream,
+	entries map[string]details.DetailsEntry,
+	deets *details.Details,
+) error {
+	rc := itemData.ToReader()
+	defer rc.Close()
+
+	var size int64
+	if ss, ok := itemData.(data.StreamSize); ok {
+		size = ss.Size()
+	}
+
+	return writeItem(ctx, w, directory, itemData.UUID(), dirName+"/"+itemData.UUID(), size, rc, entries, deets)
+}
+
+// writeItem stores size bytes from r in the file called name, and records
+// the item in deets.
+func writeItem(
+	ctx context.Context,
+	w Writer,
+	directory path.Path,
+	itemID, name string,
+	size int64,
+	r io.Reader,
+	entries map[string]details.DetailsEntry,
+	deets *details.Details,
+) error {
+	itemPath, err := directory.Append(itemID, true)
+	if err != nil {
+		return errors.Wrap(err, "building item path")
+	}
+
+	ent := entries[itemPath.String()]
+
+	if _, err := w.Write(name, size, r, modTime(ent.ItemInfo)); err != nil {
+		return err
+	}
+
+	logger.Ctx(ctx).Debugw("exported item", "path", itemPath.ShortRef())
+
+	addEntry(deets, itemPath, ent)
+
+	return nil
+}
+
+// addEntry records an exported item in deets, carrying over the backed up
+// item details.
+func addEntry(deets *details.Details, itemPath path.Path, ent details.DetailsEntry) {
+	deets.Add(
+		itemPath.String(),
+		itemPath.ShortRef(),
+		ent.ParentRef,
+		true,
+		ent.ItemInfo)
+}
+
+// exchangeCollection converts the items in an Exchange collection into
+// standard file formats, recreating the folder hierarchy under a directory
+// for the resource owner.
+func exchangeCollection(
+	ctx context.Context,
+	w Writer,
+	mailFormat control.MailFormat,
+	dc data.Collection,
+	entries map[string]details.DetailsEntry,
+	deets *details.Details,
+) error {
+	var (
+		directory = dc.FullPath()
+		dirName   = strings.Join(append([]string{directory.ResourceOwner()}, directory.Folders()...), "/")
+	)
+
+	switch directory.Category() {
+	case path.EmailCategory:
+		if mailFormat == control.MBOXFormat {
+			return mboxCollection(ctx, w, dc, dirName, entries, deets)
+		}
+
+		return convertedCollection(ctx, w, dc, dirName, ".eml", messageToEMLBytes, entries, deets)
+	case path.ContactsCategory:
+		return convertedCollection(ctx, w, dc, dirName, ".vcf", contactToVCardBytes, entries, deets)
+	case path.EventsCategory:
+		return convertedCollection(ctx, w, dc, dirName, ".ics", eventToICSBytes, entries, deets)
+	default:
+		return errors.Errorf("export of category %s not supported", directory.Category())
+	}
+}
+
+// converter writes a serialized M365 item to w in a standard file format.
+type converter func(w io.Writer, bs []byte) error
+
+// convertedCollection writes each item in the collection to its own file,
+// named after the item ID with the extension ext.
+func convertedCollection(
+	ctx context.Context,
+	w Writer,
+	dc data.Collection,
+	dirName, ext string,
+	conv converter,
+	entries map[string]details.DetailsEntry,
+	deets *details.Details,
+) error {
+	var errs error
+
+	for itemData := range dc.Items() {
+		if err := convertItem(ctx, w, dc.FullPath(), dirName, ext, conv, itemData, entries, deets); err != nil {
+			errs = support.WrapAndAppend(itemData.UUID(), err, errs)
+		}
+	}
+
+	return errs
+}
+
+func convertItem(
+	ctx context.Context,
+	w Writer,
+	directory path.Path,
+	dirName, ext string,
+	conv converter,
+	itemData data.Stream,
+	entries map[string]details.DetailsEntry,
+	deets *details.Details,
+) error {
+	rc := itemData.ToReader()
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return errors.Wrap(err, "reading item")
+	}
+
+	var buf bytes.Buffer
+
+	if err := conv(&buf, bs); err != nil {
+		return errors.Wrap(err, "converting item")
+	}
+
+	name := dirName + "/" + itemData.UUID() + ext
+
+	return writeItem(ctx, w, directory, itemData.UUID(), name, int64(buf.Len()), &buf, entries, deets)
+}
+
+// modTime returns the last modified time recorded in the item info, or
+// the current time if the info holds none.
+func modTime(info details.ItemInfo) time.Time {
+	var t time.Time
+
+	switch {
+	case info.OneDrive != nil:
+		t = info.OneDrive.Modified
+	case info.SharePoint != nil:
+		t = info.SharePoint.Modified
+	case info.Exchange != nil:
+		t = info.Exchange.Modified
+	}
+
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	return t
+}
@@ -0,0 +1,183 @@
+package export
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/connector/mockconnector"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ExportUnitSuite struct {
+	suite.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, new(ExportUnitSuite))
+}
+
+type written struct {
+	content string
+	modTime time.Time
+}
+
+// mockWriter records the files written to it.
+type mockWriter struct {
+	files map[string]written
+}
+
+func (mw *mockWriter) Write(name string, _ int64, r io.Reader, modTime time.Time) (int64, error) {
+	bs, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+
+	mw.files[name] = written{string(bs), modTime}
+
+	return int64(len(bs)), nil
+}
+
+func (mw *mockWriter) Close() error {
+	return nil
+}
+
+func (suite *ExportUnitSuite) TestCollections_drive() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	t := suite.T()
+	modified := time.Date(2022, 10, 5, 12, 30, 0, 0, time.UTC)
+
+	dir, err := path.Builder{}.
+		Append("drives", "driveID", "root:", "a", "b").
+		ToDataLayerOneDrivePath("tenant", "user", false)
+	require.NoError(t, err)
+
+	dc := mockconnector.NewMockExchangeCollection(dir, 2)
+	dc.Names = []string{"one.txt", "two.txt"}
+	dc.Data = [][]byte{[]byte("one"), []byte("two")}
+
+	itemPath, err := dir.Append("one.txt", true)
+	require.NoError(t, err)
+
+	entries := map[string]details.DetailsEntry{
+		itemPath.String(): {
+			RepoRef:   itemPath.String(),
+			ParentRef: dir.ShortRef(),
+			ItemInfo: details.ItemInfo{
+				OneDrive: &details.OneDriveInfo{ItemName: "one.txt", Modified: modified},
+			},
+		},
+	}
+
+	w := &mockWriter{files: map[string]written{}}
+
+	deets, itemsRead, err := Collections(ctx, w, control.EMLFormat, []data.Collection{dc}, entries)
+	require.NoError(t, err)
+
+	assert.Len(t, deets.Entries, 2)
+	assert.Equal(t, 2, itemsRead)
+	require.Len(t, w.files, 2)
+
+	one, ok := w.files["user/a/b/one.txt"]
+	require.True(t, ok)
+	assert.Equal(t, "one", one.content)
+	assert.Equal(t, modified, one.modTime)
+
+	two, ok := w.files["user/a/b/two.txt"]
+	require.True(t, ok)
+	assert.Equal(t, "two", two.content)
+	assert.False(t, two.modTime.IsZero())
+}
+
+func (suite *ExportUnitSuite) TestCountedCollection_stopEarly() {
+	var (
+		t     = suite.T()
+		count int64
+		done  = make(chan struct{})
+	)
+
+	p, err := path.Builder{}.
+		Append("Inbox").
+		ToDataLayerExchangePathForCategory("tenant", "user", path.EmailCategory, false)
+	require.NoError(t, err)
+
+	cc := countedCollection{
+		Collection: mockconnector.NewMockExchangeCollection(p, 1000),
+		count:      &count,
+		done:       done,
+	}
+
+	items := cc.Items()
+	<-items
+
+	close(done)
+
+	// the forwarding goroutine exits instead of blocking on the unread
+	// items.  An item it was already offering may still be handed out.
+	assert.Eventually(
+		t,
+		func() bool {
+			select {
+			case _, ok := <-items:
+				return !ok
+			default:
+				return false
+			}
+		},
+		time.Second,
+		10*time.Millisecond,
+		"items closed")
+}
+
+func (suite *ExportUnitSuite) TestCollections_errors() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	table := []struct {
+		name    string
+		getPath func(t *testing.T) path.Path
+	}{
+		{
+			name: "unsupported category",
+			getPath: func(t *testing.T) path.Path {
+				p, err := path.Builder{}.
+					Append("list").
+					ToDataLayerSharePointPath("tenant", "site", path.ListsCategory, false)
+				require.NoError(t, err)
+
+				return p
+			},
+		},
+		{
+			name: "short drive path",
+			getPath: func(t *testing.T) path.Path {
+				p, err := path.Builder{}.
+					Append("drives", "driveID").
+					ToDataLayerOneDrivePath("tenant", "user", false)
+				require.NoError(t, err)
+
+				return p
+			},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			w := &mockWriter{files: map[string]written{}}
+			dc := mockconnector.NewMockExchangeCollection(test.getPath(t), 1)
+
+			_, _, err := Collections(ctx, w, control.EMLFormat, []data.Collection{dc}, nil)
+			assert.Error(t, err)
+			assert.Empty(t, w.files)
+		})
+	}
+}
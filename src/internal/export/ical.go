@@ -0,0 +1,368 @@
+package export
+
+import (
+	"encoding/base64"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/common"
+	"github.com/alcionai/corso/src/internal/connector/support"
+)
+
+const prodID = "-//Alcion//Corso//EN"
+
+// eventToICSBytes converts a serialized event into an iCalendar object.
+func eventToICSBytes(w io.Writer, bs []byte) error {
+	event, err := support.CreateEventFromBytes(bs)
+	if err != nil {
+		return errors.Wrap(err, "deserializing event")
+	}
+
+	return eventToICS(w, event)
+}
+
+// eventToICS writes the event to w as an iCalendar (RFC 5545) object
+// holding a single VEVENT.  Event times are stored by M365 in UTC.
+func eventToICS(w io.Writer, event models.Eventable) error {
+	var (
+		cl     = &contentLines{}
+		allDay = event.GetIsAllDay() != nil && *event.GetIsAllDay()
+	)
+
+	cl.raw("BEGIN", "VCALENDAR")
+	cl.raw("VERSION", "2.0")
+	cl.raw("PRODID", prodID)
+	cl.raw("BEGIN", "VEVENT")
+
+	uid := ptrString(event.GetICalUId())
+	if len(uid) == 0 {
+		uid = ptrString(event.GetId())
+	}
+
+	cl.text("UID", uid)
+	cl.raw("DTSTAMP", utcDateTime(stampTime(event)))
+	cl.raw("CREATED", utcDateTime(event.GetCreatedDateTime()))
+	cl.raw("LAST-MODIFIED", utcDateTime(event.GetLastModifiedDateTime()))
+
+	start := eventTime(event.GetStart())
+	end := eventTime(event.GetEnd())
+
+	if allDay {
+		cl.raw("DTSTART", dateValue(start), "VALUE=DATE")
+		cl.raw("DTEND", dateValue(end), "VALUE=DATE")
+	} else {
+		cl.raw("DTSTART", utcDateTime(start))
+		cl.raw("DTEND", utcDateTime(end))
+	}
+
+	cl.raw("RRULE", recurrenceRule(event.GetRecurrence(), allDay))
+	cl.text("SUMMARY", ptrString(event.GetSubject()))
+
+	if loc := event.GetLocation(); loc != nil {
+		cl.text("LOCATION", ptrString(loc.GetDisplayName()))
+	}
+
+	if body := event.GetBody(); body != nil {
+		if body.GetContentType() != nil && *body.GetContentType() == models.HTML_BODYTYPE {
+			cl.text("DESCRIPTION", ptrString(event.GetBodyPreview()))
+			cl.text("X-ALT-DESC", ptrString(body.GetContent()), "FMTTYPE=text/html")
+		} else {
+			cl.text("DESCRIPTION", ptrString(body.GetContent()))
+		}
+	}
+
+	if org := event.GetOrganizer(); org != nil {
+		if ea := org.GetEmailAddress(); ea != nil && ea.GetAddress() != nil {
+			cl.raw("ORGANIZER", "mailto:"+*ea.GetAddress(), nameParams(ea)...)
+		}
+	}
+
+	for _, a := range event.GetAttendees() {
+		addAttendee(cl, a)
+	}
+
+	if event.GetIsCancelled() != nil && *event.GetIsCancelled() {
+		cl.raw("STATUS", "CANCELLED")
+	}
+
+	if s := event.GetSensitivity(); s != nil {
+		switch *s {
+		case models.PRIVATE_SENSITIVITY, models.PERSONAL_SENSITIVITY:
+			cl.raw("CLASS", "PRIVATE")
+		case models.CONFIDENTIAL_SENSITIVITY:
+			cl.raw("CLASS", "CONFIDENTIAL")
+		}
+	}
+
+	if sa := event.GetShowAs(); sa != nil && *sa == models.FREE_FREEBUSYSTATUS {
+		cl.raw("TRANSP", "TRANSPARENT")
+	}
+
+	cl.raw("CATEGORIES", textList(event.GetCategories()))
+
+	for _, a := range event.GetAttachments() {
+		if fa, ok := a.(models.FileAttachmentable); ok {
+			addAttachment(cl, fa)
+		}
+	}
+
+	cl.raw("END", "VEVENT")
+	cl.raw("END", "VCALENDAR")
+
+	return cl.write(w)
+}
+
+// stampTime returns the time the event was last changed, or the current
+// time if the event has no modification or creation time.
+func stampTime(event models.Eventable) *time.Time {
+	if t := event.GetLastModifiedDateTime(); t != nil {
+		return t
+	}
+
+	if t := event.GetCreatedDateTime(); t != nil {
+		return t
+	}
+
+	now := time.Now()
+
+	return &now
+}
+
+// eventTime parses an M365 event start or end time.
+func eventTime(dt models.DateTimeTimeZoneable) *time.Time {
+	if dt == nil || dt.GetDateTime() == nil {
+		return nil
+	}
+
+	// the stored value has no zone designator, but is always in UTC.
+	t, err := common.ParseTime(*dt.GetDateTime() + "Z")
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func dateValue(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(dateFormat)
+}
+
+// nameParams produces the common name parameter for an email address.
+func nameParams(ea models.EmailAddressable) []string {
+	if n := ptrString(ea.GetName()); len(n) > 0 && n != ptrString(ea.GetAddress()) {
+		return []string{param("CN", n)}
+	}
+
+	return nil
+}
+
+var (
+	attendeeRoles = map[models.AttendeeType]string{
+		models.REQUIRED_ATTENDEETYPE: "REQ-PARTICIPANT",
+		models.OPTIONAL_ATTENDEETYPE: "OPT-PARTICIPANT",
+		models.RESOURCE_ATTENDEETYPE: "NON-PARTICIPANT",
+	}
+	participationStatus = map[models.ResponseType]string{
+		models.ACCEPTED_RESPONSETYPE:            "ACCEPTED",
+		models.DECLINED_RESPONSETYPE:            "DECLINED",
+		models.TENTATIVELYACCEPTED_RESPONSETYPE: "TENTATIVE",
+		models.ORGANIZER_RESPONSETYPE:           "ACCEPTED",
+	}
+)
+
+func addAttendee(cl *contentLines, a models.Attendeeable) {
+	if a == nil || a.GetEmailAddress() == nil || a.GetEmailAddress().GetAddress() == nil {
+		return
+	}
+
+	var (
+		ea     = a.GetEmailAddress()
+		params = nameParams(ea)
+		status = "NEEDS-ACTION"
+	)
+
+	if t := a.GetType(); t != nil && len(attendeeRoles[*t]) > 0 {
+		params = append(params, "ROLE="+attendeeRoles[*t])
+
+		if *t == models.RESOURCE_ATTENDEETYPE {
+			params = append(params, "CUTYPE=RESOURCE")
+		}
+	}
+
+	if s := a.GetStatus(); s != nil && s.GetResponse() != nil {
+		if ps, ok := participationStatus[*s.GetResponse()]; ok {
+			status = ps
+		}
+	}
+
+	params = append(params, "PARTSTAT="+status)
+
+	cl.raw("ATTENDEE", "mailto:"+*ea.GetAddress(), params...)
+}
+
+func addAttachment(cl *contentLines, fa models.FileAttachmentable) {
+	ct := ptrString(fa.GetContentType())
+	if len(ct) == 0 {
+		ct = "application/octet-stream"
+	}
+
+	params := []string{
+		param("FMTTYPE", ct),
+		"ENCODING=BASE64",
+		"VALUE=BINARY",
+	}
+
+	if n := ptrString(fa.GetName()); len(n) > 0 {
+		params = append(params, param("X-FILENAME", n))
+	}
+
+	cl.raw("ATTACH", base64.StdEncoding.EncodeToString(fa.GetContentBytes()), params...)
+}
+
+// ---------------------------------------------------------------------------
+// recurrence
+// ---------------------------------------------------------------------------
+
+var (
+	weekdays = map[models.DayOfWeek]string{
+		models.SUNDAY_DAYOFWEEK:    "SU",
+		models.MONDAY_DAYOFWEEK:    "MO",
+		models.TUESDAY_DAYOFWEEK:   "TU",
+		models.WEDNESDAY_DAYOFWEEK: "WE",
+		models.THURSDAY_DAYOFWEEK:  "TH",
+		models.FRIDAY_DAYOFWEEK:    "FR",
+		models.SATURDAY_DAYOFWEEK:  "SA",
+	}
+	weekIndexes = map[models.WeekIndex]string{
+		models.FIRST_WEEKINDEX:  "1",
+		models.SECOND_WEEKINDEX: "2",
+		models.THIRD_WEEKINDEX:  "3",
+		models.FOURTH_WEEKINDEX: "4",
+		models.LAST_WEEKINDEX:   "-1",
+	}
+)
+
+// recurrenceRule produces the RRULE value for an M365 recurrence.  Returns
+// the empty string for events that don't recur.
+func recurrenceRule(rec models.PatternedRecurrenceable, allDay bool) string {
+	if rec == nil || rec.GetPattern() == nil || rec.GetPattern().GetType() == nil {
+		return ""
+	}
+
+	var (
+		pat   = rec.GetPattern()
+		parts = []string{}
+	)
+
+	switch *pat.GetType() {
+	case models.DAILY_RECURRENCEPATTERNTYPE:
+		parts = append(parts, "FREQ=DAILY")
+
+	case models.WEEKLY_RECURRENCEPATTERNTYPE:
+		parts = append(parts, "FREQ=WEEKLY", "BYDAY="+byDay(pat.GetDaysOfWeek()))
+
+		if fd := pat.GetFirstDayOfWeek(); fd != nil {
+			parts = append(parts, "WKST="+weekdays[*fd])
+		}
+
+	case models.ABSOLUTEMONTHLY_RECURRENCEPATTERNTYPE:
+		parts = append(parts, "FREQ=MONTHLY", "BYMONTHDAY="+int32String(pat.GetDayOfMonth()))
+
+	case models.RELATIVEMONTHLY_RECURRENCEPATTERNTYPE:
+		parts = append(parts, "FREQ=MONTHLY", "BYDAY="+byDay(pat.GetDaysOfWeek()), "BYSETPOS="+setPos(pat.GetIndex()))
+
+	case models.ABSOLUTEYEARLY_RECURRENCEPATTERNTYPE:
+		parts = append(
+			parts,
+			"FREQ=YEARLY",
+			"BYMONTH="+int32String(pat.GetMonth()),
+			"BYMONTHDAY="+int32String(pat.GetDayOfMonth()))
+
+	case models.RELATIVEYEARLY_RECURRENCEPATTERNTYPE:
+		parts = append(
+			parts,
+			"FREQ=YEARLY",
+			"BYMONTH="+int32String(pat.GetMonth()),
+			"BYDAY="+byDay(pat.GetDaysOfWeek()),
+			"BYSETPOS="+setPos(pat.GetIndex()))
+
+	default:
+		return ""
+	}
+
+	if i := pat.GetInterval(); i != nil && *i > 1 {
+		parts = append(parts, "INTERVAL="+int32String(i))
+	}
+
+	if rng := rec.GetRange(); rng != nil && rng.GetType() != nil {
+		switch *rng.GetType() {
+		case models.ENDDATE_RECURRENCERANGETYPE:
+			if until := untilValue(rng, allDay); len(until) > 0 {
+				parts = append(parts, "UNTIL="+until)
+			}
+
+		case models.NUMBERED_RECURRENCERANGETYPE:
+			parts = append(parts, "COUNT="+int32String(rng.GetNumberOfOccurrences()))
+		}
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func byDay(days []models.DayOfWeek) string {
+	ds := make([]string, 0, len(days))
+
+	for _, d := range days {
+		ds = append(ds, weekdays[d])
+	}
+
+	return strings.Join(ds, ",")
+}
+
+func setPos(idx *models.WeekIndex) string {
+	if idx == nil {
+		return weekIndexes[models.FIRST_WEEKINDEX]
+	}
+
+	return weekIndexes[*idx]
+}
+
+// untilValue produces the UNTIL value for the last day of a recurrence.
+// The value type must match the event's DTSTART, so timed events recur
+// until the end of that day.
+func untilValue(rng models.RecurrenceRangeable, allDay bool) string {
+	if rng.GetEndDate() == nil {
+		return ""
+	}
+
+	end, err := time.Parse(string(common.DateOnly), rng.GetEndDate().String())
+	if err != nil {
+		return ""
+	}
+
+	if allDay {
+		return end.Format(dateFormat)
+	}
+
+	end = end.Add(24*time.Hour - time.Second)
+
+	return end.Format(utcDateTimeFormat)
+}
+
+// int32String formats a recurrence value.  Missing values default to 1.
+func int32String(i *int32) string {
+	if i == nil {
+		return "1"
+	}
+
+	return strconv.Itoa(int(*i))
+}
@@ -0,0 +1,298 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/connector/mockconnector"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ICalUnitSuite struct {
+	suite.Suite
+}
+
+func TestICalUnitSuite(t *testing.T) {
+	suite.Run(t, new(ICalUnitSuite))
+}
+
+func dateTimeTimeZone(dt string) models.DateTimeTimeZoneable {
+	tz := "UTC"
+	d := models.NewDateTimeTimeZone()
+	d.SetDateTime(&dt)
+	d.SetTimeZone(&tz)
+
+	return d
+}
+
+func (suite *ICalUnitSuite) TestEventToICS() {
+	var (
+		t        = suite.T()
+		event    = models.NewEvent()
+		id       = "event-id"
+		subject  = "Planning; Q4"
+		modified = time.Date(2022, 10, 1, 8, 0, 0, 0, time.UTC)
+		attendee = models.NewAttendee()
+		ea       = models.NewEmailAddress()
+		name     = "Bob"
+		addr     = "bob@ex.com"
+		at       = models.OPTIONAL_ATTENDEETYPE
+		status   = models.NewResponseStatus()
+		resp     = models.ACCEPTED_RESPONSETYPE
+	)
+
+	event.SetId(&id)
+	event.SetSubject(&subject)
+	event.SetLastModifiedDateTime(&modified)
+	event.SetStart(dateTimeTimeZone("2022-10-05T12:30:00.0000000"))
+	event.SetEnd(dateTimeTimeZone("2022-10-05T13:00:00.0000000"))
+
+	ea.SetName(&name)
+	ea.SetAddress(&addr)
+	attendee.SetEmailAddress(ea)
+	attendee.SetType(&at)
+	status.SetResponse(&resp)
+	attendee.SetStatus(status)
+	event.SetAttendees([]models.Attendeeable{attendee})
+
+	var buf bytes.Buffer
+	require.NoError(t, eventToICS(&buf, event))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), crlf), crlf)
+
+	assert.Equal(t, []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + prodID,
+		"BEGIN:VEVENT",
+		"UID:event-id",
+		"DTSTAMP:20221001T080000Z",
+		"LAST-MODIFIED:20221001T080000Z",
+		"DTSTART:20221005T123000Z",
+		"DTEND:20221005T130000Z",
+		`SUMMARY:Planning\; Q4`,
+		"ATTENDEE;CN=Bob;ROLE=OPT-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:bob@ex.com",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, lines)
+}
+
+func (suite *ICalUnitSuite) TestEventToICSBytes() {
+	table := []struct {
+		name   string
+		bytes  []byte
+		expect []string
+	}{
+		{
+			name:  "attendees",
+			bytes: mockconnector.GetMockEventWithAttendeesBytes("attendees"),
+			expect: []string{
+				"SUMMARY:Board attendees Meeting\r\n",
+				"ORGANIZER;CN=Lidia Holloway:mailto:LidiaH@8qzvrj.onmicrosoft.com\r\n",
+				"ATTENDEE;CN=George Martinez;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION:mailto:george.martinez@8qzvrj.onmicrosoft.com\r\n",
+				"X-ALT-DESC;FMTTYPE=text/html:",
+			},
+		},
+		{
+			name:  "attachment",
+			bytes: mockconnector.GetMockEventWithAttachment("attached"),
+			expect: []string{
+				"ATTACH;FMTTYPE=",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, eventToICSBytes(&buf, test.bytes))
+
+			unfolded := strings.ReplaceAll(buf.String(), crlf+" ", "")
+
+			for _, e := range test.expect {
+				assert.Contains(t, unfolded, e)
+			}
+		})
+	}
+}
+
+func (suite *ICalUnitSuite) TestRecurrenceRule() {
+	var (
+		weekly   = models.WEEKLY_RECURRENCEPATTERNTYPE
+		relMonth = models.RELATIVEMONTHLY_RECURRENCEPATTERNTYPE
+		absYear  = models.ABSOLUTEYEARLY_RECURRENCEPATTERNTYPE
+		endDate  = models.ENDDATE_RECURRENCERANGETYPE
+		numbered = models.NUMBERED_RECURRENCERANGETYPE
+		noEnd    = models.NOEND_RECURRENCERANGETYPE
+		monday   = models.MONDAY_DAYOFWEEK
+		last     = models.LAST_WEEKINDEX
+		two      = int32(2)
+		ten      = int32(10)
+		four     = int32(4)
+	)
+
+	rec := func(
+		pt models.RecurrencePatternType,
+		rt models.RecurrenceRangeType,
+		set func(models.RecurrencePatternable, models.RecurrenceRangeable),
+	) models.PatternedRecurrenceable {
+		p := models.NewRecurrencePattern()
+		p.SetType(&pt)
+
+		r := models.NewRecurrenceRange()
+		r.SetType(&rt)
+
+		set(p, r)
+
+		pr := models.NewPatternedRecurrence()
+		pr.SetPattern(p)
+		pr.SetRange(r)
+
+		return pr
+	}
+
+	table := []struct {
+		name   string
+		rec    models.PatternedRecurrenceable
+		allDay bool
+		expect string
+	}{
+		{
+			name:   "none",
+			expect: "",
+		},
+		{
+			name: "weekly until",
+			rec: rec(weekly, endDate, func(p models.RecurrencePatternable, r models.RecurrenceRangeable) {
+				p.SetDaysOfWeek([]models.DayOfWeek{models.MONDAY_DAYOFWEEK, models.FRIDAY_DAYOFWEEK})
+				p.SetFirstDayOfWeek(&monday)
+				p.SetInterval(&two)
+				r.SetEndDate(serializationDate(suite.T(), "2022-12-31"))
+			}),
+			expect: "FREQ=WEEKLY;BYDAY=MO,FR;WKST=MO;INTERVAL=2;UNTIL=20221231T235959Z",
+		},
+		{
+			name: "weekly until all day",
+			rec: rec(weekly, endDate, func(p models.RecurrencePatternable, r models.RecurrenceRangeable) {
+				p.SetDaysOfWeek([]models.DayOfWeek{models.MONDAY_DAYOFWEEK})
+				r.SetEndDate(serializationDate(suite.T(), "2022-12-31"))
+			}),
+			allDay: true,
+			expect: "FREQ=WEEKLY;BYDAY=MO;UNTIL=20221231",
+		},
+		{
+			name: "last monday monthly",
+			rec: rec(relMonth, numbered, func(p models.RecurrencePatternable, r models.RecurrenceRangeable) {
+				p.SetDaysOfWeek([]models.DayOfWeek{models.MONDAY_DAYOFWEEK})
+				p.SetIndex(&last)
+				r.SetNumberOfOccurrences(&ten)
+			}),
+			expect: "FREQ=MONTHLY;BYDAY=MO;BYSETPOS=-1;COUNT=10",
+		},
+		{
+			name: "yearly",
+			rec: rec(absYear, noEnd, func(p models.RecurrencePatternable, r models.RecurrenceRangeable) {
+				p.SetMonth(&four)
+				p.SetDayOfMonth(&ten)
+			}),
+			expect: "FREQ=YEARLY;BYMONTH=4;BYMONTHDAY=10",
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, recurrenceRule(test.rec, test.allDay))
+		})
+	}
+}
+
+func serializationDate(t *testing.T, d string) *serialization.DateOnly {
+	do, err := serialization.ParseDateOnly(d)
+	require.NoError(t, err)
+
+	return do
+}
+
+func (suite *ICalUnitSuite) TestCollections_contactsAndEvents() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	table := []struct {
+		name     string
+		category path.CategoryType
+		folder   string
+		getDC    func(p path.Path) *mockconnector.MockExchangeDataCollection
+		ext      string
+		expect   string
+	}{
+		{
+			name:     "contacts",
+			category: path.ContactsCategory,
+			folder:   "Contacts",
+			getDC: func(p path.Path) *mockconnector.MockExchangeDataCollection {
+				dc := mockconnector.NewMockExchangeCollection(p, 2)
+				dc.Data = [][]byte{
+					mockconnector.GetMockContactBytes("one"),
+					mockconnector.GetMockContactBytes("two"),
+				}
+
+				return dc
+			},
+			ext:    ".vcf",
+			expect: "BEGIN:VCARD",
+		},
+		{
+			name:     "events",
+			category: path.EventsCategory,
+			folder:   "Calendar",
+			getDC: func(p path.Path) *mockconnector.MockExchangeDataCollection {
+				dc := mockconnector.NewMockExchangeCollection(p, 2)
+				dc.Data = [][]byte{
+					mockconnector.GetMockEventWithSubjectBytes("one"),
+					mockconnector.GetMockEventWithSubjectBytes("two"),
+				}
+
+				return dc
+			},
+			ext:    ".ics",
+			expect: "BEGIN:VEVENT",
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			dir, err := path.Builder{}.
+				Append(test.folder).
+				ToDataLayerExchangePathForCategory("tenant", "user", test.category, false)
+			require.NoError(t, err)
+
+			var (
+				w  = &mockWriter{files: map[string]written{}}
+				dc = test.getDC(dir)
+			)
+
+			deets, itemsRead, err := Collections(ctx, w, control.EMLFormat, []data.Collection{dc}, nil)
+			require.NoError(t, err)
+
+			assert.Len(t, deets.Entries, 2)
+			assert.Equal(t, 2, itemsRead)
+			require.Len(t, w.files, 2)
+
+			for _, n := range dc.Names {
+				f := "user/" + test.folder + "/" + n + test.ext
+
+				wr, ok := w.files[f]
+				require.True(t, ok, f)
+				assert.Contains(t, wr.content, test.expect, f)
+			}
+		})
+	}
+}
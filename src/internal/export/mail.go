@@ -0,0 +1,480 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/connector/support"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const (
+	crlf = "\r\n"
+
+	// RFC 2045 limits encoded lines to 76 characters.
+	base64LineLen = 76
+)
+
+// messageToEMLBytes converts a serialized message into an RFC 5322 message.
+func messageToEMLBytes(w io.Writer, bs []byte) error {
+	msg, err := support.CreateMessageFromBytes(bs)
+	if err != nil {
+		return errors.Wrap(err, "deserializing message")
+	}
+
+	return messageToEML(w, msg)
+}
+
+// mboxCollection writes all messages in the collection to a single mbox
+// file named after the folder.
+func mboxCollection(
+	ctx context.Context,
+	w Writer,
+	dc data.Collection,
+	dirName string,
+	entries map[string]details.DetailsEntry,
+	deets *details.Details,
+) error {
+	var (
+		errs      error
+		directory = dc.FullPath()
+		exported  = []path.Path{}
+	)
+
+	// The mbox is staged in a temporary file since the size of each file
+	// must be known before it can be added to an archive.
+	f, err := os.CreateTemp("", "corso-export-*.mbox")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary mbox file")
+	}
+
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	mw := mboxWriter{w: f}
+
+	for itemData := range dc.Items() {
+		itemPath, err := directory.Append(itemData.UUID(), true)
+		if err != nil {
+			errs = support.WrapAndAppend(itemData.UUID(), errors.Wrap(err, "building item path"), errs)
+			continue
+		}
+
+		msg, err := readMessage(itemData)
+		if err != nil {
+			errs = support.WrapAndAppend(itemData.UUID(), err, errs)
+			continue
+		}
+
+		if err := mw.add(msg); err != nil {
+			errs = support.WrapAndAppend(itemData.UUID(), err, errs)
+			continue
+		}
+
+		exported = append(exported, itemPath)
+	}
+
+	if len(exported) == 0 {
+		return errs
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		return support.WrapAndAppend(dirName, errors.Wrap(err, "rewinding temporary mbox file"), errs)
+	}
+
+	if _, err := w.Write(dirName+".mbox", size, f, time.Now()); err != nil {
+		return support.WrapAndAppend(dirName, err, errs)
+	}
+
+	for _, p := range exported {
+		addEntry(deets, p, entries[p.String()])
+	}
+
+	logger.Ctx(ctx).Debugw("exported mbox", "path", directory.ShortRef(), "messages", len(exported))
+
+	return errs
+}
+
+// readMessage deserializes the message stored in itemData.
+func readMessage(itemData data.Stream) (models.Messageable, error) {
+	rc := itemData.ToReader()
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading message")
+	}
+
+	msg, err := support.CreateMessageFromBytes(bs)
+
+	return msg, errors.Wrap(err, "deserializing message")
+}
+
+// messageToEML writes msg to w as an RFC 5322 message.  File attachments
+// are added as MIME parts, and attached messages are embedded as
+// message/rfc822 parts.  Other attachment types are dropped.
+func messageToEML(w io.Writer, msg models.Messageable) error {
+	var (
+		hdr      = messageHeader(msg)
+		body, ct = messageBody(msg)
+		parts    = messageAttachments(msg)
+	)
+
+	ct = mime.FormatMediaType(ct, map[string]string{"charset": "utf-8"})
+
+	if len(parts) == 0 {
+		hdr.add("Content-Type", ct)
+		hdr.add("Content-Transfer-Encoding", "quoted-printable")
+
+		if err := hdr.write(w); err != nil {
+			return err
+		}
+
+		return writeQuotedPrintable(w, body)
+	}
+
+	mw := multipart.NewWriter(w)
+
+	hdr.add("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mw.Boundary()}))
+
+	if err := hdr.write(w); err != nil {
+		return err
+	}
+
+	pw, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {ct},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return errors.Wrap(err, "creating message body part")
+	}
+
+	if err := writeQuotedPrintable(pw, body); err != nil {
+		return err
+	}
+
+	for _, p := range parts {
+		pw, err := mw.CreatePart(p.header)
+		if err != nil {
+			return errors.Wrap(err, "creating attachment part")
+		}
+
+		if err := p.write(pw); err != nil {
+			return errors.Wrap(err, "writing attachment")
+		}
+	}
+
+	return errors.Wrap(mw.Close(), "closing message")
+}
+
+// ---------------------------------------------------------------------------
+// headers
+// ---------------------------------------------------------------------------
+
+// header holds message header fields in the order they are written.
+type header struct {
+	keys   []string
+	values []string
+}
+
+func (h *header) add(k, v string) {
+	if len(v) == 0 {
+		return
+	}
+
+	h.keys = append(h.keys, k)
+	h.values = append(h.values, v)
+}
+
+func (h header) write(w io.Writer) error {
+	var sb strings.Builder
+
+	for i, k := range h.keys {
+		sb.WriteString(k + ": " + h.values[i] + crlf)
+	}
+
+	sb.WriteString(crlf)
+
+	_, err := io.WriteString(w, sb.String())
+
+	return errors.Wrap(err, "writing message header")
+}
+
+func messageHeader(msg models.Messageable) *header {
+	hdr := &header{}
+
+	hdr.add("MIME-Version", "1.0")
+	hdr.add("Message-ID", ptrString(msg.GetInternetMessageId()))
+
+	if d := messageDate(msg); !d.IsZero() {
+		hdr.add("Date", d.Format(time.RFC1123Z))
+	}
+
+	hdr.add("From", formatRecipients(msg.GetFrom()))
+
+	if s := msg.GetSender(); s != nil && formatRecipients(s) != formatRecipients(msg.GetFrom()) {
+		hdr.add("Sender", formatRecipients(s))
+	}
+
+	hdr.add("Reply-To", formatRecipients(msg.GetReplyTo()...))
+	hdr.add("To", formatRecipients(msg.GetToRecipients()...))
+	hdr.add("Cc", formatRecipients(msg.GetCcRecipients()...))
+	hdr.add("Bcc", formatRecipients(msg.GetBccRecipients()...))
+	hdr.add("Subject", mime.QEncoding.Encode("utf-8", ptrString(msg.GetSubject())))
+
+	if imp := msg.GetImportance(); imp != nil && *imp != models.NORMAL_IMPORTANCE {
+		hdr.add("Importance", imp.String())
+	}
+
+	return hdr
+}
+
+// messageDate returns the time the message was sent, falling back to the
+// time it was received or created.
+func messageDate(msg models.Messageable) time.Time {
+	for _, t := range []*time.Time{
+		msg.GetSentDateTime(),
+		msg.GetReceivedDateTime(),
+		msg.GetCreatedDateTime(),
+	} {
+		if t != nil {
+			return *t
+		}
+	}
+
+	return time.Time{}
+}
+
+// formatRecipients produces an RFC 5322 address list.
+func formatRecipients(rs ...models.Recipientable) string {
+	addrs := make([]string, 0, len(rs))
+
+	for _, r := range rs {
+		if r == nil || r.GetEmailAddress() == nil {
+			continue
+		}
+
+		ea := r.GetEmailAddress()
+		if ea.GetAddress() == nil {
+			continue
+		}
+
+		a := mail.Address{Name: ptrString(ea.GetName()), Address: *ea.GetAddress()}
+		if a.Name == a.Address {
+			a.Name = ""
+		}
+
+		addrs = append(addrs, a.String())
+	}
+
+	return strings.Join(addrs, ", ")
+}
+
+// ---------------------------------------------------------------------------
+// body and attachments
+// ---------------------------------------------------------------------------
+
+// messageBody returns the content of the message body and its media type.
+func messageBody(msg models.Messageable) (string, string) {
+	body := msg.GetBody()
+	if body == nil {
+		return "", "text/plain"
+	}
+
+	ct := "text/plain"
+	if body.GetContentType() != nil && *body.GetContentType() == models.HTML_BODYTYPE {
+		ct = "text/html"
+	}
+
+	return ptrString(body.GetContent()), ct
+}
+
+func writeQuotedPrintable(w io.Writer, s string) error {
+	qw := quotedprintable.NewWriter(w)
+
+	if _, err := io.WriteString(qw, s); err != nil {
+		return errors.Wrap(err, "writing message body")
+	}
+
+	return errors.Wrap(qw.Close(), "writing message body")
+}
+
+// mimePart is an attachment ready to be added to a multipart message.
+type mimePart struct {
+	header textproto.MIMEHeader
+	write  func(w io.Writer) error
+}
+
+func messageAttachments(msg models.Messageable) []mimePart {
+	parts := []mimePart{}
+
+	for _, a := range msg.GetAttachments() {
+		switch att := a.(type) {
+		case models.FileAttachmentable:
+			parts = append(parts, fileAttachmentPart(att))
+
+		case models.ItemAttachmentable:
+			if m, ok := att.GetItem().(models.Messageable); ok {
+				parts = append(parts, messageAttachmentPart(att, m))
+			}
+		}
+	}
+
+	return parts
+}
+
+func fileAttachmentPart(att models.FileAttachmentable) mimePart {
+	var (
+		name = ptrString(att.GetName())
+		ct   = ptrString(att.GetContentType())
+		hdr  = textproto.MIMEHeader{}
+		disp = "attachment"
+	)
+
+	if len(ct) == 0 {
+		ct = "application/octet-stream"
+	}
+
+	if att.GetIsInline() != nil && *att.GetIsInline() {
+		disp = "inline"
+	}
+
+	if cid := ptrString(att.GetContentId()); len(cid) > 0 {
+		hdr.Set("Content-ID", "<"+strings.Trim(cid, "<>")+">")
+	}
+
+	hdr.Set("Content-Type", mime.FormatMediaType(ct, nameParam("name", name)))
+	hdr.Set("Content-Disposition", mime.FormatMediaType(disp, nameParam("filename", name)))
+	hdr.Set("Content-Transfer-Encoding", "base64")
+
+	return mimePart{
+		header: hdr,
+		write: func(w io.Writer) error {
+			return writeBase64(w, att.GetContentBytes())
+		},
+	}
+}
+
+func messageAttachmentPart(att models.ItemAttachmentable, msg models.Messageable) mimePart {
+	hdr := textproto.MIMEHeader{}
+	hdr.Set("Content-Type", "message/rfc822")
+	hdr.Set(
+		"Content-Disposition",
+		mime.FormatMediaType("attachment", map[string]string{"filename": ptrString(att.GetName()) + ".eml"}))
+
+	return mimePart{
+		header: hdr,
+		write: func(w io.Writer) error {
+			return messageToEML(w, msg)
+		},
+	}
+}
+
+// nameParam produces the media type parameters for a file name, omitting
+// empty names.
+func nameParam(key, name string) map[string]string {
+	if len(name) == 0 {
+		return nil
+	}
+
+	return map[string]string{key: name}
+}
+
+func writeBase64(w io.Writer, bs []byte) error {
+	enc := base64.StdEncoding.EncodeToString(bs)
+
+	for len(enc) > 0 {
+		n := base64LineLen
+		if n > len(enc) {
+			n = len(enc)
+		}
+
+		if _, err := io.WriteString(w, enc[:n]+crlf); err != nil {
+			return err
+		}
+
+		enc = enc[n:]
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// mbox
+// ---------------------------------------------------------------------------
+
+// mboxWriter appends messages to an mbox file using the mboxrd format:
+// each message is preceded by a "From " separator line, and body lines that
+// start with any number of '>' followed by "From " gain an extra '>'.
+type mboxWriter struct {
+	w io.Writer
+}
+
+func (mw mboxWriter) add(msg models.Messageable) error {
+	var buf bytes.Buffer
+
+	if err := messageToEML(&buf, msg); err != nil {
+		return err
+	}
+
+	sender := "MAILER-DAEMON"
+	if from := msg.GetFrom(); from != nil && from.GetEmailAddress() != nil {
+		if a := ptrString(from.GetEmailAddress().GetAddress()); len(a) > 0 {
+			sender = a
+		}
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "From %s %s\n", sender, messageDate(msg).UTC().Format(time.ANSIC))
+
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), crlf), crlf) {
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			sb.WriteString(">")
+		}
+
+		sb.WriteString(line + "\n")
+	}
+
+	sb.WriteString("\n")
+
+	_, err := io.WriteString(mw.w, sb.String())
+
+	return errors.Wrap(err, "writing mbox message")
+}
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+func ptrString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,224 @@
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/connector/mockconnector"
+	"github.com/alcionai/corso/src/internal/connector/support"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type MailUnitSuite struct {
+	suite.Suite
+}
+
+func TestMailUnitSuite(t *testing.T) {
+	suite.Run(t, new(MailUnitSuite))
+}
+
+func recipient(name, addr string) models.Recipientable {
+	ea := models.NewEmailAddress()
+	ea.SetName(&name)
+	ea.SetAddress(&addr)
+
+	r := models.NewRecipient()
+	r.SetEmailAddress(ea)
+
+	return r
+}
+
+func testMessage(subject, content string) models.Messageable {
+	var (
+		msg   = models.NewMessage()
+		bt    = models.HTML_BODYTYPE
+		body  = models.NewItemBody()
+		msgID = "<abc@example.com>"
+		sent  = time.Date(2022, 10, 5, 12, 30, 0, 0, time.UTC)
+	)
+
+	body.SetContentType(&bt)
+	body.SetContent(&content)
+
+	msg.SetSubject(&subject)
+	msg.SetBody(body)
+	msg.SetInternetMessageId(&msgID)
+	msg.SetSentDateTime(&sent)
+	msg.SetFrom(recipient("Alice", "alice@example.com"))
+	msg.SetToRecipients([]models.Recipientable{
+		recipient("Bob", "bob@example.com"),
+		recipient("carol@example.com", "carol@example.com"),
+	})
+
+	return msg
+}
+
+func (suite *MailUnitSuite) TestMessageToEML() {
+	t := suite.T()
+	msg := testMessage("Hello wörld", "<p>hi</p>")
+
+	var buf bytes.Buffer
+	require.NoError(t, messageToEML(&buf, msg))
+
+	parsed, err := mail.ReadMessage(&buf)
+	require.NoError(t, err)
+
+	dec := new(mime.WordDecoder)
+	subject, err := dec.DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hello wörld", subject)
+	assert.Equal(t, "<abc@example.com>", parsed.Header.Get("Message-ID"))
+	assert.Equal(t, `"Alice" <alice@example.com>`, parsed.Header.Get("From"))
+	assert.Equal(t, `"Bob" <bob@example.com>, <carol@example.com>`, parsed.Header.Get("To"))
+	assert.Empty(t, parsed.Header.Get("Cc"))
+
+	date, err := parsed.Header.Date()
+	require.NoError(t, err)
+	assert.True(t, msg.GetSentDateTime().Equal(date))
+
+	mt, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "text/html", mt)
+	assert.Equal(t, "utf-8", params["charset"])
+
+	body, err := io.ReadAll(parsed.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>hi</p>", string(body))
+}
+
+func (suite *MailUnitSuite) TestMessageToEML_attachments() {
+	t := suite.T()
+
+	msg, err := support.CreateMessageFromBytes(mockconnector.GetMockMessageWithDirectAttachment("attached"))
+	require.NoError(t, err)
+	require.Len(t, msg.GetAttachments(), 1)
+
+	fa, ok := msg.GetAttachments()[0].(models.FileAttachmentable)
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	require.NoError(t, messageToEML(&buf, msg))
+
+	parsed, err := mail.ReadMessage(&buf)
+	require.NoError(t, err)
+
+	mt, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mt)
+
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+
+	// body
+	_, err = mr.NextPart()
+	require.NoError(t, err)
+
+	// attachment
+	p, err := mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, *fa.GetName(), p.FileName())
+
+	assert.Equal(t, "base64", p.Header.Get("Content-Transfer-Encoding"))
+
+	content, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, p))
+	require.NoError(t, err)
+	assert.Equal(t, fa.GetContentBytes(), content)
+
+	_, err = mr.NextPart()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func (suite *MailUnitSuite) TestMboxWriter() {
+	t := suite.T()
+
+	var (
+		buf bytes.Buffer
+		mw  = mboxWriter{w: &buf}
+	)
+
+	require.NoError(t, mw.add(testMessage("first", "line one\r\nFrom the start\r\n>From quoted")))
+	require.NoError(t, mw.add(testMessage("second", "two")))
+
+	out := buf.String()
+	lines := strings.Split(out, "\n")
+
+	assert.Equal(t, "From alice@example.com Wed Oct  5 12:30:00 2022", lines[0])
+	assert.Equal(t, 2, strings.Count(out, "\nFrom alice@example.com ")+1)
+	assert.NotContains(t, out, "\r\n")
+	assert.Contains(t, out, "\n>From the start\n")
+	assert.Contains(t, out, "\n>>From quoted")
+}
+
+func (suite *MailUnitSuite) TestCollections_mail() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	dir, err := path.Builder{}.
+		Append("Inbox", "sub").
+		ToDataLayerExchangePathForCategory("tenant", "user", path.EmailCategory, false)
+	require.NoError(suite.T(), err)
+
+	table := []struct {
+		name        string
+		format      control.MailFormat
+		expectFiles func(names []string) []string
+	}{
+		{
+			name:   "eml",
+			format: control.EMLFormat,
+			expectFiles: func(names []string) []string {
+				fs := []string{}
+				for _, n := range names {
+					fs = append(fs, "user/Inbox/sub/"+n+".eml")
+				}
+
+				return fs
+			},
+		},
+		{
+			name:   "mbox",
+			format: control.MBOXFormat,
+			expectFiles: func(names []string) []string {
+				return []string{"user/Inbox/sub.mbox"}
+			},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			var (
+				w  = &mockWriter{files: map[string]written{}}
+				dc = mockconnector.NewMockExchangeCollection(dir, 3)
+			)
+
+			deets, itemsRead, err := Collections(ctx, w, test.format, []data.Collection{dc}, nil)
+			require.NoError(t, err)
+
+			assert.Len(t, deets.Entries, 3)
+			assert.Equal(t, 3, itemsRead)
+
+			expect := test.expectFiles(dc.Names)
+			require.Len(t, w.files, len(expect))
+
+			for _, f := range expect {
+				wr, ok := w.files[f]
+				require.True(t, ok, f)
+				assert.Contains(t, wr.content, "From: NewMockExchangeCollection", f)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package export
+
+import (
+	"io"
+	"strings"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/connector/support"
+)
+
+// contactToVCardBytes converts a serialized contact into a vCard.
+func contactToVCardBytes(w io.Writer, bs []byte) error {
+	contact, err := support.CreateContactFromBytes(bs)
+	if err != nil {
+		return errors.Wrap(err, "deserializing contact")
+	}
+
+	return contactToVCard(w, contact)
+}
+
+// contactToVCard writes the contact to w as a vCard 4.0 (RFC 6350).
+func contactToVCard(w io.Writer, contact models.Contactable) error {
+	cl := &contentLines{}
+
+	cl.raw("BEGIN", "VCARD")
+	cl.raw("VERSION", "4.0")
+	cl.text("UID", ptrString(contact.GetId()), "VALUE=text")
+
+	// FN is required, even if empty
+	cl.fold("FN:" + escapeText(contactName(contact)))
+	cl.raw("N", structured(
+		ptrString(contact.GetSurname()),
+		ptrString(contact.GetGivenName()),
+		ptrString(contact.GetMiddleName()),
+		ptrString(contact.GetTitle()),
+		ptrString(contact.GetGeneration())))
+	cl.text("NICKNAME", ptrString(contact.GetNickName()))
+
+	if bd := contact.GetBirthday(); bd != nil && !bd.IsZero() {
+		cl.raw("BDAY", bd.UTC().Format(dateFormat))
+	}
+
+	for _, ea := range contact.GetEmailAddresses() {
+		if ea == nil {
+			continue
+		}
+
+		cl.text("EMAIL", ptrString(ea.GetAddress()))
+	}
+
+	cl.text("TEL", ptrString(contact.GetMobilePhone()), "TYPE=cell")
+
+	for _, p := range contact.GetHomePhones() {
+		cl.text("TEL", p, "TYPE=home,voice")
+	}
+
+	for _, p := range contact.GetBusinessPhones() {
+		cl.text("TEL", p, "TYPE=work,voice")
+	}
+
+	cl.raw("ADR", address(contact.GetHomeAddress()), "TYPE=home")
+	cl.raw("ADR", address(contact.GetBusinessAddress()), "TYPE=work")
+	cl.raw("ADR", address(contact.GetOtherAddress()))
+
+	for _, im := range contact.GetImAddresses() {
+		cl.text("IMPP", im)
+	}
+
+	cl.raw("ORG", structured(ptrString(contact.GetCompanyName()), ptrString(contact.GetDepartment())))
+	cl.text("TITLE", ptrString(contact.GetJobTitle()))
+	cl.text("ROLE", ptrString(contact.GetProfession()))
+	cl.text("URL", ptrString(contact.GetBusinessHomePage()))
+	cl.text("RELATED", ptrString(contact.GetSpouseName()), "TYPE=spouse", "VALUE=text")
+
+	for _, c := range contact.GetChildren() {
+		cl.text("RELATED", c, "TYPE=child", "VALUE=text")
+	}
+
+	cl.raw("CATEGORIES", textList(contact.GetCategories()))
+	cl.text("NOTE", ptrString(contact.GetPersonalNotes()))
+	cl.raw("REV", utcDateTime(contact.GetLastModifiedDateTime()))
+	cl.raw("END", "VCARD")
+
+	return cl.write(w)
+}
+
+// contactName produces the formatted name of the contact.
+func contactName(contact models.Contactable) string {
+	if n := ptrString(contact.GetDisplayName()); len(n) > 0 {
+		return n
+	}
+
+	n := strings.TrimSpace(ptrString(contact.GetGivenName()) + " " + ptrString(contact.GetSurname()))
+	if len(n) > 0 {
+		return n
+	}
+
+	if n := ptrString(contact.GetFileAs()); len(n) > 0 {
+		return n
+	}
+
+	for _, ea := range contact.GetEmailAddresses() {
+		if ea != nil && len(ptrString(ea.GetAddress())) > 0 {
+			return *ea.GetAddress()
+		}
+	}
+
+	return ""
+}
+
+// address produces the structured ADR value for a physical address:
+// po box; extended address; street; locality; region; postal code; country.
+func address(addr models.PhysicalAddressable) string {
+	if addr == nil {
+		return ""
+	}
+
+	return structured(
+		"",
+		"",
+		ptrString(addr.GetStreet()),
+		ptrString(addr.GetCity()),
+		ptrString(addr.GetState()),
+		ptrString(addr.GetPostalCode()),
+		ptrString(addr.GetCountryOrRegion()))
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/connector/mockconnector"
+)
+
+type VCardUnitSuite struct {
+	suite.Suite
+}
+
+func TestVCardUnitSuite(t *testing.T) {
+	suite.Run(t, new(VCardUnitSuite))
+}
+
+func (suite *VCardUnitSuite) TestContactToVCard() {
+	var (
+		t        = suite.T()
+		contact  = models.NewContact()
+		id       = "contact-id"
+		given    = "Alice"
+		surname  = "Smith"
+		company  = "Example, Inc."
+		mobile   = "555-0100"
+		street   = "1 Main St"
+		city     = "Springfield"
+		email    = "alice@example.com"
+		birthday = time.Date(1990, 4, 1, 0, 0, 0, 0, time.UTC)
+		addr     = models.NewPhysicalAddress()
+		ea       = models.NewEmailAddress()
+	)
+
+	contact.SetId(&id)
+	contact.SetGivenName(&given)
+	contact.SetSurname(&surname)
+	contact.SetCompanyName(&company)
+	contact.SetMobilePhone(&mobile)
+	contact.SetBusinessPhones([]string{"555-0101"})
+	contact.SetBirthday(&birthday)
+
+	addr.SetStreet(&street)
+	addr.SetCity(&city)
+	contact.SetHomeAddress(addr)
+
+	ea.SetAddress(&email)
+	contact.SetEmailAddresses([]models.EmailAddressable{ea})
+
+	var buf bytes.Buffer
+	require.NoError(t, contactToVCard(&buf, contact))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), crlf), crlf)
+
+	assert.Equal(t, []string{
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"UID;VALUE=text:contact-id",
+		"FN:Alice Smith",
+		"N:Smith;Alice;;;",
+		"BDAY:19900401",
+		"EMAIL:alice@example.com",
+		"TEL;TYPE=cell:555-0100",
+		"TEL;TYPE=work,voice:555-0101",
+		"ADR;TYPE=home:;;1 Main St;Springfield;;;",
+		`ORG:Example\, Inc.;`,
+		"END:VCARD",
+	}, lines)
+}
+
+func (suite *VCardUnitSuite) TestContactToVCardBytes() {
+	t := suite.T()
+
+	var buf bytes.Buffer
+	require.NoError(t, contactToVCardBytes(&buf, mockconnector.GetMockContactBytes("Middle")))
+
+	out := buf.String()
+	assert.Contains(t, out, "FN:Santiago Quail\r\n")
+	assert.Contains(t, out, "N:Quail;Santiago;Middle;;\r\n")
+	assert.Contains(t, out, "REV:20190804T065533Z\r\n")
+
+	assert.Error(t, contactToVCardBytes(&buf, []byte("not a contact")))
+}
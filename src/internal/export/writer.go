@@ -0,0 +1,214 @@
+// Package export writes backed up data to the local disk, either as a
+// directory tree or as a single archive file.
+package export
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+var errInvalidName = errors.New("invalid export file name")
+
+// Writer stores exported files.  Names are relative, slash-separated paths
+// from the root of the export.
+type Writer interface {
+	// Write stores size bytes from r in the file called name.
+	Write(name string, size int64, r io.Reader, modTime time.Time) (int64, error)
+	Close() error
+}
+
+// NewWriter produces a Writer for the destination.  Existing files are never
+// overwritten.
+func NewWriter(dest control.ExportDestination) (Writer, error) {
+	if len(dest.Path) == 0 {
+		return nil, errors.New("missing export destination path")
+	}
+
+	switch dest.Archive {
+	case control.NoArchive:
+		if err := os.MkdirAll(dest.Path, 0o700); err != nil {
+			return nil, errors.Wrap(err, "creating export directory")
+		}
+
+		return &dirWriter{root: dest.Path}, nil
+
+	case control.ZipArchive:
+		f, err := createFile(dest.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &zipWriter{f: f, zw: zip.NewWriter(f)}, nil
+
+	case control.TarArchive:
+		f, err := createFile(dest.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &tarWriter{f: f, tw: tar.NewWriter(f)}, nil
+
+	default:
+		return nil, errors.Errorf("unsupported archive format %q", dest.Archive)
+	}
+}
+
+// createFile makes a new file at p, along with any missing parent directories.
+func createFile(p string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return nil, errors.Wrap(err, "creating export directory")
+	}
+
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating export file")
+	}
+
+	return f, nil
+}
+
+// cleanName verifies that name stays within the root of the export.
+func cleanName(name string) (string, error) {
+	if len(name) == 0 || strings.HasPrefix(name, "/") {
+		return "", errors.Wrap(errInvalidName, name)
+	}
+
+	for _, e := range strings.Split(name, "/") {
+		if len(e) == 0 || e == "." || e == ".." || strings.ContainsRune(e, '\\') {
+			return "", errors.Wrap(errInvalidName, name)
+		}
+	}
+
+	return name, nil
+}
+
+// ---------------------------------------------------------------------------
+// directory
+// ---------------------------------------------------------------------------
+
+type dirWriter struct {
+	root string
+}
+
+func (dw *dirWriter) Write(name string, _ int64, r io.Reader, modTime time.Time) (int64, error) {
+	name, err := cleanName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	p := filepath.Join(dw.root, filepath.FromSlash(name))
+
+	f, err := createFile(p)
+	if err != nil {
+		return 0, errors.Wrap(err, name)
+	}
+
+	n, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return n, errors.Wrapf(err, "writing %s", name)
+	}
+
+	if err := f.Close(); err != nil {
+		return n, errors.Wrapf(err, "closing %s", name)
+	}
+
+	if !modTime.IsZero() {
+		if err := os.Chtimes(p, modTime, modTime); err != nil {
+			return n, errors.Wrapf(err, "setting modified time of %s", name)
+		}
+	}
+
+	return n, nil
+}
+
+func (dw *dirWriter) Close() error {
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// zip
+// ---------------------------------------------------------------------------
+
+type zipWriter struct {
+	f  *os.File
+	zw *zip.Writer
+}
+
+func (zw *zipWriter) Write(name string, _ int64, r io.Reader, modTime time.Time) (int64, error) {
+	name, err := cleanName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	w, err := zw.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modTime,
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "adding %s to archive", name)
+	}
+
+	n, err := io.Copy(w, r)
+
+	return n, errors.Wrapf(err, "writing %s", name)
+}
+
+func (zw *zipWriter) Close() error {
+	if err := zw.zw.Close(); err != nil {
+		zw.f.Close()
+		return errors.Wrap(err, "closing zip archive")
+	}
+
+	return errors.Wrap(zw.f.Close(), "closing export file")
+}
+
+// ---------------------------------------------------------------------------
+// tar
+// ---------------------------------------------------------------------------
+
+type tarWriter struct {
+	f  *os.File
+	tw *tar.Writer
+}
+
+func (tw *tarWriter) Write(name string, size int64, r io.Reader, modTime time.Time) (int64, error) {
+	name, err := cleanName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tw.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o600,
+		ModTime:  modTime,
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "adding %s to archive", name)
+	}
+
+	n, err := io.Copy(tw.tw, r)
+
+	return n, errors.Wrapf(err, "writing %s", name)
+}
+
+func (tw *tarWriter) Close() error {
+	if err := tw.tw.Close(); err != nil {
+		tw.f.Close()
+		return errors.Wrap(err, "closing tar archive")
+	}
+
+	return errors.Wrap(tw.f.Close(), "closing export file")
+}
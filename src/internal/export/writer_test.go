@@ -0,0 +1,207 @@
+package export
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type WriterUnitSuite struct {
+	suite.Suite
+}
+
+func TestWriterUnitSuite(t *testing.T) {
+	suite.Run(t, new(WriterUnitSuite))
+}
+
+var (
+	testModTime = time.Date(2022, 10, 5, 12, 30, 0, 0, time.UTC)
+	testFiles   = map[string]string{
+		"user/a.txt":     "a contents",
+		"user/b/c/d.txt": "d contents",
+	}
+)
+
+func writeTestFiles(t *testing.T, w Writer) {
+	for name, content := range testFiles {
+		n, err := w.Write(name, int64(len(content)), bytes.NewBufferString(content), testModTime)
+		require.NoError(t, err, name)
+		assert.Equal(t, int64(len(content)), n, name)
+	}
+
+	require.NoError(t, w.Close())
+}
+
+func (suite *WriterUnitSuite) TestDirWriter() {
+	t := suite.T()
+	root := filepath.Join(t.TempDir(), "export")
+
+	w, err := NewWriter(control.ExportDestination{Path: root})
+	require.NoError(t, err)
+
+	writeTestFiles(t, w)
+
+	for name, content := range testFiles {
+		p := filepath.Join(root, filepath.FromSlash(name))
+
+		bs, err := os.ReadFile(p)
+		require.NoError(t, err, name)
+		assert.Equal(t, content, string(bs), name)
+
+		fi, err := os.Stat(p)
+		require.NoError(t, err, name)
+		assert.True(t, testModTime.Equal(fi.ModTime()), name)
+	}
+}
+
+func (suite *WriterUnitSuite) TestDirWriter_noOverwrite() {
+	t := suite.T()
+	root := t.TempDir()
+
+	w, err := NewWriter(control.ExportDestination{Path: root})
+	require.NoError(t, err)
+
+	_, err = w.Write("a", 1, bytes.NewBufferString("a"), testModTime)
+	require.NoError(t, err)
+
+	_, err = w.Write("a", 1, bytes.NewBufferString("b"), testModTime)
+	assert.Error(t, err)
+}
+
+func (suite *WriterUnitSuite) TestZipWriter() {
+	t := suite.T()
+	p := filepath.Join(t.TempDir(), "export.zip")
+
+	w, err := NewWriter(control.ExportDestination{Path: p, Archive: control.ZipArchive})
+	require.NoError(t, err)
+
+	writeTestFiles(t, w)
+
+	zr, err := zip.OpenReader(p)
+	require.NoError(t, err)
+
+	defer zr.Close()
+
+	require.Len(t, zr.File, len(testFiles))
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err, f.Name)
+
+		bs, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err, f.Name)
+
+		assert.Equal(t, testFiles[f.Name], string(bs), f.Name)
+	}
+}
+
+func (suite *WriterUnitSuite) TestTarWriter() {
+	t := suite.T()
+	p := filepath.Join(t.TempDir(), "export.tar")
+
+	w, err := NewWriter(control.ExportDestination{Path: p, Archive: control.TarArchive})
+	require.NoError(t, err)
+
+	writeTestFiles(t, w)
+
+	f, err := os.Open(p)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	var (
+		tr    = tar.NewReader(f)
+		found int
+	)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+
+		bs, err := io.ReadAll(tr)
+		require.NoError(t, err, hdr.Name)
+
+		assert.Equal(t, testFiles[hdr.Name], string(bs), hdr.Name)
+		assert.True(t, testModTime.Equal(hdr.ModTime), hdr.Name)
+
+		found++
+	}
+
+	assert.Equal(t, len(testFiles), found)
+}
+
+func (suite *WriterUnitSuite) TestNewWriter_errors() {
+	table := []struct {
+		name string
+		dest func(t *testing.T) control.ExportDestination
+	}{
+		{
+			name: "no path",
+			dest: func(t *testing.T) control.ExportDestination {
+				return control.ExportDestination{}
+			},
+		},
+		{
+			name: "unknown archive",
+			dest: func(t *testing.T) control.ExportDestination {
+				return control.ExportDestination{Path: t.TempDir(), Archive: "rar"}
+			},
+		},
+		{
+			name: "archive exists",
+			dest: func(t *testing.T) control.ExportDestination {
+				p := filepath.Join(t.TempDir(), "export.zip")
+				require.NoError(t, os.WriteFile(p, []byte("zip"), 0o600))
+
+				return control.ExportDestination{Path: p, Archive: control.ZipArchive}
+			},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			_, err := NewWriter(test.dest(t))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func (suite *WriterUnitSuite) TestCleanName() {
+	table := []struct {
+		name      string
+		input     string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{"simple", "a", assert.NoError},
+		{"nested", "a/b/c", assert.NoError},
+		{"dotted name", "a/.b", assert.NoError},
+		{"empty", "", assert.Error},
+		{"absolute", "/a/b", assert.Error},
+		{"empty element", "a//b", assert.Error},
+		{"trailing slash", "a/b/", assert.Error},
+		{"current dir", "a/./b", assert.Error},
+		{"parent dir", "a/../../b", assert.Error},
+		{"backslash", `a/..\b`, assert.Error},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			_, err := cleanName(test.input)
+			test.expectErr(t, err)
+		})
+	}
+}
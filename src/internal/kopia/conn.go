@@ -18,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/alcionai/corso/src/internal/common"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/storage"
 )
 
@@ -84,7 +85,12 @@ func NewConn(s storage.Storage) *conn {
 	}
 }
 
-func (w *conn) Initialize(ctx context.Context) error {
+func (w *conn) Initialize(ctx context.Context, ol control.ObjectLock) error {
+	opts, err := newRepoOptions(ol)
+	if err != nil {
+		return errors.Wrap(err, errInit.Error())
+	}
+
 	bst, err := blobStoreByProvider(ctx, w.storage)
 	if err != nil {
 		return errors.Wrap(err, errInit.Error())
@@ -96,8 +102,7 @@ func (w *conn) Initialize(ctx context.Context) error {
 		return err
 	}
 
-	// todo - issue #75: nil here should be a storage.NewRepoOptions()
-	if err = repo.Initialize(ctx, bst, nil, cfg.CorsoPassphrase); err != nil {
+	if err = repo.Initialize(ctx, bst, opts, cfg.CorsoPassphrase); err != nil {
 		if errors.Is(err, repo.ErrAlreadyInitialized) {
 			return RepoAlreadyExistsError(err)
 		}
@@ -114,6 +119,32 @@ func (w *conn) Initialize(ctx context.Context) error {
 	)
 }
 
+// newRepoOptions produces the kopia options for initializing a repository
+// with the object lock settings.  Object locks apply to every blob kopia
+// writes, for the retention period following the write.
+func newRepoOptions(ol control.ObjectLock) (*repo.NewRepositoryOptions, error) {
+	if err := ol.Validate(); err != nil {
+		return nil, err
+	}
+
+	opts := &repo.NewRepositoryOptions{}
+
+	if !ol.Enabled() {
+		return opts, nil
+	}
+
+	switch ol.Mode {
+	case control.ObjectLockGovernance:
+		opts.RetentionMode = blob.Governance
+	case control.ObjectLockCompliance:
+		opts.RetentionMode = blob.Compliance
+	}
+
+	opts.RetentionPeriod = ol.Retention
+
+	return opts, nil
+}
+
 func (w *conn) Connect(ctx context.Context) error {
 	bst, err := blobStoreByProvider(ctx, w.storage)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kopia/kopia/repo/blob"
 	"github.com/kopia/kopia/snapshot"
 	"github.com/kopia/kopia/snapshot/policy"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/storage"
 )
 
@@ -22,7 +24,7 @@ func openKopiaRepo(t *testing.T, ctx context.Context) (*conn, error) {
 	st := tester.NewPrefixedS3Storage(t)
 
 	k := NewConn(st)
-	if err := k.Initialize(ctx); err != nil {
+	if err := k.Initialize(ctx, control.ObjectLock{}); err != nil {
 		return nil, err
 	}
 
@@ -51,6 +53,68 @@ func (suite *WrapperUnitSuite) TestCloseWithoutOpenDoesNotCrash() {
 	})
 }
 
+func (suite *WrapperUnitSuite) TestNewRepoOptions() {
+	table := []struct {
+		name         string
+		ol           control.ObjectLock
+		expectMode   blob.RetentionMode
+		expectPeriod time.Duration
+		expectErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "disabled",
+			expectErr: assert.NoError,
+		},
+		{
+			name:         "governance",
+			ol:           control.ObjectLock{Mode: control.ObjectLockGovernance, Retention: 48 * time.Hour},
+			expectMode:   blob.Governance,
+			expectPeriod: 48 * time.Hour,
+			expectErr:    assert.NoError,
+		},
+		{
+			name:         "compliance",
+			ol:           control.ObjectLock{Mode: control.ObjectLockCompliance, Retention: 720 * time.Hour},
+			expectMode:   blob.Compliance,
+			expectPeriod: 720 * time.Hour,
+			expectErr:    assert.NoError,
+		},
+		{
+			name:      "missing mode",
+			ol:        control.ObjectLock{Retention: 48 * time.Hour},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "unknown mode",
+			ol:        control.ObjectLock{Mode: "legal", Retention: 48 * time.Hour},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing retention",
+			ol:        control.ObjectLock{Mode: control.ObjectLockGovernance},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "short retention",
+			ol:        control.ObjectLock{Mode: control.ObjectLockGovernance, Retention: time.Hour},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			opts, err := newRepoOptions(test.ol)
+			test.expectErr(t, err)
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expectMode, opts.RetentionMode)
+			assert.Equal(t, test.expectPeriod, opts.RetentionPeriod)
+		})
+	}
+}
+
 // ---------------
 // integration tests that use kopia
 // ---------------
@@ -82,11 +146,11 @@ func (suite *WrapperIntegrationSuite) TestRepoExistsError() {
 
 	st := tester.NewPrefixedS3Storage(t)
 	k := NewConn(st)
-	require.NoError(t, k.Initialize(ctx))
+	require.NoError(t, k.Initialize(ctx, control.ObjectLock{}))
 
 	require.NoError(t, k.Close(ctx))
 
-	err := k.Initialize(ctx)
+	err := k.Initialize(ctx, control.ObjectLock{})
 	assert.Error(t, err)
 	assert.True(t, IsRepoAlreadyExistsError(err))
 }
@@ -101,7 +165,7 @@ func (suite *WrapperIntegrationSuite) TestBadProviderErrors() {
 	st.Provider = storage.ProviderUnknown
 
 	k := NewConn(st)
-	assert.Error(t, k.Initialize(ctx))
+	assert.Error(t, k.Initialize(ctx, control.ObjectLock{}))
 }
 
 func (suite *WrapperIntegrationSuite) TestConnectWithoutInitErrors() {
@@ -15,6 +15,7 @@ import (
 	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/control"
 )
 
 type fooModel struct {
@@ -798,7 +799,7 @@ func openConnAndModelStore(
 	st := tester.NewPrefixedS3Storage(t)
 	c := NewConn(st)
 
-	require.NoError(t, c.Initialize(ctx))
+	require.NoError(t, c.Initialize(ctx, control.ObjectLock{}))
 
 	defer func() {
 		require.NoError(t, c.Close(ctx))
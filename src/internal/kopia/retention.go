@@ -0,0 +1,126 @@
+package kopia
+
+import (
+	"context"
+	"time"
+
+	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/repo/blob"
+	"github.com/kopia/kopia/repo/content"
+	"github.com/kopia/kopia/repo/format"
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+)
+
+// lockedBlobPrefixes are the prefixes of the blobs that kopia writes with the
+// repository's object lock retention: the pack and index blobs, and the
+// repository's format and blob configs.
+var lockedBlobPrefixes = append(
+	append([]blob.ID{}, content.PackBlobIDPrefixes...),
+	content.LegacyIndexBlobPrefix,
+	// kopia's epoch manager index prefix, which kopia doesn't export.
+	"x",
+	format.KopiaRepositoryBlobID,
+	format.KopiaBlobCfgBlobID,
+)
+
+type blobLister interface {
+	ListBlobs(ctx context.Context, prefix blob.ID, cb func(bm blob.Metadata) error) error
+}
+
+type retentionSetter interface {
+	PutObjectRetention(ctx context.Context, bucket, object string, opts minio.PutObjectRetentionOptions) error
+}
+
+// ExtendRetention locks each of the repository's locked blobs for another
+// full retention period, starting now.  Blobs are locked for the retention
+// period following their write, so blobs still in use by backups would
+// otherwise become deletable once that period ends.  Must be run more often
+// than the retention period.  Returns the number of blobs whose retention was
+// extended.  Repositories without object lock are left unchanged.
+// Every pack blob is extended, including those that only deleted backups
+// use, since corso doesn't track which blobs live snapshots reference.
+// This costs one request per blob, and keeps deleted data locked.
+func (w *conn) ExtendRetention(ctx context.Context) (int, error) {
+	dr, ok := w.Repository.(repo.DirectRepository)
+	if !ok {
+		return 0, errors.New("extending retention requires a connected repository")
+	}
+
+	bcfg, err := dr.FormatManager().BlobCfgBlob()
+	if err != nil {
+		return 0, errors.Wrap(err, "reading blob config")
+	}
+
+	if !bcfg.IsRetentionEnabled() {
+		return 0, nil
+	}
+
+	cfg, err := w.storage.S3Config()
+	if err != nil {
+		return 0, err
+	}
+
+	cli, err := s3Client(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	return extendRetention(
+		ctx,
+		dr.BlobReader(),
+		cli,
+		cfg.Bucket,
+		cfg.Prefix,
+		bcfg.RetentionMode,
+		time.Now().Add(bcfg.RetentionPeriod))
+}
+
+// extendRetention locks each of the blobs with the lockedBlobPrefixes until
+// the given time.
+func extendRetention(
+	ctx context.Context,
+	bl blobLister,
+	rs retentionSetter,
+	bucket, prefix string,
+	mode blob.RetentionMode,
+	until time.Time,
+) (int, error) {
+	var (
+		m     = minio.RetentionMode(mode)
+		utc   = until.UTC()
+		count int
+	)
+
+	for _, p := range lockedBlobPrefixes {
+		err := bl.ListBlobs(ctx, p, func(bm blob.Metadata) error {
+			err := rs.PutObjectRetention(
+				ctx,
+				bucket,
+				prefix+string(bm.BlobID),
+				minio.PutObjectRetentionOptions{Mode: &m, RetainUntilDate: &utc})
+			if err != nil {
+				return errors.Wrapf(err, "extending retention of blob %s", bm.BlobID)
+			}
+
+			count++
+
+			return nil
+		})
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+// ExtendRetention locks the repository's data for another full retention
+// period.  See conn.ExtendRetention.
+func (w *Wrapper) ExtendRetention(ctx context.Context) (int, error) {
+	if w.c == nil {
+		return 0, errors.WithStack(errNotConnected)
+	}
+
+	return w.c.ExtendRetention(ctx)
+}
@@ -0,0 +1,211 @@
+package kopia
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/repo/blob"
+	"github.com/kopia/kopia/repo/format"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type mockBlobLister struct {
+	blobs []blob.ID
+}
+
+func (bl mockBlobLister) ListBlobs(ctx context.Context, prefix blob.ID, cb func(bm blob.Metadata) error) error {
+	for _, id := range bl.blobs {
+		if !strings.HasPrefix(string(id), string(prefix)) {
+			continue
+		}
+
+		if err := cb(blob.Metadata{BlobID: id}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type mockRetentionSetter struct {
+	objects map[string]minio.PutObjectRetentionOptions
+	err     error
+}
+
+func (rs *mockRetentionSetter) PutObjectRetention(
+	ctx context.Context,
+	bucket, object string,
+	opts minio.PutObjectRetentionOptions,
+) error {
+	if rs.err != nil {
+		return rs.err
+	}
+
+	rs.objects[bucket+"/"+object] = opts
+
+	return nil
+}
+
+type RetentionUnitSuite struct {
+	suite.Suite
+}
+
+func TestRetentionUnitSuite(t *testing.T) {
+	suite.Run(t, new(RetentionUnitSuite))
+}
+
+func (suite *RetentionUnitSuite) TestExtendRetention() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	var (
+		t     = suite.T()
+		until = time.Date(2022, 11, 4, 0, 0, 0, 0, time.UTC)
+		bl    = mockBlobLister{blobs: []blob.ID{
+			"kopia.repository",
+			"kopia.blobcfg",
+			"p0123",
+			"q4567",
+			"xn0_abc",
+			"_log_123",
+		}}
+		rs = &mockRetentionSetter{objects: map[string]minio.PutObjectRetentionOptions{}}
+	)
+
+	n, err := extendRetention(ctx, bl, rs, "bucket", "prefix/", blob.Compliance, until)
+	require.NoError(t, err)
+
+	// logs aren't locked by kopia, so their retention isn't extended
+	assert.Equal(t, 5, n)
+	assert.Len(t, rs.objects, 5)
+	assert.NotContains(t, rs.objects, "bucket/prefix/_log_123")
+
+	opts, ok := rs.objects["bucket/prefix/p0123"]
+	require.True(t, ok)
+	require.NotNil(t, opts.Mode)
+	assert.Equal(t, minio.Compliance, *opts.Mode)
+	require.NotNil(t, opts.RetainUntilDate)
+	assert.Equal(t, until, *opts.RetainUntilDate)
+}
+
+func (suite *RetentionUnitSuite) TestExtendRetention_error() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	var (
+		t  = suite.T()
+		bl = mockBlobLister{blobs: []blob.ID{"p0123"}}
+		rs = &mockRetentionSetter{err: assert.AnError}
+	)
+
+	n, err := extendRetention(ctx, bl, rs, "bucket", "", blob.Governance, time.Now())
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Zero(t, n)
+}
+
+// ---------------
+// integration tests that use a MinIO bucket with object lock enabled
+// ---------------
+type RetentionIntegrationSuite struct {
+	suite.Suite
+}
+
+func TestRetentionIntegrationSuite(t *testing.T) {
+	if err := tester.RunOnAny(tester.CorsoObjectLockTests); err != nil {
+		t.Skip(err)
+	}
+
+	suite.Run(t, new(RetentionIntegrationSuite))
+}
+
+func (suite *RetentionIntegrationSuite) SetupSuite() {
+	_, err := tester.GetRequiredEnvVars(tester.AWSStorageCredEnvs...)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *RetentionIntegrationSuite) TestObjectLock() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	var (
+		t  = suite.T()
+		st = tester.NewObjectLockS3Storage(t)
+		k  = NewConn(st)
+		ol = control.ObjectLock{Mode: control.ObjectLockGovernance, Retention: 24 * time.Hour}
+	)
+
+	require.NoError(t, k.Initialize(ctx, ol))
+
+	defer k.Close(ctx)
+
+	cfg, err := st.S3Config()
+	require.NoError(t, err)
+
+	cli, err := s3Client(cfg)
+	require.NoError(t, err)
+
+	dr, ok := k.Repository.(repo.DirectRepository)
+	require.True(t, ok)
+
+	object := cfg.Prefix + format.KopiaRepositoryBlobID
+
+	info, err := cli.StatObject(ctx, cfg.Bucket, object, minio.StatObjectOptions{})
+	require.NoError(t, err)
+
+	mode, before, err := cli.GetObjectRetention(ctx, cfg.Bucket, object, info.VersionID)
+	require.NoError(t, err)
+	require.NotNil(t, mode)
+	require.NotNil(t, before)
+	assert.Equal(t, minio.Governance, *mode)
+
+	// the locked version can't be deleted, even with the repo's credentials
+	err = cli.RemoveObject(ctx, cfg.Bucket, object, minio.RemoveObjectOptions{VersionID: info.VersionID})
+	assert.Error(t, err)
+
+	// retention is set in whole seconds
+	time.Sleep(time.Second)
+
+	n, err := k.ExtendRetention(ctx)
+	require.NoError(t, err)
+
+	var blobs int
+
+	for _, p := range lockedBlobPrefixes {
+		require.NoError(t, dr.BlobReader().ListBlobs(ctx, p, func(blob.Metadata) error {
+			blobs++
+			return nil
+		}))
+	}
+
+	assert.Equal(t, blobs, n)
+
+	_, after, err := cli.GetObjectRetention(ctx, cfg.Bucket, object, info.VersionID)
+	require.NoError(t, err)
+	require.NotNil(t, after)
+	assert.True(t, after.After(*before), "retention extended from %s to %s", before, after)
+}
+
+func (suite *RetentionIntegrationSuite) TestExtendRetention_unlocked() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	t := suite.T()
+
+	k := NewConn(tester.NewObjectLockS3Storage(t))
+	require.NoError(t, k.Initialize(ctx, control.ObjectLock{}))
+
+	defer k.Close(ctx)
+
+	n, err := k.ExtendRetention(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
@@ -2,9 +2,14 @@ package kopia
 
 import (
 	"context"
+	"crypto/tls"
+	"net/http"
 
 	"github.com/kopia/kopia/repo/blob"
 	"github.com/kopia/kopia/repo/blob/s3"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/errors"
 
 	"github.com/alcionai/corso/src/pkg/storage"
 )
@@ -34,3 +39,30 @@ func s3BlobStorage(ctx context.Context, s storage.Storage) (blob.Storage, error)
 
 	return s3.New(ctx, &opts)
 }
+
+// s3Client creates a client for the repository's bucket, for the object lock
+// calls kopia doesn't support.  Credentials are found the same way kopia finds
+// them for the repository's blob storage.
+func s3Client(cfg storage.S3Config) (*minio.Client, error) {
+	endpoint := defaultS3Endpoint
+	if len(cfg.Endpoint) > 0 {
+		endpoint = cfg.Endpoint
+	}
+
+	opts := &minio.Options{
+		Creds: credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+		}),
+		Secure: !cfg.DoNotUseTLS,
+	}
+
+	if cfg.DoNotVerifyTLS {
+		//nolint:gosec
+		opts.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+
+	cli, err := minio.New(endpoint, opts)
+
+	return cli, errors.Wrap(err, "creating s3 client")
+}
@@ -3,7 +3,10 @@ package kopia
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
+	"hash"
 	"io"
 	"os"
 	"runtime/trace"
@@ -29,14 +32,20 @@ const maxInflateTraversalDepth = 500
 
 var versionSize = int(unsafe.Sizeof(serializationVersion))
 
+// ErrContentHashMismatch is returned when reading an item whose content
+// doesn't match the hash recorded when it was backed up.
+var ErrContentHashMismatch = errors.New("item content does not match its backed up hash")
+
 func newBackupStreamReader(version uint32, reader io.ReadCloser) *backupStreamReader {
 	buf := make([]byte, versionSize)
 	binary.BigEndian.PutUint32(buf, version)
 	bufReader := io.NopCloser(bytes.NewReader(buf))
+	hasher := sha256.New()
 
 	return &backupStreamReader{
 		readers:  []io.ReadCloser{bufReader, reader},
-		combined: io.NopCloser(io.MultiReader(bufReader, reader)),
+		combined: io.NopCloser(io.MultiReader(bufReader, io.TeeReader(reader, hasher))),
+		hasher:   hasher,
 	}
 }
 
@@ -44,10 +53,18 @@ func newBackupStreamReader(version uint32, reader io.ReadCloser) *backupStreamRe
 // components return when backing up information. It injects a version number at
 // the start of the data stream. Future versions of Corso may not need this if
 // they use more complex serialization logic as serialization/version injection
-// will be handled by other components.
+// will be handled by other components.  It also hashes the item's content, not
+// including the version number, as the content is read.
 type backupStreamReader struct {
 	readers  []io.ReadCloser
 	combined io.ReadCloser
+	hasher   hash.Hash
+}
+
+// ContentHash returns the hex-encoded SHA-256 hash of the item content read
+// so far.  Only complete once the reader has been read to the end.
+func (rw *backupStreamReader) ContentHash() string {
+	return hex.EncodeToString(rw.hasher.Sum(nil))
 }
 
 func (rw *backupStreamReader) Read(p []byte) (n int, err error) {
@@ -117,9 +134,34 @@ func (rw *restoreStreamReader) Read(p []byte) (n int, err error) {
 	return rw.ReadCloser.Read(p)
 }
 
+// verifyingReader is a wrapper around the reader of a restored item that
+// hashes the item's content as it's read. Reaching the end of the content
+// returns ErrContentHashMismatch if the hash doesn't match the one recorded
+// when the item was backed up.
+type verifyingReader struct {
+	io.ReadCloser
+	hasher       hash.Hash
+	expectedHash string
+	itemPath     string
+}
+
+func (rw *verifyingReader) Read(p []byte) (n int, err error) {
+	n, err = rw.ReadCloser.Read(p)
+	rw.hasher.Write(p[:n])
+
+	if err == io.EOF && hex.EncodeToString(rw.hasher.Sum(nil)) != rw.expectedHash {
+		return n, errors.Wrapf(ErrContentHashMismatch, "item %q", rw.itemPath)
+	}
+
+	return n, err
+}
+
 type itemDetails struct {
 	info     details.ItemInfo
 	repoPath path.Path
+	// reader is set for items streamed in this backup, so the hash of their
+	// content can be recorded once they're uploaded.
+	reader *backupStreamReader
 }
 
 type corsoProgress struct {
@@ -155,10 +197,16 @@ func (cp *corsoProgress) FinishedFile(relativePath string, err error) {
 
 	parent := d.repoPath.ToBuilder().Dir()
 
-	cp.deets.Add(
+	var contentHash string
+	if d.reader != nil {
+		contentHash = d.reader.ContentHash()
+	}
+
+	cp.deets.AddItem(
 		d.repoPath.String(),
 		d.repoPath.ShortRef(),
 		parent.ShortRef(),
+		contentHash,
 		true,
 		d.info,
 	)
@@ -277,12 +325,14 @@ func collectionEntries(
 			// used for restore. If progress does not contain information about a
 			// finished file it just returns without an error so it's safe to skip
 			// adding something to it.
+			reader := newBackupStreamReader(serializationVersion, e.ToReader())
+
 			ei, ok := e.(data.StreamInfo)
 			if ok {
 				// Relative path given to us in the callback is missing the root
 				// element. Add to pending set before calling the callback to avoid race
 				// conditions when the item is completed.
-				d := &itemDetails{info: ei.Info(), repoPath: itemPath}
+				d := &itemDetails{info: ei.Info(), repoPath: itemPath, reader: reader}
 				progress.put(encodeAsPath(itemPath.PopFront().Elements()...), d)
 			}
 
@@ -294,7 +344,7 @@ func collectionEntries(
 			entry := virtualfs.StreamingFileWithModTimeFromReader(
 				encodedName,
 				modTime,
-				newBackupStreamReader(serializationVersion, e.ToReader()),
+				reader,
 			)
 			if err := cb(ctx, entry); err != nil {
 				// Kopia's uploader swallows errors in most cases, so if we see
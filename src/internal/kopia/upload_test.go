@@ -3,6 +3,8 @@ package kopia
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	stdpath "path"
 	"testing"
@@ -345,6 +347,55 @@ func (suite *VersionReadersUnitSuite) TestWriteHandlesShortReads() {
 	assert.Equal(t, inputData, readData)
 }
 
+func (suite *VersionReadersUnitSuite) TestContentHash() {
+	inputData := []byte("This is some data for the reader to test with")
+	sum := sha256.Sum256(inputData)
+	expectedHash := hex.EncodeToString(sum[:])
+
+	table := []struct {
+		name  string
+		hash  string
+		check assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "MatchingHashSucceeds",
+			hash:  expectedHash,
+			check: assert.NoError,
+		},
+		{
+			name: "MismatchedHashFails",
+			hash: "deadbeef",
+			check: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrContentHashMismatch)
+			},
+		},
+	}
+
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			versioner := newBackupStreamReader(42, io.NopCloser(bytes.NewReader(inputData)))
+
+			versionedData, err := io.ReadAll(versioner)
+			require.NoError(t, err)
+			assert.Equal(t, expectedHash, versioner.ContentHash())
+
+			reader := &verifyingReader{
+				ReadCloser: &restoreStreamReader{
+					expectedVersion: 42,
+					ReadCloser:      io.NopCloser(bytes.NewReader(versionedData)),
+				},
+				hasher:       sha256.New(),
+				expectedHash: test.hash,
+				itemPath:     "item",
+			}
+
+			readData, err := io.ReadAll(reader)
+			test.check(t, err)
+			assert.Equal(t, inputData, readData)
+		})
+	}
+}
+
 type CorsoProgressUnitSuite struct {
 	suite.Suite
 	targetFilePath path.Path
@@ -389,7 +440,7 @@ var finishedFileTable = []struct {
 		cachedItems: func(fname string, fpath path.Path) map[string]testInfo {
 			return map[string]testInfo{
 				fname: {
-					info:       &itemDetails{details.ItemInfo{}, fpath},
+					info:       &itemDetails{info: details.ItemInfo{}, repoPath: fpath},
 					err:        nil,
 					totalBytes: 100,
 				},
@@ -416,7 +467,7 @@ var finishedFileTable = []struct {
 		cachedItems: func(fname string, fpath path.Path) map[string]testInfo {
 			return map[string]testInfo{
 				fname: {
-					info: &itemDetails{details.ItemInfo{}, fpath},
+					info: &itemDetails{info: details.ItemInfo{}, repoPath: fpath},
 					err:  assert.AnError,
 				},
 			}
@@ -460,6 +511,35 @@ func (suite *CorsoProgressUnitSuite) TestFinishedFile() {
 	}
 }
 
+func (suite *CorsoProgressUnitSuite) TestFinishedFileRecordsContentHash() {
+	t := suite.T()
+	inputData := []byte("This is some data for the reader to test with")
+	sum := sha256.Sum256(inputData)
+
+	bd := &details.Details{}
+	cp := corsoProgress{
+		UploadProgress: &snapshotfs.NullUploadProgress{},
+		deets:          bd,
+		pending:        map[string]*itemDetails{},
+	}
+
+	reader := newBackupStreamReader(serializationVersion, io.NopCloser(bytes.NewReader(inputData)))
+	_, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	cp.put(suite.targetFileName, &itemDetails{
+		info:     details.ItemInfo{},
+		repoPath: suite.targetFilePath,
+		reader:   reader,
+	})
+	cp.FinishedFile(suite.targetFileName, nil)
+
+	assert.Equal(
+		t,
+		map[string]string{suite.targetFilePath.String(): hex.EncodeToString(sum[:])},
+		bd.ContentHashes())
+}
+
 func (suite *CorsoProgressUnitSuite) TestFinishedFileBuildsHierarchy() {
 	t := suite.T()
 	// Order of folders in hierarchy from root to leaf (excluding the item).
@@ -473,7 +553,7 @@ func (suite *CorsoProgressUnitSuite) TestFinishedFileBuildsHierarchy() {
 		pending:        map[string]*itemDetails{},
 	}
 
-	deets := &itemDetails{details.ItemInfo{}, suite.targetFilePath}
+	deets := &itemDetails{info: details.ItemInfo{}, repoPath: suite.targetFilePath}
 	cp.put(suite.targetFileName, deets)
 	require.Len(t, cp.pending, 1)
 
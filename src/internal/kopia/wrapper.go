@@ -2,7 +2,9 @@ package kopia
 
 import (
 	"context"
+	"crypto/sha256"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/kopia/kopia/fs"
@@ -30,6 +32,16 @@ const (
 	serializationVersion uint32 = 1
 )
 
+// checkpointInterval is how often an in-progress backup is saved as an
+// incomplete snapshot.  The next backup hands the newest checkpoint to kopia
+// as a previous snapshot, which only saves kopia from hashing the content
+// an interrupted backup already stored; content dedup alone would already
+// keep it from being uploaded twice.
+// Checkpoints don't hold the backup's metadata or details, and resuming
+// doesn't reuse either: the next backup still reads every item from M365,
+// and rebuilds the details of every item.
+var checkpointInterval = 15 * time.Minute
+
 // common manifest tags
 const (
 	TagBackupID       = "backup-id"
@@ -114,7 +126,8 @@ type IncrementalBase struct {
 // backups and should represent the base snapshot from which metadata is sourced
 // from as well as any incomplete snapshot checkpoints that may contain more
 // recent data than the base snapshot. The absence of previousSnapshots causes a
-// complete backup of all data.
+// complete backup of all data. The details of the backed up items are added to
+// deets, which is returned. If deets is nil, new details are returned instead.
 func (w Wrapper) BackupCollections(
 	ctx context.Context,
 	previousSnapshots []IncrementalBase,
@@ -122,6 +135,7 @@ func (w Wrapper) BackupCollections(
 	service path.ServiceType,
 	oc *OwnersCats,
 	tags map[string]string,
+	deets *details.Details,
 ) (*BackupStats, *details.Details, error) {
 	if w.c == nil {
 		return nil, nil, errNotConnected
@@ -130,13 +144,17 @@ func (w Wrapper) BackupCollections(
 	ctx, end := D.Span(ctx, "kopia:backupCollections")
 	defer end()
 
+	if deets == nil {
+		deets = &details.Details{}
+	}
+
 	if len(collections) == 0 {
-		return &BackupStats{}, &details.Details{}, nil
+		return &BackupStats{}, deets, nil
 	}
 
 	progress := &corsoProgress{
 		pending: map[string]*itemDetails{},
-		deets:   &details.Details{},
+		deets:   deets,
 	}
 
 	// TODO(ashmrtn): Pass previousSnapshots here to enable building the directory
@@ -179,6 +197,19 @@ func (w Wrapper) makeSnapshotWithRoot(
 		prevSnaps = append(prevSnaps, ent.Manifest)
 	}
 
+	// Checkpoints get the same tags as the final snapshot so that they're
+	// found when looking up the bases for the next backup.
+	tags := tagsFromStrings(oc)
+	for k, v := range addlTags {
+		mk, mv := MakeTagKV(k)
+
+		if len(v) == 0 {
+			v = mv
+		}
+
+		tags[mk] = v
+	}
+
 	err := repo.WriteSession(
 		ctx,
 		w.c,
@@ -211,8 +242,11 @@ func (w Wrapper) makeSnapshotWithRoot(
 				return err
 			}
 
+			ct := &checkpointTagger{RepositoryWriter: rw, tags: tags}
+
 			// By default Uploader is best-attempt.
-			u := snapshotfs.NewUploader(rw)
+			u := snapshotfs.NewUploader(ct)
+			u.CheckpointInterval = checkpointInterval
 			progress.UploadProgress = u.Progress
 			u.Progress = progress
 
@@ -223,16 +257,7 @@ func (w Wrapper) makeSnapshotWithRoot(
 				return err
 			}
 
-			man.Tags = tagsFromStrings(oc)
-			for k, v := range addlTags {
-				mk, mv := MakeTagKV(k)
-
-				if len(v) == 0 {
-					v = mv
-				}
-
-				man.Tags[mk] = v
-			}
+			man.Tags = tags
 
 			if _, err := snapshot.SaveSnapshot(innerCtx, rw, man); err != nil {
 				err = errors.Wrap(err, "saving snapshot")
@@ -240,6 +265,12 @@ func (w Wrapper) makeSnapshotWithRoot(
 				return err
 			}
 
+			// A complete snapshot supersedes the checkpoints of this backup,
+			// and those of the interrupted backups it resumed.
+			if len(man.IncompleteReason) == 0 {
+				deleteCheckpoints(innerCtx, rw, append(ct.ids, incompleteIDs(prevSnaps)...))
+			}
+
 			return nil
 		},
 	)
@@ -254,6 +285,67 @@ func (w Wrapper) makeSnapshotWithRoot(
 	return &res, nil
 }
 
+// checkpointTagger adds tags to the checkpoint snapshots the kopia uploader
+// saves while a backup is in progress, and records their IDs.  Kopia doesn't
+// tag checkpoints on its own, which would leave them invisible to
+// FetchPrevSnapshotManifests.  Kopia saves one checkpoint at a time.
+type checkpointTagger struct {
+	repo.RepositoryWriter
+	tags map[string]string
+	ids  []manifest.ID
+}
+
+func (ct *checkpointTagger) PutManifest(
+	ctx context.Context,
+	labels map[string]string,
+	payload any,
+) (manifest.ID, error) {
+	man, ok := payload.(*snapshot.Manifest)
+	isCheckpoint := ok && man.IncompleteReason == snapshotfs.IncompleteReasonCheckpoint
+
+	if isCheckpoint {
+		if man.Tags == nil {
+			man.Tags = make(map[string]string, len(ct.tags))
+		}
+
+		for k, v := range ct.tags {
+			man.Tags[k] = v
+			labels[k] = v
+		}
+	}
+
+	id, err := ct.RepositoryWriter.PutManifest(ctx, labels, payload)
+	if err == nil && isCheckpoint {
+		ct.ids = append(ct.ids, id)
+	}
+
+	return id, err
+}
+
+// incompleteIDs returns the IDs of the incomplete snapshots.
+func incompleteIDs(mans []*snapshot.Manifest) []manifest.ID {
+	ids := []manifest.ID{}
+
+	for _, m := range mans {
+		if len(m.IncompleteReason) > 0 {
+			ids = append(ids, m.ID)
+		}
+	}
+
+	return ids
+}
+
+// deleteCheckpoints deletes the checkpoint snapshots.  Failing to delete a
+// checkpoint doesn't fail the backup, since the checkpoint is only left
+// taking up space; it's deleted along with its backup.
+func deleteCheckpoints(ctx context.Context, rw repo.RepositoryWriter, ids []manifest.ID) {
+	for _, id := range ids {
+		if err := rw.DeleteManifest(ctx, id); err != nil {
+			logger.Ctx(ctx).Warnw("deleting backup checkpoint", "snapshot_id", id, "error", err)
+		}
+	}
+}
+
 func (w Wrapper) getSnapshotRoot(
 	ctx context.Context,
 	snapshotID string,
@@ -391,6 +483,44 @@ func (w Wrapper) RestoreMultipleItems(
 	return res, errs.ErrorOrNil()
 }
 
+// VerifyContentHashes makes the items in the collections returned by
+// RestoreMultipleItems check their content against the hashes recorded when
+// they were backed up, keyed by item RepoRef.  Reading an item to the end
+// returns ErrContentHashMismatch if its content doesn't match.  Items without
+// a recorded hash aren't checked.
+func VerifyContentHashes(dcs []data.Collection, hashes map[string]string) {
+	for _, dc := range dcs {
+		kdc, ok := dc.(*kopiaDataCollection)
+		if !ok {
+			continue
+		}
+
+		for _, s := range kdc.streams {
+			kds, ok := s.(*kopiaDataStream)
+			if !ok {
+				continue
+			}
+
+			p, err := kdc.path.Append(kds.uuid, true)
+			if err != nil {
+				continue
+			}
+
+			h, ok := hashes[p.String()]
+			if !ok {
+				continue
+			}
+
+			kds.reader = &verifyingReader{
+				ReadCloser:   kds.reader,
+				hasher:       sha256.New(),
+				expectedHash: h,
+				itemPath:     p.String(),
+			}
+		}
+	}
+}
+
 // DeleteSnapshot removes the provided manifest from kopia.
 func (w Wrapper) DeleteSnapshot(
 	ctx context.Context,
@@ -423,6 +553,55 @@ func (w Wrapper) DeleteSnapshot(
 	return nil
 }
 
+// DeleteCheckpoints deletes the checkpoint snapshots saved while making the
+// backup.  Checkpoints are normally deleted once the backup completes, so
+// this only removes those left behind when that failed.
+func (w Wrapper) DeleteCheckpoints(ctx context.Context, backupID string) error {
+	if w.c == nil {
+		return errNotConnected
+	}
+
+	bk, _ := MakeTagKV(TagBackupID)
+
+	metas, err := w.c.FindManifests(ctx, map[string]string{
+		manifest.TypeLabelKey: snapshot.ManifestType,
+		bk:                    backupID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "finding backup checkpoints")
+	}
+
+	ids := make([]manifest.ID, 0, len(metas))
+	for _, m := range metas {
+		ids = append(ids, m.ID)
+	}
+
+	mans, err := snapshot.LoadSnapshots(ctx, w.c, ids)
+	if err != nil {
+		return errors.Wrap(err, "loading backup checkpoints")
+	}
+
+	err = repo.WriteSession(
+		ctx,
+		w.c,
+		repo.WriteSessionOptions{Purpose: "KopiaWrapperCheckpointDeletion"},
+		func(innerCtx context.Context, rw repo.RepositoryWriter) error {
+			for _, id := range incompleteIDs(mans) {
+				if err := rw.DeleteManifest(innerCtx, id); err != nil {
+					return errors.Wrap(err, "deleting backup checkpoint")
+				}
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "kopia deleting backup checkpoints")
+	}
+
+	return nil
+}
+
 // FetchPrevSnapshotManifests returns a set of manifests for complete and maybe
 // incomplete snapshots for the given (resource owner, service, category)
 // tuples. Up to two manifests can be returned per tuple: one complete and one
@@ -6,11 +6,14 @@ import (
 	"io"
 	stdpath "path"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kopia/kopia/repo"
 	"github.com/kopia/kopia/repo/manifest"
 	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/policy"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -133,6 +136,75 @@ func (suite *KopiaUnitSuite) TestCloseWithoutInitDoesNotPanic() {
 	})
 }
 
+// mockManifestWriter records the manifests written through it.
+type mockManifestWriter struct {
+	repo.RepositoryWriter
+	labels []map[string]string
+}
+
+func (mw *mockManifestWriter) PutManifest(
+	ctx context.Context,
+	labels map[string]string,
+	payload any,
+) (manifest.ID, error) {
+	mw.labels = append(mw.labels, labels)
+	return manifest.ID(uuid.NewString()), nil
+}
+
+func (suite *KopiaUnitSuite) TestCheckpointTagger() {
+	tagKey, tagValue := MakeTagKV(TagBackupCategory)
+	tags := map[string]string{tagKey: tagValue}
+
+	table := []struct {
+		name       string
+		payload    any
+		expectTags func(assert.TestingT, any, any, ...any) bool
+		expectIDs  int
+	}{
+		{
+			name:       "checkpoint",
+			payload:    &snapshot.Manifest{IncompleteReason: snapshotfs.IncompleteReasonCheckpoint},
+			expectTags: assert.Contains,
+			expectIDs:  1,
+		},
+		{
+			name:       "canceled",
+			payload:    &snapshot.Manifest{IncompleteReason: snapshotfs.IncompleteReasonCanceled},
+			expectTags: assert.NotContains,
+		},
+		{
+			name:       "complete",
+			payload:    &snapshot.Manifest{},
+			expectTags: assert.NotContains,
+		},
+		{
+			name:       "not a snapshot",
+			payload:    &policy.Policy{},
+			expectTags: assert.NotContains,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			ctx, flush := tester.NewContext()
+			defer flush()
+
+			mw := &mockManifestWriter{}
+			ct := &checkpointTagger{RepositoryWriter: mw, tags: tags}
+
+			_, err := ct.PutManifest(ctx, map[string]string{"type": "snapshot"}, test.payload)
+			require.NoError(t, err)
+			require.Len(t, mw.labels, 1)
+
+			test.expectTags(t, mw.labels[0], tagKey)
+			assert.Len(t, ct.ids, test.expectIDs)
+
+			if man, ok := test.payload.(*snapshot.Manifest); ok {
+				test.expectTags(t, man.Tags, tagKey)
+			}
+		})
+	}
+}
+
 // ---------------
 // integration tests that use kopia
 // ---------------
@@ -273,6 +345,7 @@ func (suite *KopiaIntegrationSuite) TestBackupCollections() {
 				path.ExchangeService,
 				oc,
 				customTags,
+				nil,
 			)
 			assert.NoError(t, err)
 
@@ -354,6 +427,7 @@ func (suite *KopiaIntegrationSuite) TestRestoreAfterCompressionChange() {
 		path.ExchangeService,
 		oc,
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -436,6 +510,7 @@ func (suite *KopiaIntegrationSuite) TestBackupCollections_ReaderError() {
 		path.ExchangeService,
 		oc,
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 
@@ -448,6 +523,199 @@ func (suite *KopiaIntegrationSuite) TestBackupCollections_ReaderError() {
 	assert.Len(t, deets.Entries, 5+6)
 }
 
+// interruptedCollection hands out the items of the collection, then never
+// finishes, as if the backup was interrupted while reading the next item.
+type interruptedCollection struct {
+	data.Collection
+	// handedOut is closed once all of the items are handed out.
+	handedOut chan struct{}
+}
+
+func (ic interruptedCollection) Items() <-chan data.Stream {
+	res := make(chan data.Stream)
+
+	go func() {
+		for s := range ic.Collection.Items() {
+			res <- s
+		}
+
+		close(ic.handedOut)
+	}()
+
+	return res
+}
+
+// interruptBackup starts a backup of the collection with the backup ID, and
+// interrupts it once a checkpoint holding all of its items is saved.
+// Returns the checkpoint.
+func (suite *KopiaIntegrationSuite) interruptBackup(
+	t *testing.T,
+	oc *OwnersCats,
+	dc data.Collection,
+	backupID string,
+) *ManifestEntry {
+	interval := checkpointInterval
+	checkpointInterval = 100 * time.Millisecond
+
+	defer func() { checkpointInterval = interval }()
+
+	var (
+		ctx, cancel = context.WithCancel(suite.ctx)
+		found       = make(chan *ManifestEntry, 1)
+		wait        = checkpointInterval
+		bk, _       = MakeTagKV(TagBackupID)
+		ic          = interruptedCollection{dc, make(chan struct{})}
+	)
+
+	defer cancel()
+
+	go func() {
+		var checkpoint *ManifestEntry
+
+		defer func() {
+			found <- checkpoint
+			cancel()
+		}()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ic.handedOut:
+		}
+
+		// checkpoints saved once every item is handed out can still miss the
+		// last item, so wait for the checkpoint after the next one.
+		for seen := 0; seen < 2 && ctx.Err() == nil; {
+			time.Sleep(wait)
+
+			mans, err := suite.w.FetchPrevSnapshotManifests(ctx, oc, map[string]string{TagBackupCategory: ""})
+			if err != nil {
+				return
+			}
+
+			for _, m := range mans {
+				if m.Tags[bk] != backupID || m.IncompleteReason != snapshotfs.IncompleteReasonCheckpoint {
+					continue
+				}
+
+				if checkpoint == nil || checkpoint.ID != m.ID {
+					checkpoint = m
+					seen++
+				}
+			}
+		}
+	}()
+
+	_, _, err := suite.w.BackupCollections(
+		ctx,
+		nil,
+		[]data.Collection{ic},
+		path.ExchangeService,
+		oc,
+		map[string]string{TagBackupID: backupID, TagBackupCategory: ""},
+		nil,
+	)
+	require.Error(t, err)
+
+	cancel()
+
+	checkpoint := <-found
+	require.NotNil(t, checkpoint, "checkpoint of the interrupted backup")
+
+	return checkpoint
+}
+
+func (suite *KopiaIntegrationSuite) TestBackupCollections_ResumeFromCheckpoint() {
+	t := suite.T()
+
+	k, v := MakeServiceCat(path.ExchangeService, path.EmailCategory)
+	oc := &OwnersCats{
+		ResourceOwners: map[string]struct{}{
+			testUser: {},
+		},
+		ServiceCats: map[string]ServiceCat{
+			k: v,
+		},
+	}
+
+	dc := mockconnector.NewMockExchangeCollection(suite.testPath1, 3)
+	checkpoint := suite.interruptBackup(t, oc, dc, "interrupted")
+
+	// the next backup finds the checkpoint, the same way a backup operation
+	// looks up its bases.
+	mans, err := suite.w.FetchPrevSnapshotManifests(suite.ctx, oc, map[string]string{TagBackupCategory: ""})
+	require.NoError(t, err)
+
+	bases := []IncrementalBase{}
+
+	for _, m := range mans {
+		bases = append(bases, IncrementalBase{
+			Manifest:     m.Manifest,
+			SubtreePaths: []*path.Builder{suite.testPath1.ToBuilder().Dir()},
+		})
+	}
+
+	require.Len(t, bases, 1)
+	assert.Equal(t, checkpoint.ID, bases[0].ID)
+
+	stats, deets, err := suite.w.BackupCollections(
+		suite.ctx,
+		bases,
+		[]data.Collection{dc},
+		path.ExchangeService,
+		oc,
+		map[string]string{TagBackupID: "resumed", TagBackupCategory: ""},
+		nil,
+	)
+	require.NoError(t, err)
+
+	// items in the checkpoint aren't uploaded again.
+	assert.Equal(t, 3, stats.CachedFileCount, "cached files")
+	assert.Equal(t, 0, stats.UncachedFileCount, "uncached files")
+	assert.False(t, stats.Incomplete)
+	assert.Len(t, deets.Items(), 3)
+
+	// the complete snapshot replaces the checkpoint.
+	_, err = snapshot.LoadSnapshot(suite.ctx, suite.w.c, checkpoint.ID)
+	assert.ErrorIs(t, err, snapshot.ErrSnapshotNotFound)
+
+	mans, err = suite.w.FetchPrevSnapshotManifests(suite.ctx, oc, map[string]string{TagBackupCategory: ""})
+	require.NoError(t, err)
+	require.Len(t, mans, 1)
+	assert.Equal(t, manifest.ID(stats.SnapshotID), mans[0].ID)
+}
+
+func (suite *KopiaIntegrationSuite) TestDeleteCheckpoints() {
+	t := suite.T()
+
+	k, v := MakeServiceCat(path.ExchangeService, path.EmailCategory)
+	oc := &OwnersCats{
+		ResourceOwners: map[string]struct{}{
+			testUser: {},
+		},
+		ServiceCats: map[string]ServiceCat{
+			k: v,
+		},
+	}
+
+	checkpoint := suite.interruptBackup(
+		t,
+		oc,
+		mockconnector.NewMockExchangeCollection(suite.testPath1, 3),
+		"abandoned")
+
+	// checkpoints of other backups are kept.
+	require.NoError(t, suite.w.DeleteCheckpoints(suite.ctx, "other"))
+
+	_, err := snapshot.LoadSnapshot(suite.ctx, suite.w.c, checkpoint.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, suite.w.DeleteCheckpoints(suite.ctx, "abandoned"))
+
+	_, err = snapshot.LoadSnapshot(suite.ctx, suite.w.c, checkpoint.ID)
+	assert.ErrorIs(t, err, snapshot.ErrSnapshotNotFound)
+}
+
 type backedupFile struct {
 	parentPath path.Path
 	itemPath   path.Path
@@ -481,6 +749,7 @@ func (suite *KopiaIntegrationSuite) TestBackupCollectionsHandlesNoCollections()
 				path.UnknownService,
 				&OwnersCats{},
 				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -642,6 +911,7 @@ func (suite *KopiaSimpleRepoIntegrationSuite) SetupTest() {
 		path.ExchangeService,
 		oc,
 		nil,
+		nil,
 	)
 	require.NoError(t, err)
 	require.Equal(t, stats.ErrorCount, 0)
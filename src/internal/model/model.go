@@ -29,6 +29,9 @@ const (
 // common tags for filtering
 const (
 	ServiceTag = "service"
+	// UserTagPrefix namespaces the tags that users attach to a model, so
+	// they can't collide with the tags set by corso.
+	UserTagPrefix = "user:"
 )
 
 // Valid returns true if the ModelType value fits within the iota range.
@@ -2,6 +2,7 @@ package operations
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -39,6 +40,10 @@ type BackupOperation struct {
 	Version   string             `json:"version"`
 
 	account account.Account
+	// sharedProgress is set when the progress display is shared with other
+	// operations running at the same time.  The display is completed once
+	// they all finish, instead of at the end of this operation.
+	sharedProgress bool
 }
 
 // BackupResults aggregate the details of the result of the operation.
@@ -46,7 +51,10 @@ type BackupResults struct {
 	stats.Errs
 	stats.ReadWrites
 	stats.StartAndEndTime
+	stats.Throttling
 	BackupID model.StableID `json:"backupID"`
+	// Failures lists the items skipped due to errors.
+	Failures []details.Failure `json:"failures,omitempty"`
 }
 
 // NewBackupOperation constructs and validates a backup operation.
@@ -86,6 +94,8 @@ type backupStats struct {
 	resourceCount     int
 	started           bool
 	readErr, writeErr error
+	throttling        stats.Throttling
+	failures          []details.Failure
 }
 
 // Run begins a synchronous backup operation.
@@ -96,6 +106,7 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 	var (
 		opStats       backupStats
 		backupDetails *details.Details
+		detailsWriter *streamstore.DetailsWriter
 		tenantID      = op.account.ID()
 		startTime     = time.Now()
 	)
@@ -115,19 +126,36 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 	// persist operation results to the model store on exit
 	defer func() {
 		// wait for the progress display to clean up
-		observe.Complete()
+		if !op.sharedProgress {
+			observe.Complete()
+		}
 
 		err = op.persistResults(startTime, &opStats)
 		if err != nil {
+			if detailsWriter != nil {
+				detailsWriter.Abort(ctx)
+			}
+
 			return
 		}
 
-		err = op.createBackupModels(ctx, opStats.k.SnapshotID, backupDetails)
+		err = op.createBackupModels(ctx, opStats.k.SnapshotID, backupDetails, detailsWriter)
 		if err != nil {
 			opStats.writeErr = err
 		}
 	}()
 
+	// details are stored in chunks as they're produced, so that they
+	// never need to be held in memory all at once.
+	detailsWriter, err = streamstore.New(op.kopia, tenantID, op.Selectors.PathService()).
+		NewDetailsWriter(ctx)
+	if err != nil {
+		opStats.writeErr = errors.Wrap(err, "storing backup details")
+		return opStats.writeErr
+	}
+
+	backupDetails = details.NewChunked(details.DefaultChunkSize, detailsWriter)
+
 	oc := selectorToOwnersCats(op.Selectors)
 
 	mans, mdColls, err := produceManifestsAndMetadata(ctx, op.kopia, op.store, oc, tenantID)
@@ -142,13 +170,17 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 		return opStats.readErr
 	}
 
-	cs, err := produceBackupDataCollections(ctx, gc, op.Selectors, mdColls, control.Options{})
+	defer func() {
+		opStats.throttling = gc.ThrottleStats()
+	}()
+
+	cs, err := produceBackupDataCollections(ctx, gc, op.Selectors, mdColls, op.Options)
 	if err != nil {
 		opStats.readErr = errors.Wrap(err, "retrieving data to backup")
 		return opStats.readErr
 	}
 
-	opStats.k, backupDetails, err = consumeBackupDataCollections(
+	opStats.k, err = consumeBackupDataCollections(
 		ctx,
 		op.kopia,
 		tenantID,
@@ -156,7 +188,8 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 		oc,
 		mans,
 		cs,
-		op.Results.BackupID)
+		op.Results.BackupID,
+		backupDetails)
 	if err != nil {
 		opStats.writeErr = errors.Wrap(err, "backing up service data")
 		return opStats.writeErr
@@ -169,12 +202,230 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 
 	// TODO: should always be 1, since backups are 1:1 with resourceOwners now.
 	opStats.resourceCount = len(data.ResourceOwnerSet(cs))
-	opStats.started = true
 	opStats.gc = gc.AwaitStatus()
+	opStats.failures = opStats.gc.Failures
+
+	if op.Options.FailFast && len(opStats.failures) > 0 {
+		opStats.readErr = failFast(ctx, op.kopia, opStats.k.SnapshotID, opStats.failures)
+		return opStats.readErr
+	}
+
+	opStats.started = true
 
 	return err
 }
 
+// DryRun enumerates the data that Run would back up, without retrieving or
+// storing any of it.  Produces an estimate for each resource owner and
+// category, compared against the most recent backup of the same data.
+func (op *BackupOperation) DryRun(ctx context.Context) ([]backup.Estimate, error) {
+	ctx, end := D.Span(ctx, "operations:backup:dryRun")
+	defer end()
+
+	var (
+		tenantID = op.account.ID()
+		oc       = selectorToOwnersCats(op.Selectors)
+	)
+
+	prev, err := prevBackupItemSizes(ctx, op.kopia, op.store, oc, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving previous backup details")
+	}
+
+	gc, err := connectToM365(ctx, op.Selectors, op.account)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to M365")
+	}
+
+	// Metadata from previous backups isn't passed along, so that every item
+	// is enumerated instead of only the items changed since the last backup.
+	cs, err := produceBackupDataCollections(ctx, gc, op.Selectors, nil, op.Options)
+	if err != nil {
+		return nil, errors.Wrap(err, "enumerating data to backup")
+	}
+
+	return estimateCollections(cs, op.Selectors.PathService(), prev), nil
+}
+
+// prevBackupItemSizes returns the size of each item in the most recent
+// backups of the resource owners and categories, keyed by repoRef.
+func prevBackupItemSizes(
+	ctx context.Context,
+	kw *kopia.Wrapper,
+	sw *store.Wrapper,
+	oc *kopia.OwnersCats,
+	tenantID string,
+) (map[string]int64, error) {
+	ms, err := kw.FetchPrevSnapshotManifests(
+		ctx,
+		oc,
+		map[string]string{kopia.TagBackupCategory: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sizes     = map[string]int64{}
+		seen      = map[string]struct{}{}
+		bidTag, _ = kopia.MakeTagKV(kopia.TagBackupID)
+	)
+
+	for _, man := range ms {
+		// checkpoints don't have backup details
+		if len(man.IncompleteReason) > 0 {
+			continue
+		}
+
+		bID := man.Tags[bidTag]
+		if _, ok := seen[bID]; ok || len(bID) == 0 {
+			continue
+		}
+
+		seen[bID] = struct{}{}
+
+		b, err := sw.GetBackup(ctx, model.StableID(bID))
+		if err != nil {
+			// the backup may have been deleted without its snapshot
+			if errors.Is(err, kopia.ErrNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		it, err := streamstore.New(kw, tenantID, b.Selectors.PathService()).
+			IterateBackupDetails(ctx, b.DetailsID)
+		if err != nil {
+			return nil, err
+		}
+
+		for it.Next(ctx) {
+			ent := it.Entry()
+			if ent.Folder != nil {
+				continue
+			}
+
+			sizes[ent.RepoRef] = ent.Size()
+		}
+
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sizes, nil
+}
+
+// estimateCollections totals the items in the collections by resource owner
+// and category.  Items in prev are counted as already backed up.  Items of
+// unknown size are estimated to be the size they had in prev, or else the
+// average size of the other items in their category.
+func estimateCollections(
+	cs []data.Collection,
+	service path.ServiceType,
+	prev map[string]int64,
+) []backup.Estimate {
+	type tally struct {
+		est        backup.Estimate
+		knownBytes int64
+		knownItems int
+		unsized    int
+		unsizedNew int
+	}
+
+	var (
+		tallies = map[string]*tally{}
+		keys    []string
+	)
+
+	for _, c := range cs {
+		fp := c.FullPath()
+
+		// skip metadata and deleted collections
+		if fp == nil || fp.Service() != service || c.State() == data.DeletedState {
+			continue
+		}
+
+		key := fp.ResourceOwner() + "/" + fp.Category().String()
+
+		t, ok := tallies[key]
+		if !ok {
+			t = &tally{est: backup.Estimate{
+				ResourceOwner: fp.ResourceOwner(),
+				Category:      fp.Category().String(),
+			}}
+			tallies[key] = t
+			keys = append(keys, key)
+		}
+
+		t.est.Containers++
+
+		ce, ok := c.(data.CollectionEstimator)
+		if !ok {
+			continue
+		}
+
+		for _, item := range ce.EstimateItems() {
+			var (
+				size            = item.Size
+				prevSize, found = int64(0), false
+			)
+
+			if ip, err := fp.Append(item.UUID, true); err == nil {
+				prevSize, found = prev[ip.String()]
+			}
+
+			t.est.Items++
+
+			if !found {
+				t.est.NewItems++
+			}
+
+			if size < 0 && found {
+				size = prevSize
+			}
+
+			if size < 0 {
+				t.unsized++
+
+				if !found {
+					t.unsizedNew++
+				}
+
+				continue
+			}
+
+			t.knownBytes += size
+			t.knownItems++
+			t.est.Bytes += size
+
+			if !found {
+				t.est.NewBytes += size
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	ests := make([]backup.Estimate, 0, len(keys))
+
+	for _, k := range keys {
+		t := tallies[k]
+
+		if t.knownItems > 0 {
+			avg := t.knownBytes / int64(t.knownItems)
+			t.est.Bytes += avg * int64(t.unsized)
+			t.est.NewBytes += avg * int64(t.unsizedNew)
+		} else {
+			t.est.UnsizedItems = t.unsized
+		}
+
+		ests = append(ests, t.est)
+	}
+
+	return ests
+}
+
 // calls kopia to retrieve prior backup manifests, metadata collections to supply backup heuristics.
 func produceManifestsAndMetadata(
 	ctx context.Context,
@@ -204,7 +455,16 @@ func produceManifestsAndMetadata(
 	}
 
 	for _, man := range ms {
+		// Checkpoints of interrupted backups are still handed to kopia, so
+		// that their content isn't hashed again.  They don't hold metadata
+		// or details, so a resumed backup still reads every item from M365
+		// and rebuilds every detail entry.
 		if len(man.IncompleteReason) > 0 {
+			logger.Ctx(ctx).Infow(
+				"resuming from incomplete backup",
+				"snapshot_id", man.ID,
+				"incomplete_reason", man.IncompleteReason)
+
 			continue
 		}
 
@@ -350,6 +610,32 @@ func builderFromReason(tenant string, r kopia.Reason) (*path.Builder, error) {
 	return p.ToBuilder().Dir(), nil
 }
 
+type snapshotDeleter interface {
+	DeleteSnapshot(ctx context.Context, snapshotID string) error
+}
+
+// failFast produces the error of a backup that stops because items failed.
+// The backup's snapshot is deleted; no backup model is stored for it, but
+// the snapshot would otherwise become the base of the next incremental
+// backup, which would then never fetch the failed items again.
+func failFast(
+	ctx context.Context,
+	sd snapshotDeleter,
+	snapshotID string,
+	failures []details.Failure,
+) error {
+	err := errors.Errorf(
+		"%d items failed to back up, first failure: %s",
+		len(failures),
+		failures[0].Error)
+
+	if derr := sd.DeleteSnapshot(ctx, snapshotID); derr != nil {
+		return multierror.Append(err, errors.Wrap(derr, "deleting snapshot of failed backup"))
+	}
+
+	return err
+}
+
 type backuper interface {
 	BackupCollections(
 		ctx context.Context,
@@ -358,10 +644,12 @@ type backuper interface {
 		service path.ServiceType,
 		oc *kopia.OwnersCats,
 		tags map[string]string,
+		deets *details.Details,
 	) (*kopia.BackupStats, *details.Details, error)
 }
 
-// calls kopia to backup the collections of data
+// calls kopia to backup the collections of data, adding the details
+// of the backed up items to deets
 func consumeBackupDataCollections(
 	ctx context.Context,
 	bu backuper,
@@ -371,7 +659,8 @@ func consumeBackupDataCollections(
 	mans []*kopia.ManifestEntry,
 	cs []data.Collection,
 	backupID model.StableID,
-) (*kopia.BackupStats, *details.Details, error) {
+	deets *details.Details,
+) (*kopia.BackupStats, error) {
 	complete, closer := observe.MessageWithCompletion("Backing up data:")
 	defer func() {
 		complete <- struct{}{}
@@ -392,7 +681,7 @@ func consumeBackupDataCollections(
 		for _, reason := range m.Reasons {
 			pb, err := builderFromReason(tenantID, reason)
 			if err != nil {
-				return nil, nil, errors.Wrap(err, "getting subtree paths for bases")
+				return nil, errors.Wrap(err, "getting subtree paths for bases")
 			}
 
 			paths = append(paths, pb)
@@ -404,7 +693,9 @@ func consumeBackupDataCollections(
 		})
 	}
 
-	return bu.BackupCollections(ctx, bases, cs, sel.PathService(), oc, tags)
+	stats, _, err := bu.BackupCollections(ctx, bases, cs, sel.PathService(), oc, tags, deets)
+
+	return stats, err
 }
 
 // writes the results metrics to the operation results.
@@ -416,6 +707,8 @@ func (op *BackupOperation) persistResults(
 	op.Results.StartedAt = started
 	op.Results.CompletedAt = time.Now()
 
+	op.Results.Throttling = opStats.throttling
+
 	op.Status = Completed
 	if !opStats.started {
 		op.Status = Failed
@@ -438,6 +731,7 @@ func (op *BackupOperation) persistResults(
 	op.Results.ItemsRead = opStats.gc.Successful
 	op.Results.ItemsWritten = opStats.k.TotalFileCount
 	op.Results.ResourceOwners = opStats.resourceCount
+	op.Results.Failures = opStats.failures
 
 	return nil
 }
@@ -447,27 +741,37 @@ func (op *BackupOperation) createBackupModels(
 	ctx context.Context,
 	snapID string,
 	backupDetails *details.Details,
+	detailsWriter *streamstore.DetailsWriter,
 ) error {
-	if backupDetails == nil {
+	if backupDetails == nil || detailsWriter == nil {
 		return errors.New("no backup details to record")
 	}
 
-	detailsID, err := streamstore.New(
-		op.kopia,
-		op.account.ID(),
-		op.Selectors.PathService(),
-	).WriteBackupDetails(ctx, backupDetails)
+	// the index lets later queries read only the chunks of the
+	// details holding the resource owners and categories they select.
+	idx, err := backupDetails.Flush()
+	if err != nil {
+		detailsWriter.Abort(ctx)
+		return errors.Wrap(err, "creating backupdetails model")
+	}
+
+	detailsID, err := detailsWriter.Close()
 	if err != nil {
 		return errors.Wrap(err, "creating backupdetails model")
 	}
 
+	idx.SnapshotID = detailsID
+
 	b := backup.New(
 		snapID, detailsID, op.Status.String(),
 		op.Results.BackupID,
 		op.Selectors,
 		op.Results.ReadWrites,
 		op.Results.StartAndEndTime,
+		op.Results.Failures,
+		op.Options.Tags,
 	)
+	b.DetailsIndex = idx
 
 	err = op.store.Put(ctx, model.BackupSchema, b)
 	if err != nil {
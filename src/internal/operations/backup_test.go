@@ -296,6 +296,7 @@ func (mbu mockBackuper) BackupCollections(
 	service path.ServiceType,
 	oc *kopia.OwnersCats,
 	tags map[string]string,
+	deets *details.Details,
 ) (*kopia.BackupStats, *details.Details, error) {
 	if mbu.checkFunc != nil {
 		mbu.checkFunc(bases, cs, service, oc, tags)
@@ -304,6 +305,53 @@ func (mbu mockBackuper) BackupCollections(
 	return &kopia.BackupStats{}, &details.Details{}, nil
 }
 
+type mockSnapshotDeleter struct {
+	deleted []string
+	err     error
+}
+
+func (msd *mockSnapshotDeleter) DeleteSnapshot(_ context.Context, snapshotID string) error {
+	msd.deleted = append(msd.deleted, snapshotID)
+	return msd.err
+}
+
+func (suite *BackupOpSuite) TestBackupOperation_FailFast() {
+	failures := []details.Failure{{ItemID: "item", Error: "failed"}}
+
+	table := []struct {
+		name      string
+		deleteErr error
+	}{
+		{
+			name: "snapshot deleted",
+		},
+		{
+			name:      "delete fails",
+			deleteErr: assert.AnError,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			ctx, flush := tester.NewContext()
+			defer flush()
+
+			sd := &mockSnapshotDeleter{err: test.deleteErr}
+
+			err := failFast(ctx, sd, "snapshot", failures)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed")
+
+			// the snapshot must not become the base of the next incremental
+			// backup, which would skip the failed items.
+			assert.Equal(t, []string{"snapshot"}, sd.deleted)
+
+			if test.deleteErr != nil {
+				assert.ErrorIs(t, err, test.deleteErr)
+			}
+		})
+	}
+}
+
 func (suite *BackupOpSuite) TestBackupOperation_ConsumeBackupDataCollections_Paths() {
 	var (
 		tenant        = "a-tenant"
@@ -452,11 +500,140 @@ func (suite *BackupOpSuite) TestBackupOperation_ConsumeBackupDataCollections_Pat
 				test.inputMan,
 				nil,
 				model.StableID(""),
+				nil,
 			)
 		})
 	}
 }
 
+type mockEstimatorColl struct {
+	fp    path.Path
+	state data.CollectionState
+	items []data.ItemEstimate
+}
+
+func (mc mockEstimatorColl) Items() <-chan data.Stream {
+	return nil
+}
+
+func (mc mockEstimatorColl) FullPath() path.Path {
+	return mc.fp
+}
+
+func (mc mockEstimatorColl) PreviousPath() path.Path {
+	return nil
+}
+
+func (mc mockEstimatorColl) State() data.CollectionState {
+	return mc.state
+}
+
+func (mc mockEstimatorColl) EstimateItems() []data.ItemEstimate {
+	return mc.items
+}
+
+func (suite *BackupOpSuite) TestBackupOperation_EstimateCollections() {
+	var (
+		t      = suite.T()
+		tenant = "a-tenant"
+		user   = "a-user"
+	)
+
+	makePath := func(
+		t *testing.T,
+		service path.ServiceType,
+		cat path.CategoryType,
+		folder string,
+	) path.Path {
+		var (
+			p   path.Path
+			err error
+		)
+
+		pb := path.Builder{}.Append(folder)
+
+		switch service {
+		case path.ExchangeMetadataService:
+			p, err = pb.ToServiceCategoryMetadataPath(tenant, user, path.ExchangeService, cat, false)
+		default:
+			p, err = pb.ToDataLayerExchangePathForCategory(tenant, user, cat, false)
+		}
+
+		require.NoError(t, err)
+
+		return p
+	}
+
+	var (
+		inbox    = makePath(t, path.ExchangeService, path.EmailCategory, "Inbox")
+		archive  = makePath(t, path.ExchangeService, path.EmailCategory, "Archive")
+		contacts = makePath(t, path.ExchangeService, path.ContactsCategory, "Contacts")
+		metadata = makePath(t, path.ExchangeMetadataService, path.EmailCategory, "delta")
+		itemRef  = func(p path.Path, id string) string {
+			ip, err := p.Append(id, true)
+			require.NoError(t, err)
+
+			return ip.String()
+		}
+	)
+
+	cs := []data.Collection{
+		mockEstimatorColl{
+			fp: inbox,
+			items: []data.ItemEstimate{
+				{UUID: "old1", Size: -1},
+				{UUID: "old2", Size: -1},
+				{UUID: "new1", Size: -1},
+			},
+		},
+		mockEstimatorColl{
+			fp:    archive,
+			items: []data.ItemEstimate{{UUID: "new2", Size: 50}},
+		},
+		mockEstimatorColl{
+			fp:    contacts,
+			items: []data.ItemEstimate{{UUID: "new3", Size: -1}},
+		},
+		mockEstimatorColl{
+			fp:    metadata,
+			items: []data.ItemEstimate{{UUID: "delta", Size: 10}},
+		},
+		mockEstimatorColl{
+			fp:    makePath(t, path.ExchangeService, path.EmailCategory, "Deleted"),
+			state: data.DeletedState,
+			items: []data.ItemEstimate{{UUID: "gone", Size: 10}},
+		},
+	}
+
+	prev := map[string]int64{
+		itemRef(inbox, "old1"): 100,
+		itemRef(inbox, "old2"): 150,
+	}
+
+	expect := []backup.Estimate{
+		{
+			ResourceOwner: user,
+			Category:      path.ContactsCategory.String(),
+			Containers:    1,
+			Items:         1,
+			NewItems:      1,
+			UnsizedItems:  1,
+		},
+		{
+			ResourceOwner: user,
+			Category:      path.EmailCategory.String(),
+			Containers:    2,
+			Items:         4,
+			NewItems:      2,
+			// 100 + 150 + 50, plus new1 at the average size of 100
+			Bytes:    400,
+			NewBytes: 150,
+		},
+	}
+
+	assert.Equal(t, expect, estimateCollections(cs, path.ExchangeService, prev))
+}
+
 // ---------------------------------------------------------------------------
 // integration
 // ---------------------------------------------------------------------------
@@ -475,7 +652,7 @@ func prepNewBackupOp(
 	st := tester.NewPrefixedS3Storage(t)
 
 	k := kopia.NewConn(st)
-	require.NoError(t, k.Initialize(ctx))
+	require.NoError(t, k.Initialize(ctx, control.ObjectLock{}))
 
 	// kopiaRef comes with a count of 1 and Wrapper bumps it again so safe
 	// to close here.
@@ -0,0 +1,273 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/common"
+	"github.com/alcionai/corso/src/internal/data"
+	D "github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/internal/export"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/internal/streamstore"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// ExportOperation wraps an operation with export-specific props.
+type ExportOperation struct {
+	operation
+
+	BackupID    model.StableID            `json:"backupID"`
+	Results     ExportResults             `json:"results"`
+	Selectors   selectors.Selector        `json:"selectors"`
+	Destination control.ExportDestination `json:"destination"`
+	Version     string                    `json:"version"`
+
+	account account.Account
+}
+
+// ExportResults aggregate the details of the results of the operation.
+type ExportResults struct {
+	stats.Errs
+	stats.ReadWrites
+	stats.StartAndEndTime
+}
+
+// NewExportOperation constructs and validates an export operation.
+func NewExportOperation(
+	ctx context.Context,
+	opts control.Options,
+	kw *kopia.Wrapper,
+	sw *store.Wrapper,
+	acct account.Account,
+	backupID model.StableID,
+	sel selectors.Selector,
+	dest control.ExportDestination,
+	bus events.Eventer,
+) (ExportOperation, error) {
+	op := ExportOperation{
+		operation:   newOperation(opts, bus, kw, sw),
+		BackupID:    backupID,
+		Selectors:   sel,
+		Destination: dest,
+		Version:     "v0",
+		account:     acct,
+	}
+	if err := op.validate(); err != nil {
+		return ExportOperation{}, err
+	}
+
+	return op, nil
+}
+
+func (op ExportOperation) validate() error {
+	if len(op.Destination.Path) == 0 {
+		return errors.New("missing export destination path")
+	}
+
+	return op.operation.validate()
+}
+
+// aggregates stats from the export.Run().
+// primarily used so that the defer can take in a
+// pointer wrapping the values, while those values
+// get populated asynchronously.
+type exportStats struct {
+	cs                []data.Collection
+	bytesRead         *stats.ByteCounter
+	itemsRead         int
+	itemsWritten      int
+	resourceCount     int
+	started           bool
+	readErr, writeErr error
+
+	// a transient value only used to pair up start-end events.
+	exportID string
+}
+
+// Run begins a synchronous export operation.  Items are read from the
+// backup the same way as for a restore, but are written to the local disk
+// instead of M365.
+func (op *ExportOperation) Run(ctx context.Context) (exportDetails *details.Details, err error) {
+	ctx, end := D.Span(ctx, "operations:export:run")
+	defer end()
+
+	var (
+		opStats = exportStats{
+			bytesRead: &stats.ByteCounter{},
+			exportID:  uuid.NewString(),
+		}
+		startTime = time.Now()
+	)
+
+	defer func() {
+		// wait for the progress display to clean up
+		observe.Complete()
+
+		err = op.persistResults(ctx, startTime, &opStats)
+		if err != nil {
+			return
+		}
+	}()
+
+	dID, bup, err := op.store.GetDetailsIDFromBackupID(ctx, op.BackupID)
+	if err != nil {
+		err = errors.Wrap(err, "getting backup details ID for export")
+		opStats.readErr = err
+
+		return nil, err
+	}
+
+	// only the entries the selector includes are kept as the details are read.
+	deets, err := streamstore.New(
+		op.kopia,
+		op.account.ID(),
+		op.Selectors.PathService(),
+	).ReduceBackupDetails(ctx, dID, op.Selectors.Reduce)
+	if err != nil {
+		err = errors.Wrap(err, "getting backup details data for export")
+		opStats.readErr = err
+
+		return nil, err
+	}
+
+	op.bus.Event(
+		ctx,
+		events.ExportStart,
+		map[string]any{
+			events.StartTime:        startTime,
+			events.BackupID:         op.BackupID,
+			events.BackupCreateTime: bup.CreationTime,
+			events.ExportID:         opStats.exportID,
+		},
+	)
+
+	paths, err := formatDetailsForRestoration(deets)
+	if err != nil {
+		opStats.readErr = err
+		return nil, err
+	}
+
+	observe.Message(fmt.Sprintf("Discovered %d items in backup %s to export", len(paths), op.BackupID))
+
+	kopiaComplete, closer := observe.MessageWithCompletion("Enumerating items in repository:")
+	defer closer()
+	defer close(kopiaComplete)
+
+	dcs, err := op.kopia.RestoreMultipleItems(ctx, bup.SnapshotID, paths, opStats.bytesRead)
+	if err != nil {
+		err = errors.Wrap(err, "retrieving service data")
+		opStats.readErr = err
+
+		return nil, err
+	}
+	kopiaComplete <- struct{}{}
+
+	// Items fail to read if their content differs from what was backed up.
+	kopia.VerifyContentHashes(dcs, deets.ContentHashes())
+
+	opStats.cs = dcs
+	opStats.resourceCount = len(data.ResourceOwnerSet(dcs))
+
+	w, err := export.NewWriter(op.Destination)
+	if err != nil {
+		opStats.writeErr = errors.Wrap(err, "opening export destination")
+		return nil, opStats.writeErr
+	}
+
+	exportComplete, closer := observe.MessageWithCompletion("Exporting data:")
+	defer closer()
+	defer close(exportComplete)
+
+	opStats.started = true
+
+	entries := make(map[string]details.DetailsEntry, len(paths))
+	for _, ent := range deets.Items() {
+		entries[ent.RepoRef] = *ent
+	}
+
+	exportDetails, opStats.itemsRead, err = export.Collections(ctx, w, op.Destination.MailFormat, dcs, entries)
+	opStats.itemsWritten = len(exportDetails.Entries)
+
+	if cerr := w.Close(); cerr != nil {
+		err = multierror.Append(err, cerr).ErrorOrNil()
+	}
+
+	if err != nil {
+		err = errors.Wrap(err, "exporting service data")
+		opStats.writeErr = err
+
+		return exportDetails, err
+	}
+	exportComplete <- struct{}{}
+
+	return exportDetails, nil
+}
+
+// persists details and statistics about the export operation.
+func (op *ExportOperation) persistResults(
+	ctx context.Context,
+	started time.Time,
+	opStats *exportStats,
+) error {
+	op.Results.StartedAt = started
+	op.Results.CompletedAt = time.Now()
+
+	op.Status = Completed
+
+	if !opStats.started {
+		op.Status = Failed
+
+		return multierror.Append(
+			errors.New("errors prevented the operation from processing"),
+			opStats.readErr,
+			opStats.writeErr)
+	}
+
+	if opStats.readErr == nil && opStats.writeErr == nil && opStats.itemsWritten == 0 {
+		op.Status = NoData
+	}
+
+	op.Results.ReadErrors = opStats.readErr
+	op.Results.WriteErrors = opStats.writeErr
+
+	op.Results.BytesRead = opStats.bytesRead.NumBytes
+	op.Results.ItemsRead = opStats.itemsRead
+	op.Results.ItemsWritten = opStats.itemsWritten
+	op.Results.ResourceOwners = opStats.resourceCount
+
+	dur := op.Results.CompletedAt.Sub(op.Results.StartedAt)
+
+	op.bus.Event(
+		ctx,
+		events.ExportEnd,
+		map[string]any{
+			events.BackupID:      op.BackupID,
+			events.DataRetrieved: op.Results.BytesRead,
+			events.Duration:      dur,
+			events.EndTime:       common.FormatTime(op.Results.CompletedAt),
+			events.ItemsRead:     op.Results.ItemsRead,
+			events.ItemsWritten:  op.Results.ItemsWritten,
+			events.Resources:     op.Results.ResourceOwners,
+			events.ExportID:      opStats.exportID,
+			events.Service:       op.Selectors.Service.String(),
+			events.StartTime:     common.FormatTime(op.Results.StartedAt),
+			events.Status:        op.Status.String(),
+		},
+	)
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package operations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/connector/exchange"
+	"github.com/alcionai/corso/src/internal/data"
+	evmock "github.com/alcionai/corso/src/internal/events/mock"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+type ExportOpSuite struct {
+	suite.Suite
+}
+
+func TestExportOpSuite(t *testing.T) {
+	suite.Run(t, new(ExportOpSuite))
+}
+
+func (suite *ExportOpSuite) TestExportOperation_PersistResults() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	var (
+		kw   = &kopia.Wrapper{}
+		sw   = &store.Wrapper{}
+		acct = account.Account{}
+		now  = time.Now()
+		dest = control.ExportDestination{Path: suite.T().TempDir()}
+	)
+
+	table := []struct {
+		expectStatus opStatus
+		expectErr    assert.ErrorAssertionFunc
+		stats        exportStats
+	}{
+		{
+			expectStatus: Completed,
+			expectErr:    assert.NoError,
+			stats: exportStats{
+				started:       true,
+				resourceCount: 1,
+				bytesRead: &stats.ByteCounter{
+					NumBytes: 42,
+				},
+				cs:           []data.Collection{&exchange.Collection{}},
+				itemsRead:    2,
+				itemsWritten: 1,
+			},
+		},
+		{
+			expectStatus: Failed,
+			expectErr:    assert.Error,
+			stats: exportStats{
+				started:   false,
+				bytesRead: &stats.ByteCounter{},
+			},
+		},
+		{
+			expectStatus: NoData,
+			expectErr:    assert.NoError,
+			stats: exportStats{
+				started:   true,
+				bytesRead: &stats.ByteCounter{},
+				cs:        []data.Collection{},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.expectStatus.String(), func(t *testing.T) {
+			op, err := NewExportOperation(
+				ctx,
+				control.Options{},
+				kw,
+				sw,
+				acct,
+				"foo",
+				selectors.Selector{},
+				dest,
+				evmock.NewBus())
+			require.NoError(t, err)
+			test.expectErr(t, op.persistResults(ctx, now, &test.stats))
+
+			assert.Equal(t, test.expectStatus.String(), op.Status.String(), "status")
+			assert.Equal(t, test.stats.itemsRead, op.Results.ItemsRead, "items read")
+			assert.Equal(t, test.stats.readErr, op.Results.ReadErrors, "read errors")
+			assert.Equal(t, test.stats.itemsWritten, op.Results.ItemsWritten, "items written")
+			assert.Equal(t, test.stats.bytesRead.NumBytes, op.Results.BytesRead, "bytes read")
+			assert.Equal(t, test.stats.resourceCount, op.Results.ResourceOwners, "resource owners")
+			assert.Equal(t, test.stats.writeErr, op.Results.WriteErrors, "write errors")
+			assert.Equal(t, now, op.Results.StartedAt, "started at")
+			assert.Less(t, now, op.Results.CompletedAt, "completed at")
+		})
+	}
+}
+
+func (suite *ExportOpSuite) TestNewExportOperation_missingPath() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	_, err := NewExportOperation(
+		ctx,
+		control.Options{},
+		&kopia.Wrapper{},
+		&store.Wrapper{},
+		account.Account{},
+		"foo",
+		selectors.Selector{},
+		control.ExportDestination{},
+		evmock.NewBus())
+	assert.Error(suite.T(), err)
+}
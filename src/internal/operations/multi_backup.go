@@ -0,0 +1,220 @@
+package operations
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	D "github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// MultiBackupOperation backs up many resource owners, running a
+// BackupOperation for each of them.  Up to Parallelism backups run at once.
+// The backups share the tenant's graph rate limit and the repository's kopia
+// writer, so raising the parallelism doesn't multiply the load on either.
+type MultiBackupOperation struct {
+	Parallelism int                  `json:"parallelism"`
+	Results     []backup.OwnerResult `json:"results"`
+
+	owners []string
+	ops    []BackupOperation
+	// errs holds the reason each backup couldn't be constructed, if any.
+	// Run reports those backups as failed, without running them.
+	errs []error
+}
+
+// NewMultiBackupOperation constructs a backup operation for each of the
+// selectors, each of which must select a single resource owner.  A selector
+// that can't be backed up doesn't stop the others; its failure is reported
+// in the results once the operation is run.
+func NewMultiBackupOperation(
+	ctx context.Context,
+	opts control.Options,
+	kw *kopia.Wrapper,
+	sw *store.Wrapper,
+	acct account.Account,
+	sels []selectors.Selector,
+	bus events.Eventer,
+) MultiBackupOperation {
+	mbo := MultiBackupOperation{
+		Parallelism: opts.BackupParallelism,
+		owners:      make([]string, 0, len(sels)),
+		ops:         make([]BackupOperation, 0, len(sels)),
+		errs:        make([]error, 0, len(sels)),
+	}
+
+	if mbo.Parallelism < 1 {
+		mbo.Parallelism = 1
+	}
+
+	for _, sel := range sels {
+		owner, err := discreteOwner(sel)
+		if err != nil {
+			owners, _ := sel.IncludedOwners()
+			mbo.add(strings.Join(owners, ","), BackupOperation{}, errors.Wrap(err, "creating backup"))
+			continue
+		}
+
+		op, err := NewBackupOperation(ctx, opts, kw, sw, acct, sel, bus)
+		if err != nil {
+			mbo.add(owner, BackupOperation{}, errors.Wrapf(err, "creating backup for %s", owner))
+			continue
+		}
+
+		// the progress display is completed once all of the backups finish
+		op.sharedProgress = true
+
+		mbo.add(owner, op, nil)
+	}
+
+	return mbo
+}
+
+// discreteOwner returns the single resource owner included in the selector.
+func discreteOwner(sel selectors.Selector) (string, error) {
+	ros, err := sel.ResourceOwners()
+	if err != nil {
+		return "", errors.Wrap(err, "getting resource owners")
+	}
+
+	if len(ros.Includes) != 1 || len(ros.Filters) > 0 {
+		return "", errors.New("each backup must select a single resource owner")
+	}
+
+	return ros.Includes[0], nil
+}
+
+func (mbo *MultiBackupOperation) add(owner string, op BackupOperation, err error) {
+	mbo.owners = append(mbo.owners, owner)
+	mbo.ops = append(mbo.ops, op)
+	mbo.errs = append(mbo.errs, err)
+}
+
+// Run backs up each of the resource owners.  A failed backup doesn't stop
+// the others; the error lists every resource owner that couldn't be backed
+// up.  Results holds the outcome for each resource owner, in the order they
+// were provided.
+func (mbo *MultiBackupOperation) Run(ctx context.Context) error {
+	ctx, end := D.Span(ctx, "operations:multiBackup:run")
+	defer end()
+
+	defer observe.Complete()
+
+	mbo.Results = make([]backup.OwnerResult, len(mbo.ops))
+	for i, owner := range mbo.owners {
+		mbo.Results[i] = backup.OwnerResult{ResourceOwner: owner, Status: Unknown.String()}
+	}
+
+	return runConcurrently(ctx, len(mbo.ops), mbo.Parallelism, func(ctx context.Context, i int) error {
+		var (
+			op    = &mbo.ops[i]
+			owner = mbo.owners[i]
+			start = time.Now()
+		)
+
+		if err := mbo.errs[i]; err != nil {
+			mbo.Results[i] = backup.OwnerResult{
+				ResourceOwner: owner,
+				Status:        Failed.String(),
+				Error:         err.Error(),
+			}
+
+			return err
+		}
+
+		logger.Ctx(ctx).Infow("backing up resource owner", "resource_owner", owner)
+
+		err := op.Run(ctx)
+		mbo.Results[i] = ownerResult(owner, op, err, time.Since(start))
+
+		return errors.Wrapf(err, "backing up %s", owner)
+	})
+}
+
+// ownerResult summarizes the backup operation.
+func ownerResult(owner string, op *BackupOperation, err error, dur time.Duration) backup.OwnerResult {
+	r := backup.OwnerResult{
+		ResourceOwner: owner,
+		Status:        op.Status.String(),
+		ItemsWritten:  op.Results.ItemsWritten,
+		BytesUploaded: op.Results.BytesUploaded,
+		FailedItems:   len(op.Results.Failures),
+		Duration:      dur,
+	}
+
+	// backups that failed before starting aren't stored
+	if op.Status != Failed {
+		r.BackupID = op.Results.BackupID
+	}
+
+	if err != nil {
+		r.Error = err.Error()
+	}
+
+	return r
+}
+
+// runConcurrently calls fn for each index in [0, n), with up to parallelism
+// calls running at once.  Returns the errors of all calls.  Calls that
+// haven't started when the context is cancelled are skipped.
+func runConcurrently(
+	ctx context.Context,
+	n, parallelism int,
+	fn func(ctx context.Context, i int) error,
+) error {
+	var (
+		errs        *multierror.Error
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		semaphoreCh = make(chan struct{}, parallelism)
+	)
+
+	defer close(semaphoreCh)
+
+	appendErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = multierror.Append(errs, err)
+	}
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			appendErr(errors.Wrap(ctx.Err(), "starting backups"))
+			wg.Wait()
+
+			return errs.ErrorOrNil()
+
+		case semaphoreCh <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphoreCh }()
+
+			if err := fn(ctx, i); err != nil {
+				appendErr(err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,216 @@
+package operations
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	evmock "github.com/alcionai/corso/src/internal/events/mock"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+type MultiBackupOpSuite struct {
+	suite.Suite
+}
+
+func TestMultiBackupOpSuite(t *testing.T) {
+	suite.Run(t, new(MultiBackupOpSuite))
+}
+
+func (suite *MultiBackupOpSuite) TestRunConcurrently() {
+	var (
+		t      = suite.T()
+		mu     sync.Mutex
+		called = map[int]bool{}
+		active int
+		most   int
+	)
+
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	err := runConcurrently(ctx, 10, 3, func(ctx context.Context, i int) error {
+		mu.Lock()
+		called[i] = true
+		active++
+
+		if active > most {
+			most = active
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+
+		if i%4 == 0 {
+			return errors.Errorf("failed %d", i)
+		}
+
+		return nil
+	})
+
+	assert.Len(t, called, 10)
+	assert.LessOrEqual(t, most, 3)
+
+	// 0, 4, and 8 fail without stopping the others
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed 0")
+	assert.Contains(t, err.Error(), "failed 4")
+	assert.Contains(t, err.Error(), "failed 8")
+}
+
+func (suite *MultiBackupOpSuite) TestRunConcurrently_cancelled() {
+	var (
+		t           = suite.T()
+		ctx, cancel = context.WithCancel(context.Background())
+		mu          sync.Mutex
+		calls       int
+	)
+
+	err := runConcurrently(ctx, 10, 1, func(ctx context.Context, i int) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		cancel()
+
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Less(t, calls, 10)
+}
+
+func (suite *MultiBackupOpSuite) TestDiscreteOwner() {
+	table := []struct {
+		name      string
+		sel       func() selectors.Selector
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "single owner",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup()
+				sel.Include(sel.MailFolders([]string{"alice"}, selectors.Any()))
+
+				return sel.Selector
+			},
+			expect:    "alice",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "many owners",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeBackup()
+				sel.Include(sel.MailFolders([]string{"alice", "bob"}, selectors.Any()))
+
+				return sel.Selector
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "any owner",
+			sel: func() selectors.Selector {
+				sel := selectors.NewOneDriveBackup()
+				sel.Include(sel.Users(selectors.Any()))
+
+				return sel.Selector
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			owner, err := discreteOwner(test.sel())
+			test.expectErr(t, err)
+			assert.Equal(t, test.expect, owner)
+		})
+	}
+}
+
+func ownerSelectors(owners ...[]string) []selectors.Selector {
+	sels := []selectors.Selector{}
+
+	for _, os := range owners {
+		sel := selectors.NewExchangeBackup()
+		sel.Include(sel.MailFolders(os, selectors.Any()))
+		sels = append(sels, sel.Selector)
+	}
+
+	return sels
+}
+
+func (suite *MultiBackupOpSuite) TestNewMultiBackupOperation_invalidSelector() {
+	var (
+		t    = suite.T()
+		sels = ownerSelectors([]string{"alice", "bob"}, []string{"carol"})
+	)
+
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	mbo := NewMultiBackupOperation(
+		ctx,
+		control.Options{},
+		&kopia.Wrapper{},
+		&store.Wrapper{},
+		account.Account{},
+		sels,
+		evmock.NewBus())
+
+	// the invalid selector doesn't keep the other owner from backing up
+	assert.Equal(t, []string{"alice,bob", "carol"}, mbo.owners)
+	require.Len(t, mbo.errs, 2)
+	assert.Error(t, mbo.errs[0])
+	assert.NoError(t, mbo.errs[1])
+}
+
+func (suite *MultiBackupOpSuite) TestMultiBackupOperation_Run_unconstructed() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	// without a kopia connection, none of the backups can be constructed
+	mbo := NewMultiBackupOperation(
+		ctx,
+		control.Options{},
+		nil,
+		nil,
+		account.Account{},
+		ownerSelectors([]string{"alice", "bob"}, []string{"carol"}),
+		evmock.NewBus())
+
+	err := mbo.Run(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "carol")
+
+	require.Len(t, mbo.Results, 2)
+
+	for i, owner := range []string{"alice,bob", "carol"} {
+		r := mbo.Results[i]
+		assert.Equal(t, owner, r.ResourceOwner)
+		assert.Equal(t, Failed.String(), r.Status, owner)
+		assert.NotEmpty(t, r.Error, owner)
+		assert.Empty(t, r.BackupID, owner)
+	}
+}
@@ -46,6 +46,7 @@ type RestoreResults struct {
 	stats.Errs
 	stats.ReadWrites
 	stats.StartAndEndTime
+	stats.Throttling
 }
 
 // NewRestoreOperation constructs and validates a restore operation.
@@ -90,6 +91,7 @@ type restoreStats struct {
 	resourceCount     int
 	started           bool
 	readErr, writeErr error
+	throttling        stats.Throttling
 
 	// a transient value only used to pair up start-end events.
 	restoreID string
@@ -126,11 +128,12 @@ func (op *RestoreOperation) Run(ctx context.Context) (restoreDetails *details.De
 		return nil, err
 	}
 
+	// only the entries the selector includes are kept as the details are read.
 	deets, err := streamstore.New(
 		op.kopia,
 		op.account.ID(),
 		op.Selectors.PathService(),
-	).ReadBackupDetails(ctx, dID)
+	).ReduceBackupDetails(ctx, dID, op.Selectors.Reduce)
 	if err != nil {
 		err = errors.Wrap(err, "getting backup details data for restore")
 		opStats.readErr = err
@@ -150,7 +153,7 @@ func (op *RestoreOperation) Run(ctx context.Context) (restoreDetails *details.De
 		},
 	)
 
-	paths, err := formatDetailsForRestoration(ctx, op.Selectors, deets)
+	paths, err := formatDetailsForRestoration(deets)
 	if err != nil {
 		opStats.readErr = err
 		return nil, err
@@ -171,6 +174,9 @@ func (op *RestoreOperation) Run(ctx context.Context) (restoreDetails *details.De
 	}
 	kopiaComplete <- struct{}{}
 
+	// Items fail to read if their content differs from what was backed up.
+	kopia.VerifyContentHashes(dcs, deets.ContentHashes())
+
 	opStats.cs = dcs
 	opStats.resourceCount = len(data.ResourceOwnerSet(dcs))
 
@@ -180,11 +186,15 @@ func (op *RestoreOperation) Run(ctx context.Context) (restoreDetails *details.De
 		return nil, opStats.readErr
 	}
 
+	defer func() {
+		opStats.throttling = gc.ThrottleStats()
+	}()
+
 	restoreComplete, closer := observe.MessageWithCompletion("Restoring data:")
 	defer closer()
 	defer close(restoreComplete)
 
-	restoreDetails, err = gc.RestoreDataCollections(ctx, op.Selectors, op.Destination, dcs)
+	restoreDetails, err = gc.RestoreDataCollections(ctx, op.Selectors, op.Destination, op.Options, dcs)
 	if err != nil {
 		err = errors.Wrap(err, "restoring service data")
 		opStats.writeErr = err
@@ -210,6 +220,8 @@ func (op *RestoreOperation) persistResults(
 	op.Results.StartedAt = started
 	op.Results.CompletedAt = time.Now()
 
+	op.Results.Throttling = opStats.throttling
+
 	op.Status = Completed
 
 	if !opStats.started {
@@ -256,21 +268,12 @@ func (op *RestoreOperation) persistResults(
 	return nil
 }
 
-// formatDetailsForRestoration reduces the provided detail entries according to the
-// selector specifications.
-func formatDetailsForRestoration(
-	ctx context.Context,
-	sel selectors.Selector,
-	deets *details.Details,
-) ([]path.Path, error) {
-	fds, err := sel.Reduce(ctx, deets)
-	if err != nil {
-		return nil, err
-	}
-
+// formatDetailsForRestoration returns the paths of the items in the
+// details, which are already reduced by the selector.
+func formatDetailsForRestoration(deets *details.Details) ([]path.Path, error) {
 	var (
 		errs     *multierror.Error
-		fdsPaths = fds.Paths()
+		fdsPaths = deets.Paths()
 		paths    = make([]path.Path, len(fdsPaths))
 	)
 
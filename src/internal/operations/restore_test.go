@@ -159,7 +159,7 @@ func (suite *RestoreOpIntegrationSuite) SetupSuite() {
 	st := tester.NewPrefixedS3Storage(t)
 
 	k := kopia.NewConn(st)
-	require.NoError(t, k.Initialize(ctx))
+	require.NoError(t, k.Initialize(ctx, control.ObjectLock{}))
 
 	suite.kopiaCloser = func(ctx context.Context) {
 		k.Close(ctx)
@@ -34,3 +34,22 @@ type ByteCounter struct {
 func (bc *ByteCounter) Count(i int64) {
 	atomic.AddInt64(&bc.NumBytes, i)
 }
+
+// Throttling tracks the requests that were slowed down by the service's
+// throttling limits, or by rate limiting to stay within them.
+type Throttling struct {
+	ThrottledRequests int           `json:"throttledRequests,omitempty"`
+	Retries           int           `json:"retries,omitempty"`
+	RetryWait         time.Duration `json:"retryWait,omitempty"`
+	RateLimitWait     time.Duration `json:"rateLimitWait,omitempty"`
+}
+
+// Since returns the throttling that occurred after the earlier snapshot.
+func (t Throttling) Since(earlier Throttling) Throttling {
+	return Throttling{
+		ThrottledRequests: t.ThrottledRequests - earlier.ThrottledRequests,
+		Retries:           t.Retries - earlier.Retries,
+		RetryWait:         t.RetryWait - earlier.RetryWait,
+		RateLimitWait:     t.RateLimitWait - earlier.RateLimitWait,
+	}
+}
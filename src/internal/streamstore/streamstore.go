@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/alcionai/corso/src/internal/kopia"
 	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
@@ -38,109 +40,439 @@ const (
 	// collectionPurposeDetails is used to indicate
 	// what the collection is being used for
 	collectionPurposeDetails = "details"
+	// detailsWriterBuffer is the number of chunks that can wait to
+	// be stored before writing more chunks blocks.
+	detailsWriterBuffer = 4
 )
 
+// detailsManifest is the item stored alongside the chunks of a details
+// object, naming the chunks in the order they were written.  Details
+// stored before they were chunked hold all of their entries in the
+// manifest instead.
+type detailsManifest struct {
+	details.DetailsModel
+	Chunks []string `json:"chunks,omitempty"`
+}
+
 // WriteBackupDetails persists a `details.Details`
 // object in the stream store
 func (ss *streamStore) WriteBackupDetails(
 	ctx context.Context,
 	backupDetails *details.Details,
 ) (string, error) {
-	// construct the path of the container for the `details` item
-	p, err := path.Builder{}.
-		ToStreamStorePath(
-			ss.tenant,
-			collectionPurposeDetails,
-			ss.service,
-			false,
-		)
+	dw, err := ss.NewDetailsWriter(ctx)
 	if err != nil {
 		return "", err
 	}
 
-	// TODO: We could use an io.Pipe here to avoid a double copy but that
-	// makes error handling a bit complicated
-	dbytes, err := json.Marshal(backupDetails)
+	ents := backupDetails.Entries
+
+	for i := 0; i < len(ents); i += details.DefaultChunkSize {
+		end := i + details.DefaultChunkSize
+		if end > len(ents) {
+			end = len(ents)
+		}
+
+		err := dw.WriteChunk(details.Chunk{
+			Name:         "chunk-" + strconv.Itoa(i/details.DefaultChunkSize),
+			DetailsModel: details.DetailsModel{Entries: ents[i:end]},
+		})
+		if err != nil {
+			dw.Abort(ctx)
+			return "", err
+		}
+	}
+
+	return dw.Close()
+}
+
+// ReadBackupDetails reads the specified details object
+// from the kopia repository
+func (ss *streamStore) ReadBackupDetails(
+	ctx context.Context,
+	detailsID string,
+) (*details.Details, error) {
+	it, err := ss.IterateBackupDetails(ctx, detailsID)
 	if err != nil {
-		return "", errors.Wrap(err, "marshalling backup details")
+		return nil, err
+	}
+
+	d := &details.Details{}
+
+	for it.Next(ctx) {
+		d.Entries = append(d.Entries, it.Entry())
 	}
 
-	dc := &streamCollection{
-		folderPath: p,
-		item: &streamItem{
-			name: detailsItemName,
-			data: dbytes,
-		},
+	if err := it.Err(); err != nil {
+		return nil, err
 	}
 
-	backupStats, _, err := ss.kw.BackupCollections(ctx, nil, []data.Collection{dc}, ss.service, nil, nil)
+	return d, nil
+}
+
+// IterateBackupDetails returns an iterator over the entries of the
+// specified details object, which reads the details one chunk at a time.
+func (ss *streamStore) IterateBackupDetails(
+	ctx context.Context,
+	detailsID string,
+) (*DetailsIterator, error) {
+	var m detailsManifest
+
+	err := ss.readItems(ctx, detailsID, []string{detailsItemName}, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&m)
+	})
 	if err != nil {
-		return "", nil
+		return nil, err
 	}
 
-	return backupStats.SnapshotID, nil
+	return &DetailsIterator{
+		ss:         ss,
+		snapshotID: detailsID,
+		chunks:     m.Chunks,
+		entries:    m.Entries,
+	}, nil
 }
 
-// ReadBackupDetails reads the specified details object
-// from the kopia repository
-func (ss *streamStore) ReadBackupDetails(
+// ReadDetailsChunks reads the named chunks of the specified details
+// object, and merges their entries.
+func (ss *streamStore) ReadDetailsChunks(
 	ctx context.Context,
 	detailsID string,
+	chunks []string,
 ) (*details.Details, error) {
-	// construct the path for the `details` item
-	detailsPath, err := path.Builder{}.
-		Append(detailsItemName).
+	d := &details.Details{}
+
+	if len(chunks) == 0 {
+		return d, nil
+	}
+
+	err := ss.readItems(ctx, detailsID, chunks, func(r io.Reader) error {
+		var dm details.DetailsModel
+
+		if err := json.NewDecoder(r).Decode(&dm); err != nil {
+			return err
+		}
+
+		d.Entries = append(d.Entries, dm.Entries...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Reducer returns the entries of the details that are kept.  A selector's
+// Reduce is a Reducer.
+type Reducer func(context.Context, *details.Details) (*details.Details, error)
+
+// ReduceBackupDetails reads the specified details object one chunk at a
+// time, and merges the entries of each chunk kept by the reducer.  Only the
+// kept entries, and a single chunk, are held in memory at a time.
+func (ss *streamStore) ReduceBackupDetails(
+	ctx context.Context,
+	detailsID string,
+	reduce Reducer,
+) (*details.Details, error) {
+	var m detailsManifest
+
+	err := ss.readItems(ctx, detailsID, []string{detailsItemName}, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&m)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// details stored before they were chunked hold every entry in the manifest.
+	if len(m.Chunks) == 0 {
+		return reduce(ctx, &details.Details{DetailsModel: m.DetailsModel})
+	}
+
+	return ss.ReduceDetailsChunks(ctx, detailsID, m.Chunks, reduce)
+}
+
+// ReduceDetailsChunks reads the named chunks of the specified details
+// object one at a time, and merges the entries of each chunk kept by the
+// reducer.
+func (ss *streamStore) ReduceDetailsChunks(
+	ctx context.Context,
+	detailsID string,
+	chunks []string,
+	reduce Reducer,
+) (*details.Details, error) {
+	d := &details.Details{}
+
+	for _, c := range chunks {
+		cd, err := ss.ReadDetailsChunks(ctx, detailsID, []string{c})
+		if err != nil {
+			return nil, err
+		}
+
+		rd, err := reduce(ctx, cd)
+		if err != nil {
+			return nil, err
+		}
+
+		d.Entries = append(d.Entries, rd.Entries...)
+	}
+
+	return d, nil
+}
+
+// DetailsIterator iterates over the entries of a details object.  Only a
+// single chunk of the details is held in memory at a time.
+type DetailsIterator struct {
+	ss         *streamStore
+	snapshotID string
+	chunks     []string
+	entries    []details.DetailsEntry
+	cur        details.DetailsEntry
+	err        error
+}
+
+// Next advances the iterator to the next entry, reading the next chunk of
+// the details if needed.  Returns false once there are no more entries, or
+// reading a chunk failed.
+func (it *DetailsIterator) Next(ctx context.Context) bool {
+	for len(it.entries) == 0 {
+		if it.err != nil || len(it.chunks) == 0 {
+			return false
+		}
+
+		d, err := it.ss.ReadDetailsChunks(ctx, it.snapshotID, it.chunks[:1])
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.chunks = it.chunks[1:]
+		it.entries = d.Entries
+	}
+
+	it.cur, it.entries = it.entries[0], it.entries[1:]
+
+	return true
+}
+
+// Entry returns the entry the iterator is at.
+func (it *DetailsIterator) Entry() details.DetailsEntry {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *DetailsIterator) Err() error {
+	return it.err
+}
+
+// DetailsWriter stores the chunks of a details object in the stream store
+// as they're written, so that the details never need to be held in memory
+// all at once.  Writing blocks while the writer's buffer of chunks is full,
+// until kopia takes the next chunk.  Chunked details write from within the
+// Add that fills a chunk, after releasing the details' lock, so backpressure
+// only pauses the items whose entries fill chunks.
+// Chunks must not be written concurrently.  Either Close or Abort must be
+// called once the writer is done with.
+type DetailsWriter struct {
+	kw         *kopia.Wrapper
+	items      chan data.Stream
+	chunks     []string
+	done       chan struct{}
+	cancel     context.CancelFunc
+	snapshotID string
+	err        error
+}
+
+// NewDetailsWriter starts storing a details object, whose chunks are
+// handed to the returned writer.
+func (ss *streamStore) NewDetailsWriter(ctx context.Context) (*DetailsWriter, error) {
+	// construct the path of the container for the items
+	p, err := path.Builder{}.
 		ToStreamStorePath(
 			ss.tenant,
 			collectionPurposeDetails,
 			ss.service,
-			true,
+			false,
 		)
 	if err != nil {
 		return nil, err
 	}
 
-	var bc stats.ByteCounter
+	ctx, cancel := context.WithCancel(ctx)
+
+	dw := &DetailsWriter{
+		kw:     ss.kw,
+		items:  make(chan data.Stream, detailsWriterBuffer),
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	dc := &streamCollection{folderPath: p, items: dw.items}
+
+	go func() {
+		defer close(dw.done)
+
+		backupStats, _, err := ss.kw.BackupCollections(
+			ctx,
+			nil,
+			[]data.Collection{dc},
+			ss.service,
+			nil,
+			nil,
+			nil)
+		if err != nil {
+			dw.err = err
+			return
+		}
+
+		dw.snapshotID = backupStats.SnapshotID
+	}()
+
+	return dw, nil
+}
+
+// WriteChunk stores the chunk.
+func (dw *DetailsWriter) WriteChunk(c details.Chunk) error {
+	dbytes, err := json.Marshal(c.DetailsModel)
+	if err != nil {
+		return errors.Wrap(err, "marshalling backup details")
+	}
+
+	if err := dw.put(&streamItem{name: c.Name, data: dbytes}); err != nil {
+		return err
+	}
+
+	dw.chunks = append(dw.chunks, c.Name)
+
+	return nil
+}
+
+// Close finishes storing the details, and returns the ID of the
+// snapshot holding them.
+func (dw *DetailsWriter) Close() (string, error) {
+	defer dw.cancel()
 
-	dcs, err := ss.kw.RestoreMultipleItems(ctx, detailsID, []path.Path{detailsPath}, &bc)
+	mbytes, err := json.Marshal(detailsManifest{Chunks: dw.chunks})
 	if err != nil {
-		return nil, errors.Wrap(err, "retrieving backup details data")
+		err = errors.Wrap(err, "marshalling backup details")
+	} else {
+		err = dw.put(&streamItem{name: detailsItemName, data: mbytes})
 	}
 
-	// Expect only 1 data collection
-	if len(dcs) != 1 {
-		return nil, errors.Errorf("expected 1 details data collection: %d", len(dcs))
+	close(dw.items)
+	<-dw.done
+
+	if err != nil {
+		return "", err
+	}
+
+	if dw.err != nil {
+		return "", errors.Wrap(dw.err, "storing backup details")
+	}
+
+	return dw.snapshotID, nil
+}
+
+// Abort stops storing the details, and deletes the snapshot holding the
+// chunks written so far.  Cancelling the upload instead could leave an
+// incomplete snapshot, and its checkpoints, that nothing cleans up, so the
+// snapshot is finished before it's deleted.
+func (dw *DetailsWriter) Abort(ctx context.Context) {
+	defer dw.cancel()
+
+	close(dw.items)
+	<-dw.done
+
+	if len(dw.snapshotID) == 0 {
+		return
+	}
+
+	if err := dw.kw.DeleteSnapshot(ctx, dw.snapshotID); err != nil {
+		logger.Ctx(ctx).Warnw("deleting aborted backup details", "snapshot_id", dw.snapshotID, "error", err)
+	}
+}
+
+// put hands the item to the snapshot being stored, unless storing it
+// already ended.
+func (dw *DetailsWriter) put(item *streamItem) error {
+	select {
+	case dw.items <- item:
+		return nil
+
+	case <-dw.done:
+		if dw.err != nil {
+			return errors.Wrap(dw.err, "storing backup details")
+		}
+
+		return errors.New("storing backup details ended early")
 	}
+}
+
+// readItems reads the named items from the snapshot, and hands the
+// content of each to fn.
+func (ss *streamStore) readItems(
+	ctx context.Context,
+	snapshotID string,
+	names []string,
+	fn func(io.Reader) error,
+) error {
+	ps := make([]path.Path, 0, len(names))
+
+	for _, name := range names {
+		// construct the path for the item
+		p, err := path.Builder{}.
+			Append(name).
+			ToStreamStorePath(
+				ss.tenant,
+				collectionPurposeDetails,
+				ss.service,
+				true,
+			)
+		if err != nil {
+			return err
+		}
 
-	dc := dcs[0]
+		ps = append(ps, p)
+	}
+
+	var bc stats.ByteCounter
 
-	var d details.Details
+	dcs, err := ss.kw.RestoreMultipleItems(ctx, snapshotID, ps, &bc)
+	if err != nil {
+		return errors.Wrap(err, "retrieving backup details data")
+	}
 
-	found := false
-	items := dc.Items()
+	var found int
 
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, errors.New("context cancelled waiting for backup details data")
+	for _, dc := range dcs {
+		items := dc.Items()
 
-		case itemData, ok := <-items:
-			if !ok {
-				if !found {
-					return nil, errors.New("no backup details found")
+	readItems:
+		for {
+			select {
+			case <-ctx.Done():
+				return errors.New("context cancelled waiting for backup details data")
+
+			case itemData, ok := <-items:
+				if !ok {
+					break readItems
 				}
 
-				return &d, nil
-			}
+				if err := fn(itemData.ToReader()); err != nil {
+					return errors.Wrap(err, "failed to decode details data from repository")
+				}
 
-			err := json.NewDecoder(itemData.ToReader()).Decode(&d)
-			if err != nil {
-				return nil, errors.Wrap(err, "failed to decode details data from repository")
+				found++
 			}
-
-			found = true
 		}
 	}
+
+	if found != len(names) {
+		return errors.Errorf("expected %d backup details items, found %d", len(names), found)
+	}
+
+	return nil
 }
 
 // DeleteBackupDetails deletes the specified details object from the kopia repository
@@ -157,12 +489,12 @@ func (ss *streamStore) DeleteBackupDetails(
 }
 
 // streamCollection is a data.Collection used to persist
-// a single data stream
+// data streams as they're produced
 type streamCollection struct {
 	// folderPath indicates what level in the hierarchy this collection
 	// represents
 	folderPath path.Path
-	item       *streamItem
+	items      <-chan data.Stream
 }
 
 func (dc *streamCollection) FullPath() path.Path {
@@ -177,14 +509,10 @@ func (dc *streamCollection) State() data.CollectionState {
 	return data.NewState
 }
 
-// Items() always returns a channel with a single data.Stream
-// representing the object to be persisted
+// Items() returns a channel with a data.Stream for each
+// object to be persisted
 func (dc *streamCollection) Items() <-chan data.Stream {
-	items := make(chan data.Stream, 1)
-	defer close(items)
-	items <- dc.item
-
-	return items
+	return dc.items
 }
 
 type streamItem struct {
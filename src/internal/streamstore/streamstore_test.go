@@ -1,6 +1,8 @@
 package streamstore
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,6 +12,7 @@ import (
 	"github.com/alcionai/corso/src/internal/kopia"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
@@ -37,7 +40,7 @@ func (suite *StreamStoreIntegrationSuite) TestDetails() {
 	st := tester.NewPrefixedS3Storage(t)
 
 	k := kopia.NewConn(st)
-	require.NoError(t, k.Initialize(ctx))
+	require.NoError(t, k.Initialize(ctx, control.ObjectLock{}))
 
 	defer k.Close(ctx)
 
@@ -73,3 +76,101 @@ func (suite *StreamStoreIntegrationSuite) TestDetails() {
 	assert.NotNil(t, readDeets.Entries[0].Exchange)
 	assert.Equal(t, *deets.Entries[0].Exchange, *readDeets.Entries[0].Exchange)
 }
+
+func (suite *StreamStoreIntegrationSuite) TestDetailsWriter() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	// need to initialize the repository before we can test connecting to it.
+	st := tester.NewPrefixedS3Storage(t)
+
+	k := kopia.NewConn(st)
+	require.NoError(t, k.Initialize(ctx, control.ObjectLock{}))
+
+	defer k.Close(ctx)
+
+	kw, err := kopia.NewWrapper(k)
+	require.NoError(t, err)
+
+	defer kw.Close(ctx)
+
+	ss := New(kw, "tenant", path.ExchangeService)
+
+	dw, err := ss.NewDetailsWriter(ctx)
+	require.NoError(t, err)
+
+	deets := details.NewChunked(2, dw)
+	refs := []string{}
+
+	for _, ref := range []string{"a1", "b1", "a2", "a3"} {
+		p, err := path.Builder{}.
+			Append("Inbox", ref).
+			ToDataLayerExchangePathForCategory("tenant", ref[:1], path.EmailCategory, true)
+		require.NoError(t, err)
+
+		deets.Add(p.String(), ref, "parentref", true, details.ItemInfo{})
+		refs = append(refs, p.String())
+	}
+
+	idx, err := deets.Flush()
+	require.NoError(t, err)
+
+	id, err := dw.Close()
+	require.NoError(t, err)
+	require.NotEmpty(t, id)
+
+	it, err := ss.IterateBackupDetails(ctx, id)
+	require.NoError(t, err)
+
+	readRefs := []string{}
+	for it.Next(ctx) {
+		readRefs = append(readRefs, it.Entry().RepoRef)
+	}
+
+	require.NoError(t, it.Err())
+	assert.ElementsMatch(t, refs, readRefs)
+
+	shards := idx.Select([]string{"a"}, nil)
+	require.Len(t, shards, 1)
+
+	readDeets, err := ss.ReadDetailsChunks(ctx, id, shards[0].Chunks)
+	require.NoError(t, err)
+
+	readRefs = []string{}
+	for _, ent := range readDeets.Entries {
+		readRefs = append(readRefs, ent.RepoRef)
+	}
+
+	assert.ElementsMatch(t, []string{refs[0], refs[2], refs[3]}, readRefs)
+
+	// each chunk is reduced on its own, keeping only the first items.
+	var reduced int
+
+	readDeets, err = ss.ReduceBackupDetails(
+		ctx,
+		id,
+		func(_ context.Context, d *details.Details) (*details.Details, error) {
+			reduced++
+
+			kept := &details.Details{}
+
+			for _, ent := range d.Entries {
+				if strings.HasSuffix(ent.ShortRef, "1") {
+					kept.Entries = append(kept.Entries, ent)
+				}
+			}
+
+			return kept, nil
+		})
+	require.NoError(t, err)
+
+	readRefs = []string{}
+	for _, ent := range readDeets.Entries {
+		readRefs = append(readRefs, ent.RepoRef)
+	}
+
+	assert.Equal(t, 3, reduced)
+	assert.ElementsMatch(t, []string{refs[0], refs[1]}, readRefs)
+}
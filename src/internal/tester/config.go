@@ -19,6 +19,10 @@ const (
 	TestCfgPrefix          = "prefix"
 	TestCfgStorageProvider = "provider"
 
+	// S3 config for a bucket with object lock enabled
+	TestCfgObjectLockBucket   = "objectlockbucket"
+	TestCfgObjectLockEndpoint = "objectlockendpoint"
+
 	// M365 config
 	TestCfgAzureTenantID    = "azure_tenantid"
 	TestCfgSiteID           = "m365siteid"
@@ -37,6 +41,8 @@ const (
 	EnvCorsoM365LoadTestUserID      = "CORSO_M365_LOAD_TEST_USER_ID"
 	EnvCorsoM365LoadTestOrgUsers    = "CORSO_M365_LOAD_TEST_ORG_USERS"
 	EnvCorsoTestConfigFilePath      = "CORSO_TEST_CONFIG_FILE"
+	EnvCorsoObjectLockTestBucket    = "CORSO_OBJECT_LOCK_TEST_BUCKET"
+	EnvCorsoObjectLockTestEndpoint  = "CORSO_OBJECT_LOCK_TEST_ENDPOINT"
 )
 
 // global to hold the test config results.
@@ -108,6 +114,20 @@ func readTestConfig() (map[string]string, error) {
 	fallbackTo(testEnv, TestCfgBucket, vpr.GetString(TestCfgBucket), "test-corso-repo-init")
 	fallbackTo(testEnv, TestCfgEndpoint, vpr.GetString(TestCfgEndpoint), "s3.amazonaws.com")
 	fallbackTo(testEnv, TestCfgPrefix, vpr.GetString(TestCfgPrefix))
+	fallbackTo(
+		testEnv,
+		TestCfgObjectLockBucket,
+		os.Getenv(EnvCorsoObjectLockTestBucket),
+		vpr.GetString(TestCfgObjectLockBucket),
+		"test-corso-object-lock",
+	)
+	fallbackTo(
+		testEnv,
+		TestCfgObjectLockEndpoint,
+		os.Getenv(EnvCorsoObjectLockTestEndpoint),
+		vpr.GetString(TestCfgObjectLockEndpoint),
+		"localhost:9000",
+	)
 	fallbackTo(testEnv, TestCfgAzureTenantID, os.Getenv(account.AzureTenantID), vpr.GetString(TestCfgAzureTenantID))
 	fallbackTo(
 		testEnv,
@@ -26,6 +26,7 @@ const (
 	CorsoGraphConnectorSharePointTests            = "CORSO_GRAPH_CONNECTOR_SHAREPOINT_TESTS"
 	CorsoKopiaWrapperTests                        = "CORSO_KOPIA_WRAPPER_TESTS"
 	CorsoModelStoreTests                          = "CORSO_MODEL_STORE_TESTS"
+	CorsoObjectLockTests                          = "CORSO_OBJECT_LOCK_TESTS"
 	CorsoOneDriveTests                            = "CORSO_ONE_DRIVE_TESTS"
 	CorsoOperationTests                           = "CORSO_OPERATION_TESTS"
 	CorsoOperationBackupTests                     = "CORSO_OPERATION_BACKUP_TESTS"
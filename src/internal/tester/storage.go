@@ -42,3 +42,32 @@ func NewPrefixedS3Storage(t *testing.T) storage.Storage {
 
 	return st
 }
+
+// NewObjectLockS3Storage returns a storage.Storage object for a MinIO server,
+// reached without TLS, whose bucket has object lock enabled.  The bucket and
+// endpoint are set by the test config, or the CORSO_OBJECT_LOCK_TEST_BUCKET
+// and CORSO_OBJECT_LOCK_TEST_ENDPOINT env vars.  As with NewPrefixedS3Storage,
+// the prefix for the storage path will be unique.
+func NewObjectLockS3Storage(t *testing.T) storage.Storage {
+	now := LogTimeOfTest(t)
+
+	cfg, err := readTestConfig()
+	require.NoError(t, err, "configuring storage from test file")
+
+	st, err := storage.NewStorage(
+		storage.ProviderS3,
+		storage.S3Config{
+			Bucket:      cfg[TestCfgObjectLockBucket],
+			Endpoint:    cfg[TestCfgObjectLockEndpoint],
+			Prefix:      t.Name() + "-" + now,
+			DoNotUseTLS: true,
+		},
+		storage.CommonConfig{
+			Corso:       credentials.GetCorso(),
+			KopiaCfgDir: t.TempDir(),
+		},
+	)
+	require.NoError(t, err, "creating storage")
+
+	return st
+}
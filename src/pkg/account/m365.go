@@ -1,6 +1,8 @@
 package account
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/alcionai/corso/src/pkg/credentials"
@@ -9,11 +11,40 @@ import (
 // config exported name consts
 const (
 	AzureTenantID = "AZURE_TENANT_ID"
+	AzureCloud    = "AZURE_CLOUD"
 )
 
+// Azure cloud consts identify the national cloud that hosts the tenant.
+// Each cloud has its own Azure AD authority and graph endpoint.
+const (
+	AzureCloudPublic       = "public"
+	AzureCloudUSGovGCCHigh = "usgov-gcchigh"
+	AzureCloudUSGovDoD     = "usgov-dod"
+	AzureCloudChina        = "china"
+)
+
+// AzureClouds lists the supported azure clouds.
+var AzureClouds = []string{
+	AzureCloudPublic,
+	AzureCloudUSGovGCCHigh,
+	AzureCloudUSGovDoD,
+	AzureCloudChina,
+}
+
 type M365Config struct {
-	credentials.M365 // requires: ClientID, ClientSecret
+	credentials.M365 // requires: ClientID, and one of ClientSecret or ClientCertPath
 	AzureTenantID    string
+	// AzureCloud is one of the AzureClouds.  Empty means AzureCloudPublic.
+	AzureCloud string
+}
+
+// Cloud returns the normalized name of the azure cloud hosting the tenant.
+func (c M365Config) Cloud() string {
+	if len(c.AzureCloud) == 0 {
+		return AzureCloudPublic
+	}
+
+	return strings.ToLower(c.AzureCloud)
 }
 
 // config key consts
@@ -21,6 +52,10 @@ const (
 	keyAzureClientID     = "azure_clientid"
 	keyAzureClientSecret = "azure_clientSecret"
 	keyAzureTenantID     = "azure_tenantid"
+	keyAzureCloud        = "azure_cloud"
+
+	keyAzureClientCertPath     = "azure_clientCertPath"
+	keyAzureClientCertPassword = "azure_clientCertPassword"
 )
 
 // StringConfig transforms a m365Config struct into a plain
@@ -28,9 +63,12 @@ const (
 // serialize into the map are expected to be strings.
 func (c M365Config) StringConfig() (map[string]string, error) {
 	cfg := map[string]string{
-		keyAzureClientID:     c.AzureClientID,
-		keyAzureClientSecret: c.AzureClientSecret,
-		keyAzureTenantID:     c.AzureTenantID,
+		keyAzureClientID:           c.AzureClientID,
+		keyAzureClientSecret:       c.AzureClientSecret,
+		keyAzureTenantID:           c.AzureTenantID,
+		keyAzureCloud:              c.AzureCloud,
+		keyAzureClientCertPath:     c.AzureClientCertPath,
+		keyAzureClientCertPassword: c.AzureClientCertPassword,
 	}
 
 	return cfg, c.validate()
@@ -52,23 +90,41 @@ func (a Account) M365Config() (M365Config, error) {
 		c.AzureClientID = a.Config[keyAzureClientID]
 		c.AzureClientSecret = a.Config[keyAzureClientSecret]
 		c.AzureTenantID = a.Config[keyAzureTenantID]
+		c.AzureCloud = a.Config[keyAzureCloud]
+		c.AzureClientCertPath = a.Config[keyAzureClientCertPath]
+		c.AzureClientCertPassword = a.Config[keyAzureClientCertPassword]
 	}
 
 	return c, c.validate()
 }
 
 func (c M365Config) validate() error {
-	check := map[string]string{
-		credentials.AzureClientID:     c.AzureClientID,
-		credentials.AzureClientSecret: c.AzureClientSecret,
-		AzureTenantID:                 c.AzureTenantID,
+	if len(c.AzureTenantID) == 0 {
+		return errors.Wrap(errMissingRequired, AzureTenantID)
+	}
+
+	if err := ValidateAzureCloud(c.AzureCloud); err != nil {
+		return err
+	}
+
+	return c.M365.Validate()
+}
+
+// ValidateAzureCloud returns an error if cloud isn't one of the AzureClouds.
+// An empty cloud is valid, and refers to the public cloud.
+func ValidateAzureCloud(cloud string) error {
+	if len(cloud) == 0 {
+		return nil
 	}
 
-	for k, v := range check {
-		if len(v) == 0 {
-			return errors.Wrap(errMissingRequired, k)
+	for _, ac := range AzureClouds {
+		if strings.EqualFold(cloud, ac) {
+			return nil
 		}
 	}
 
-	return nil
+	return errors.Errorf(
+		"unknown azure cloud %q, must be one of: %s",
+		cloud,
+		strings.Join(AzureClouds, ", "))
 }
@@ -25,6 +25,7 @@ var goodM365Config = account.M365Config{
 		AzureClientSecret: "cs",
 	},
 	AzureTenantID: "tid",
+	AzureCloud:    account.AzureCloudUSGovGCCHigh,
 }
 
 func (suite *M365CfgSuite) TestM365Config_Config() {
@@ -39,6 +40,9 @@ func (suite *M365CfgSuite) TestM365Config_Config() {
 		{"azure_clientid", m365.AzureClientID},
 		{"azure_clientSecret", m365.AzureClientSecret},
 		{"azure_tenantid", m365.AzureTenantID},
+		{"azure_cloud", m365.AzureCloud},
+		{"azure_clientCertPath", m365.AzureClientCertPath},
+		{"azure_clientCertPassword", m365.AzureClientCertPassword},
 	}
 	for _, test := range table {
 		assert.Equal(suite.T(), test.expect, c[test.key])
@@ -57,6 +61,51 @@ func (suite *M365CfgSuite) TestAccount_M365Config() {
 	assert.Equal(t, in.AzureClientID, out.AzureClientID)
 	assert.Equal(t, in.AzureClientSecret, out.AzureClientSecret)
 	assert.Equal(t, in.AzureTenantID, out.AzureTenantID)
+	assert.Equal(t, in.AzureCloud, out.AzureCloud)
+}
+
+func (suite *M365CfgSuite) TestM365Config_Cloud() {
+	table := []struct {
+		cloud  string
+		expect string
+	}{
+		{"", account.AzureCloudPublic},
+		{"public", account.AzureCloudPublic},
+		{"USGov-DoD", account.AzureCloudUSGovDoD},
+		{"china", account.AzureCloudChina},
+	}
+	for _, test := range table {
+		suite.T().Run(test.cloud, func(t *testing.T) {
+			cfg := goodM365Config
+			cfg.AzureCloud = test.cloud
+
+			assert.Equal(t, test.expect, cfg.Cloud())
+			assert.NoError(t, account.ValidateAzureCloud(test.cloud))
+		})
+	}
+}
+
+func (suite *M365CfgSuite) TestAccount_M365Config_Certificate() {
+	t := suite.T()
+
+	in := account.M365Config{
+		M365: credentials.M365{
+			AzureClientID:           "cid",
+			AzureClientCertPath:     "/path/to/cert.pfx",
+			AzureClientCertPassword: "pw",
+		},
+		AzureTenantID: "tid",
+	}
+	a, err := account.NewAccount(account.ProviderM365, in)
+	require.NoError(t, err)
+	out, err := a.M365Config()
+	require.NoError(t, err)
+
+	assert.True(t, out.UsesCertificate())
+	assert.Equal(t, in.AzureClientID, out.AzureClientID)
+	assert.Empty(t, out.AzureClientSecret)
+	assert.Equal(t, in.AzureClientCertPath, out.AzureClientCertPath)
+	assert.Equal(t, in.AzureClientCertPassword, out.AzureClientCertPassword)
 }
 
 func makeTestM365Cfg(cid, cs, tid string) account.M365Config {
@@ -78,6 +127,14 @@ func (suite *M365CfgSuite) TestAccount_M365Config_InvalidCases() {
 		{"missing client ID", makeTestM365Cfg("", "cs", "tid")},
 		{"missing client secret", makeTestM365Cfg("cid", "", "tid")},
 		{"missing tenant ID", makeTestM365Cfg("cid", "cs", "")},
+		{
+			"unknown cloud",
+			account.M365Config{
+				M365:          credentials.M365{AzureClientID: "cid", AzureClientSecret: "cs"},
+				AzureTenantID: "tid",
+				AzureCloud:    "moon",
+			},
+		},
 	}
 	for _, test := range table {
 		suite.T().Run(test.name, func(t *testing.T) {
@@ -3,13 +3,18 @@ package backup
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/internal/common"
 	"github.com/alcionai/corso/src/internal/connector/support"
 	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
@@ -25,12 +30,28 @@ type Backup struct {
 	// We store the ModelStoreID since Details is immutable
 	DetailsID string `json:"detailsID"`
 
+	// DetailsIndex describes the shards of the details, split by resource
+	// owner and category.  Backups made before the index was added don't
+	// have one.
+	DetailsIndex *details.Index `json:"detailsIndex,omitempty"`
+
 	// Status of the operation
 	Status string `json:"status"`
 
 	// Selectors used in this operation
 	Selectors selectors.Selector `json:"selectors"`
 
+	// Failures lists the items that were skipped because they couldn't be
+	// backed up.
+	Failures []details.Failure `json:"failures,omitempty"`
+
+	// LegalHold, when set, keeps the backup from being deleted.
+	LegalHold *LegalHold `json:"legalHold,omitempty"`
+
+	// HoldHistory is the audit trail of legal holds set on, and cleared
+	// from, the backup.
+	HoldHistory []HoldEvent `json:"holdHistory,omitempty"`
+
 	// stats are embedded so that the values appear as top-level properties
 	stats.Errs
 	stats.ReadWrites
@@ -40,14 +61,41 @@ type Backup struct {
 // interface compliance checks
 var _ print.Printable = &Backup{}
 
+// ErrLegalHold is returned when deleting a backup that is under legal hold.
+var ErrLegalHold = errors.New("backup is under legal hold")
+
+// LegalHold describes why, and by whom, a backup is held.
+type LegalHold struct {
+	Reason string    `json:"reason"`
+	SetBy  string    `json:"setBy"`
+	SetAt  time.Time `json:"setAt"`
+}
+
+type HoldAction string
+
+const (
+	HoldSet     HoldAction = "set"
+	HoldCleared HoldAction = "cleared"
+)
+
+// HoldEvent records a single change to a backup's legal hold.
+type HoldEvent struct {
+	Action HoldAction `json:"action"`
+	Reason string     `json:"reason"`
+	By     string     `json:"by"`
+	At     time.Time  `json:"at"`
+}
+
 func New(
 	snapshotID, detailsID, status string,
 	id model.StableID,
 	selector selectors.Selector,
 	rw stats.ReadWrites,
 	se stats.StartAndEndTime,
+	failures []details.Failure,
+	userTags map[string]string,
 ) *Backup {
-	return &Backup{
+	b := &Backup{
 		BaseModel: model.BaseModel{
 			ID: id,
 			Tags: map[string]string{
@@ -59,9 +107,101 @@ func New(
 		DetailsID:       detailsID,
 		Status:          status,
 		Selectors:       selector,
+		Failures:        failures,
 		ReadWrites:      rw,
 		StartAndEndTime: se,
 	}
+
+	b.UpdateUserTags(userTags, nil)
+
+	return b
+}
+
+// UserTags returns the tags that users attached to the backup.
+func (b Backup) UserTags() map[string]string {
+	tags := map[string]string{}
+
+	for k, v := range b.Tags {
+		if strings.HasPrefix(k, model.UserTagPrefix) {
+			tags[strings.TrimPrefix(k, model.UserTagPrefix)] = v
+		}
+	}
+
+	return tags
+}
+
+// UpdateUserTags attaches the set tags to the backup, replacing the values
+// of any tags with the same keys, and removes the tags with the remove keys.
+// Changes must be saved to the model store to persist.
+func (b *Backup) UpdateUserTags(set map[string]string, remove []string) {
+	if b.Tags == nil {
+		b.Tags = map[string]string{}
+	}
+
+	for k, v := range set {
+		b.Tags[model.UserTagPrefix+k] = v
+	}
+
+	for _, k := range remove {
+		delete(b.Tags, model.UserTagPrefix+k)
+	}
+}
+
+// OnHold reports whether the backup is under legal hold.
+func (b Backup) OnHold() bool {
+	return b.LegalHold != nil
+}
+
+// SetHold places the backup under legal hold, replacing any existing hold.
+// Changes must be saved to the model store to persist.
+func (b *Backup) SetHold(reason, by string, at time.Time) {
+	b.LegalHold = &LegalHold{Reason: reason, SetBy: by, SetAt: at}
+	b.HoldHistory = append(b.HoldHistory, HoldEvent{HoldSet, reason, by, at})
+}
+
+// ClearHold releases the backup from legal hold.  Returns an error if the
+// backup isn't held.  Changes must be saved to the model store to persist.
+func (b *Backup) ClearHold(reason, by string, at time.Time) error {
+	if !b.OnHold() {
+		return errors.Errorf("backup %s is not under legal hold", b.ID)
+	}
+
+	b.LegalHold = nil
+	b.HoldHistory = append(b.HoldHistory, HoldEvent{HoldCleared, reason, by, at})
+
+	return nil
+}
+
+// ResourceOwners returns the resource owners whose data the backup holds.
+// If the backup has a details index, the owners come from the index.
+// Otherwise they come from the backup's selector, and all is true when the
+// selector included every resource owner.
+func (b Backup) ResourceOwners() (owners []string, all bool) {
+	if b.DetailsIndex != nil {
+		set := map[string]struct{}{}
+
+		for _, s := range b.DetailsIndex.Shards {
+			if len(s.ResourceOwner) > 0 {
+				set[s.ResourceOwner] = struct{}{}
+			}
+		}
+
+		owners = make([]string, 0, len(set))
+		for o := range set {
+			owners = append(owners, o)
+		}
+
+		sort.Strings(owners)
+
+		return owners, false
+	}
+
+	ros, err := b.Selectors.ResourceOwners()
+	if err != nil || ros.IncludesAny || len(ros.Includes) == 0 {
+		return nil, true
+	}
+
+	return ros.Includes, false
 }
 
 // --------------------------------------------------------------------------------
@@ -91,12 +231,16 @@ func PrintAll(ctx context.Context, bs []*Backup) {
 type Printable struct {
 	ID            model.StableID      `json:"id"`
 	ErrorCount    int                 `json:"errorCount"`
+	FailedItems   int                 `json:"failedItems"`
 	StartedAt     time.Time           `json:"started at"`
 	Status        string              `json:"status"`
 	Version       string              `json:"version"`
 	Selectors     selectors.Printable `json:"selectors"`
 	BytesRead     int64               `json:"bytesRead"`
 	BytesUploaded int64               `json:"bytesUploaded"`
+	Tags          map[string]string   `json:"tags,omitempty"`
+	LegalHold     *LegalHold          `json:"legalHold,omitempty"`
+	HoldHistory   []HoldEvent         `json:"holdHistory,omitempty"`
 }
 
 // MinimumPrintable reduces the Backup to its minimally printable details.
@@ -104,12 +248,16 @@ func (b Backup) MinimumPrintable() any {
 	return Printable{
 		ID:            b.ID,
 		ErrorCount:    support.GetNumberOfErrors(b.ReadErrors) + support.GetNumberOfErrors(b.WriteErrors),
+		FailedItems:   len(b.Failures),
 		StartedAt:     b.StartedAt,
 		Status:        b.Status,
 		Version:       "0",
 		Selectors:     b.Selectors.ToPrintable(),
 		BytesRead:     b.BytesRead,
 		BytesUploaded: b.BytesUploaded,
+		Tags:          b.UserTags(),
+		LegalHold:     b.LegalHold,
+		HoldHistory:   b.HoldHistory,
 	}
 }
 
@@ -121,6 +269,7 @@ func (b Backup) Headers() []string {
 		"ID",
 		"Status",
 		"Selectors",
+		"Tags",
 	}
 }
 
@@ -130,10 +279,32 @@ func (b Backup) Values() []string {
 	errCount := support.GetNumberOfErrors(b.ReadErrors) + support.GetNumberOfErrors(b.WriteErrors)
 	status := fmt.Sprintf("%s (%d errors)", b.Status, errCount)
 
+	if len(b.Failures) > 0 {
+		status = fmt.Sprintf("%s (%d errors, %d failed items)", b.Status, errCount, len(b.Failures))
+	}
+
+	if b.OnHold() {
+		status += " [legal hold]"
+	}
+
 	return []string{
 		common.FormatTabularDisplayTime(b.StartedAt),
 		string(b.ID),
 		status,
 		b.Selectors.ToPrintable().Resources(),
+		userTagsString(b.UserTags()),
+	}
+}
+
+// userTagsString produces the tags as a sorted, comma-separated list of
+// key=value pairs.
+func userTagsString(tags map[string]string) string {
+	kvs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		kvs = append(kvs, k+"="+v)
 	}
+
+	sort.Strings(kvs)
+
+	return strings.Join(kvs, ",")
 }
@@ -13,6 +13,8 @@ import (
 	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
@@ -32,7 +34,9 @@ func stubBackup(t time.Time) backup.Backup {
 		BaseModel: model.BaseModel{
 			ID: model.StableID("id"),
 			Tags: map[string]string{
-				model.ServiceTag: sel.PathService().String(),
+				model.ServiceTag:               sel.PathService().String(),
+				model.UserTagPrefix + "ticket": "INC123",
+				model.UserTagPrefix + "reason": "audit",
 			},
 		},
 		CreationTime: t,
@@ -67,6 +71,7 @@ func (suite *BackupSuite) TestBackup_HeadersValues() {
 		"ID",
 		"Status",
 		"Selectors",
+		"Tags",
 	}
 	hs := b.Headers()
 	assert.Equal(t, expectHs, hs)
@@ -77,12 +82,194 @@ func (suite *BackupSuite) TestBackup_HeadersValues() {
 		"id",
 		"status (2 errors)",
 		selectors.All,
+		"reason=audit,ticket=INC123",
 	}
 
 	vs := b.Values()
 	assert.Equal(t, expectVs, vs)
 }
 
+func (suite *BackupSuite) TestBackup_UpdateUserTags() {
+	t := suite.T()
+	b := stubBackup(time.Now())
+
+	b.UpdateUserTags(map[string]string{"ticket": "INC456", "team": "legal"}, []string{"reason", "missing"})
+
+	assert.Equal(
+		t,
+		map[string]string{"ticket": "INC456", "team": "legal"},
+		b.UserTags())
+	assert.Equal(t, path.ExchangeService.String(), b.Tags[model.ServiceTag], "corso tags are kept")
+
+	result, ok := b.MinimumPrintable().(backup.Printable)
+	require.True(t, ok)
+	assert.Equal(t, b.UserTags(), result.Tags)
+}
+
+func (suite *BackupSuite) TestBackup_Hold() {
+	t := suite.T()
+	b := stubBackup(time.Now())
+	setAt := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	clearAt := setAt.Add(time.Hour)
+
+	require.False(t, b.OnHold())
+	assert.Error(t, b.ClearHold("done", "legal", clearAt), "clearing an unheld backup")
+
+	b.SetHold("litigation", "legal", setAt)
+	require.True(t, b.OnHold())
+	assert.Equal(t, &backup.LegalHold{Reason: "litigation", SetBy: "legal", SetAt: setAt}, b.LegalHold)
+	assert.Equal(t, "status (2 errors) [legal hold]", b.Values()[2])
+
+	result, ok := b.MinimumPrintable().(backup.Printable)
+	require.True(t, ok)
+	assert.Equal(t, b.LegalHold, result.LegalHold)
+
+	require.NoError(t, b.ClearHold("settled", "counsel", clearAt))
+	assert.False(t, b.OnHold())
+	assert.Equal(
+		t,
+		[]backup.HoldEvent{
+			{Action: backup.HoldSet, Reason: "litigation", By: "legal", At: setAt},
+			{Action: backup.HoldCleared, Reason: "settled", By: "counsel", At: clearAt},
+		},
+		b.HoldHistory)
+}
+
+func (suite *BackupSuite) TestBackup_ResourceOwners() {
+	backupOf := func(owners ...string) backup.Backup {
+		sel := selectors.NewExchangeBackup()
+		sel.Include(sel.Users(owners))
+
+		return backup.Backup{Selectors: sel.Selector}
+	}
+
+	indexed := backupOf(selectors.Any()...)
+	indexed.DetailsIndex = &details.Index{
+		Shards: []details.IndexShard{
+			{Name: "shard-0"},
+			{Name: "shard-1", ResourceOwner: "b", Category: "email"},
+			{Name: "shard-2", ResourceOwner: "a", Category: "email"},
+			{Name: "shard-3", ResourceOwner: "a", Category: "events"},
+		},
+	}
+
+	table := []struct {
+		name      string
+		b         backup.Backup
+		expect    []string
+		expectAll assert.BoolAssertionFunc
+	}{
+		{"selected owners", backupOf("a", "b"), []string{"a", "b"}, assert.False},
+		{"any owner", backupOf(selectors.Any()...), nil, assert.True},
+		{"any among the owners", backupOf("a", selectors.AnyTgt), nil, assert.True},
+		{"indexed", indexed, []string{"a", "b"}, assert.False},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			owners, all := test.b.ResourceOwners()
+			assert.ElementsMatch(t, test.expect, owners)
+			test.expectAll(t, all)
+		})
+	}
+}
+
+func (suite *BackupSuite) TestBackup_Values_failures() {
+	t := suite.T()
+	b := stubBackup(time.Now())
+	b.Failures = []details.Failure{
+		{Path: "p", ItemID: "a", Class: details.FailureNotFound},
+		{Path: "p", ItemID: "b", Class: details.FailureThrottled},
+	}
+
+	assert.Equal(t, "status (2 errors, 2 failed items)", b.Values()[2])
+
+	result, ok := b.MinimumPrintable().(backup.Printable)
+	require.True(t, ok)
+	assert.Equal(t, 2, result.FailedItems)
+}
+
+func (suite *BackupSuite) TestEstimate_HeadersValues() {
+	t := suite.T()
+	e := backup.Estimate{
+		ResourceOwner: "user",
+		Category:      "email",
+		Containers:    2,
+		Items:         10,
+		NewItems:      3,
+		Bytes:         2000,
+		NewBytes:      600,
+		UnsizedItems:  1,
+	}
+
+	expectHs := []string{
+		"Resource Owner",
+		"Category",
+		"Containers",
+		"Items",
+		"New Items",
+		"Size",
+		"New Size",
+		"Unsized Items",
+	}
+	assert.Equal(t, expectHs, e.Headers())
+
+	expectVs := []string{"user", "email", "2", "10", "3", "2.0 kB", "600 B", "1"}
+	assert.Equal(t, expectVs, e.Values())
+
+	// no sizes could be estimated
+	e.Bytes, e.NewBytes, e.UnsizedItems = 0, 0, 10
+
+	expectVs = []string{"user", "email", "2", "10", "3", "unknown", "unknown", "10"}
+	assert.Equal(t, expectVs, e.Values())
+}
+
+func (suite *BackupSuite) TestOwnerResult_HeadersValues() {
+	t := suite.T()
+	r := backup.OwnerResult{
+		ResourceOwner: "user",
+		BackupID:      "id",
+		Status:        "Completed",
+		ItemsWritten:  10,
+		BytesUploaded: 2000,
+		FailedItems:   1,
+		Duration:      90*time.Second + 400*time.Millisecond,
+	}
+
+	expectHs := []string{
+		"Resource Owner",
+		"ID",
+		"Status",
+		"Items",
+		"Uploaded",
+		"Failed Items",
+		"Duration",
+		"Error",
+	}
+	assert.Equal(t, expectHs, r.Headers())
+
+	expectVs := []string{"user", "id", "Completed", "10", "2.0 kB", "1", "1m30s", ""}
+	assert.Equal(t, expectVs, r.Values())
+}
+
+func (suite *BackupSuite) TestItemMatch_HeadersValues() {
+	t := suite.T()
+	now := time.Now()
+	m := backup.ItemMatch{
+		BackupID:        "id",
+		BackupStartedAt: now,
+		DetailsEntry: details.DetailsEntry{
+			ShortRef: "short",
+			ItemInfo: details.ItemInfo{Folder: &details.FolderInfo{DisplayName: "inbox"}},
+		},
+	}
+
+	expectHs := []string{"Backup ID", "Backup Started At", "ID", "Display Name"}
+	assert.Equal(t, expectHs, m.Headers())
+
+	expectVs := []string{"id", common.FormatTabularDisplayTime(now), "short", "inbox"}
+	assert.Equal(t, expectVs, m.Values())
+}
+
 func (suite *BackupSuite) TestBackup_MinimumPrintable() {
 	t := suite.T()
 	now := time.Now()
@@ -94,6 +281,7 @@ func (suite *BackupSuite) TestBackup_MinimumPrintable() {
 
 	assert.Equal(t, b.ID, result.ID, "id")
 	assert.Equal(t, 2, result.ErrorCount, "error count")
+	assert.Zero(t, result.FailedItems, "failed items")
 	assert.Equal(t, now, result.StartedAt, "started at")
 	assert.Equal(t, b.Status, result.Status, "status")
 
@@ -0,0 +1,247 @@
+package details
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultChunkSize is the largest number of entries stored in a single
+// chunk of a backup's details.
+const DefaultChunkSize = 1000
+
+// Chunk is a group of details entries that are stored, and read back,
+// together.  All of a chunk's entries belong to the same resource owner
+// and category.
+type Chunk struct {
+	Name string
+	DetailsModel
+}
+
+// ChunkWriter stores the chunks of a backup's details as they fill.
+type ChunkWriter interface {
+	WriteChunk(c Chunk) error
+}
+
+// NewChunked creates details that hand their entries to the writer in
+// chunks of up to size entries, instead of holding every entry in memory.
+// Flush must be called once all the entries are added.  Chunks are handed
+// to the writer one at a time, in the order they fill, but outside of the
+// details' lock: a writer that blocks until the chunk is stored only blocks
+// the Add that filled the chunk, and those that fill another one.
+func NewChunked(size int, cw ChunkWriter) *Details {
+	if size < 1 {
+		size = DefaultChunkSize
+	}
+
+	return &Details{
+		chunks: &chunker{
+			size:    size,
+			cw:      cw,
+			pending: map[shardKey]*Chunk{},
+			shards:  map[shardKey]*IndexShard{},
+		},
+	}
+}
+
+// Flush writes the chunks that haven't filled yet, and returns the index
+// describing all of the chunks written.  The index's SnapshotID must be set
+// once the chunks are stored.  Returns the first error produced by writing
+// the chunks, after which any further entries were dropped.  Entries can't
+// be added once the details are flushed.
+func (d *Details) Flush() (*Index, error) {
+	d.mu.Lock()
+
+	if d.chunks == nil {
+		d.mu.Unlock()
+		return nil, errors.New("flushing details that aren't chunked")
+	}
+
+	d.chunks.flush()
+	d.mu.Unlock()
+
+	// waits for the chunks other callers are writing, too.
+	d.writeQueued()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.chunks.index()
+}
+
+// writeChunks hands the chunks that filled to the writer, if there are any.
+// Adding entries that don't fill a chunk never waits on the writer.
+func (d *Details) writeChunks() {
+	if d.chunks == nil {
+		return
+	}
+
+	d.mu.Lock()
+	queued := len(d.chunks.full) > 0
+	d.mu.Unlock()
+
+	if queued {
+		d.writeQueued()
+	}
+}
+
+// writeQueued hands the queued chunks to the writer.  The chunks are taken
+// from the chunker while holding the details' lock, and written after
+// releasing it.  Only one caller writes at a time, which keeps the chunks
+// in order.
+func (d *Details) writeQueued() {
+	c := d.chunks
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	d.mu.Lock()
+	full := c.full
+	c.full = nil
+	d.mu.Unlock()
+
+	for _, fc := range full {
+		err := c.cw.WriteChunk(*fc.chunk)
+
+		d.mu.Lock()
+		c.record(fc, err)
+		stop := c.err != nil
+		d.mu.Unlock()
+
+		if stop {
+			return
+		}
+	}
+}
+
+// chunker groups entries into chunks by resource owner and category, and
+// queues each chunk to be written once it fills.  All fields but writeMu
+// are guarded by the details' lock.
+type chunker struct {
+	size      int
+	cw        ChunkWriter
+	pending   map[shardKey]*Chunk
+	full      []fullChunk
+	shards    map[shardKey]*IndexShard
+	numChunks int
+	flushed   bool
+	err       error
+	// writeMu is held while handing chunks to the writer.
+	writeMu sync.Mutex
+}
+
+// fullChunk is a chunk waiting to be written, along with the key of the
+// shard it belongs to.
+type fullChunk struct {
+	key   shardKey
+	chunk *Chunk
+}
+
+func (c *chunker) add(de DetailsEntry) {
+	if c.err != nil {
+		return
+	}
+
+	if c.flushed {
+		c.err = errors.Errorf("adding entry %q to flushed details", de.RepoRef)
+		return
+	}
+
+	k := keyFor(de)
+
+	chunk, ok := c.pending[k]
+	if !ok {
+		chunk = &Chunk{Name: "chunk-" + strconv.Itoa(c.numChunks)}
+		c.pending[k] = chunk
+		c.numChunks++
+	}
+
+	chunk.Entries = append(chunk.Entries, de)
+
+	if len(chunk.Entries) >= c.size {
+		c.queue(k)
+	}
+}
+
+// queue moves the pending chunk of the shard to the chunks waiting to be
+// written.
+func (c *chunker) queue(k shardKey) {
+	c.full = append(c.full, fullChunk{key: k, chunk: c.pending[k]})
+	delete(c.pending, k)
+}
+
+// record notes the outcome of writing the chunk, adding it to its shard
+// if it was written.
+func (c *chunker) record(fc fullChunk, err error) {
+	if c.err != nil {
+		return
+	}
+
+	if err != nil {
+		c.err = errors.Wrapf(err, "writing details %s", fc.chunk.Name)
+		return
+	}
+
+	shard, ok := c.shards[fc.key]
+	if !ok {
+		shard = &IndexShard{ResourceOwner: fc.key.resourceOwner, Category: fc.key.category}
+		c.shards[fc.key] = shard
+	}
+
+	shard.Entries += len(fc.chunk.Entries)
+	shard.Chunks = append(shard.Chunks, fc.chunk.Name)
+}
+
+// flush queues the chunks that haven't filled yet, and stops any further
+// entries from being added.
+func (c *chunker) flush() {
+	c.flushed = true
+
+	keys := make([]shardKey, 0, len(c.pending))
+	for k := range c.pending {
+		keys = append(keys, k)
+	}
+
+	sortKeys(keys)
+
+	for _, k := range keys {
+		c.queue(k)
+	}
+}
+
+// index describes the chunks written, or returns the error that stopped
+// them from being written.
+func (c *chunker) index() (*Index, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	keys := make([]shardKey, 0, len(c.shards))
+	for k := range c.shards {
+		keys = append(keys, k)
+	}
+
+	sortKeys(keys)
+
+	idx := &Index{Shards: make([]IndexShard, 0, len(keys))}
+
+	for i, k := range keys {
+		shard := *c.shards[k]
+		shard.Name = "shard-" + strconv.Itoa(i)
+		idx.Shards = append(idx.Shards, shard)
+	}
+
+	return idx, nil
+}
+
+func sortKeys(keys []shardKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].resourceOwner != keys[j].resourceOwner {
+			return keys[i].resourceOwner < keys[j].resourceOwner
+		}
+
+		return keys[i].category < keys[j].category
+	})
+}
@@ -0,0 +1,179 @@
+package details_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ChunkUnitSuite struct {
+	suite.Suite
+}
+
+func TestChunkUnitSuite(t *testing.T) {
+	suite.Run(t, new(ChunkUnitSuite))
+}
+
+type mockChunkWriter struct {
+	chunks []details.Chunk
+	err    error
+}
+
+func (cw *mockChunkWriter) WriteChunk(c details.Chunk) error {
+	if cw.err != nil {
+		return cw.err
+	}
+
+	cw.chunks = append(cw.chunks, c)
+
+	return nil
+}
+
+func (suite *ChunkUnitSuite) TestChunked() {
+	t := suite.T()
+
+	var (
+		aMail1   = exchangeEntry(t, "a", path.EmailCategory, "1")
+		aMail2   = exchangeEntry(t, "a", path.EmailCategory, "2")
+		aMail3   = exchangeEntry(t, "a", path.EmailCategory, "3")
+		aEvent   = exchangeEntry(t, "a", path.EventsCategory, "4")
+		bMail    = exchangeEntry(t, "b", path.EmailCategory, "5")
+		cw       = &mockChunkWriter{}
+		d        = details.NewChunked(2, cw)
+		addEntry = func(de details.DetailsEntry) {
+			d.Add(de.RepoRef, de.ShortRef, de.ParentRef, de.Updated, de.ItemInfo)
+		}
+	)
+
+	addEntry(bMail)
+	addEntry(aMail1)
+	addEntry(aEvent)
+	addEntry(aMail2)
+
+	d.AddFolders([]details.FolderEntry{
+		{RepoRef: "folder", ShortRef: "folder"},
+	})
+
+	// full chunks are written as soon as they fill.
+	require.Len(t, cw.chunks, 1)
+	assert.Equal(t, "chunk-1", cw.chunks[0].Name)
+	assert.Equal(t, []details.DetailsEntry{aMail1, aMail2}, cw.chunks[0].Entries)
+
+	addEntry(aMail3)
+
+	assert.Empty(t, d.Entries, "chunked details don't hold entries")
+
+	idx, err := d.Flush()
+	require.NoError(t, err)
+
+	chunks := map[string][]details.DetailsEntry{}
+	for _, c := range cw.chunks {
+		chunks[c.Name] = c.Entries
+	}
+
+	expectChunks := map[string][]details.DetailsEntry{
+		"chunk-0": {bMail},
+		"chunk-1": {aMail1, aMail2},
+		"chunk-2": {aEvent},
+		"chunk-3": {{RepoRef: "folder", ShortRef: "folder"}},
+		"chunk-4": {aMail3},
+	}
+	assert.Equal(t, expectChunks, chunks)
+
+	expectShards := []details.IndexShard{
+		{Name: "shard-0", Entries: 1, Chunks: []string{"chunk-3"}},
+		{Name: "shard-1", ResourceOwner: "a", Category: "email", Entries: 3, Chunks: []string{"chunk-1", "chunk-4"}},
+		{Name: "shard-2", ResourceOwner: "a", Category: "events", Entries: 1, Chunks: []string{"chunk-2"}},
+		{Name: "shard-3", ResourceOwner: "b", Category: "email", Entries: 1, Chunks: []string{"chunk-0"}},
+	}
+	assert.Equal(t, expectShards, idx.Shards)
+	assert.Empty(t, idx.SnapshotID)
+
+	addEntry(bMail)
+
+	_, err = d.Flush()
+	assert.Error(t, err, "adding entries to flushed details")
+}
+
+// blockingChunkWriter blocks writes until it's released.
+type blockingChunkWriter struct {
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (cw *blockingChunkWriter) WriteChunk(c details.Chunk) error {
+	cw.writing <- struct{}{}
+	<-cw.release
+
+	return nil
+}
+
+func (suite *ChunkUnitSuite) TestChunked_writeOutsideLock() {
+	var (
+		t      = suite.T()
+		aMail1 = exchangeEntry(t, "a", path.EmailCategory, "1")
+		aMail2 = exchangeEntry(t, "a", path.EmailCategory, "2")
+		bMail  = exchangeEntry(t, "b", path.EmailCategory, "3")
+		cw     = &blockingChunkWriter{
+			writing: make(chan struct{}, 2),
+			release: make(chan struct{}),
+		}
+		d     = details.NewChunked(2, cw)
+		added = make(chan struct{})
+	)
+
+	d.Add(aMail1.RepoRef, aMail1.ShortRef, aMail1.ParentRef, aMail1.Updated, aMail1.ItemInfo)
+
+	go d.Add(aMail2.RepoRef, aMail2.ShortRef, aMail2.ParentRef, aMail2.Updated, aMail2.ItemInfo)
+
+	<-cw.writing
+
+	// adding an entry that doesn't fill a chunk doesn't wait on the chunk
+	// being written.
+	go func() {
+		d.Add(bMail.RepoRef, bMail.ShortRef, bMail.ParentRef, bMail.Updated, bMail.ItemInfo)
+		close(added)
+	}()
+
+	select {
+	case <-added:
+	case <-time.After(time.Second):
+		assert.Fail(t, "adding an entry waited on the chunk writer")
+	}
+
+	close(cw.release)
+
+	idx, err := d.Flush()
+	require.NoError(t, err)
+
+	expectShards := []details.IndexShard{
+		{Name: "shard-0", ResourceOwner: "a", Category: "email", Entries: 2, Chunks: []string{"chunk-0"}},
+		{Name: "shard-1", ResourceOwner: "b", Category: "email", Entries: 1, Chunks: []string{"chunk-1"}},
+	}
+	assert.Equal(t, expectShards, idx.Shards)
+}
+
+func (suite *ChunkUnitSuite) TestChunked_writeError() {
+	t := suite.T()
+	cw := &mockChunkWriter{err: assert.AnError}
+	d := details.NewChunked(1, cw)
+
+	entry := exchangeEntry(t, "a", path.EmailCategory, "1")
+	d.Add(entry.RepoRef, entry.ShortRef, entry.ParentRef, entry.Updated, entry.ItemInfo)
+
+	_, err := d.Flush()
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func (suite *ChunkUnitSuite) TestFlush_notChunked() {
+	d := &details.Details{}
+
+	_, err := d.Flush()
+	assert.Error(suite.T(), err)
+}
@@ -83,6 +83,22 @@ func (dm DetailsModel) Paths() []string {
 	return r
 }
 
+// ContentHashes returns the content hashes of the items that have one,
+// keyed by RepoRef.
+func (dm DetailsModel) ContentHashes() map[string]string {
+	hs := map[string]string{}
+
+	for _, ent := range dm.Entries {
+		if ent.Folder != nil || len(ent.ContentHash) == 0 {
+			continue
+		}
+
+		hs[ent.RepoRef] = ent.ContentHash
+	}
+
+	return hs
+}
+
 // Items returns a slice of *ItemInfo that does not contain any FolderInfo
 // entries. Required because not all folders in the details are valid resource
 // paths.
@@ -113,23 +129,35 @@ type Details struct {
 	// internal
 	mu           sync.Mutex          `json:"-"`
 	knownFolders map[string]struct{} `json:"-"`
+	chunks       *chunker            `json:"-"`
 }
 
 func (d *Details) Add(repoRef, shortRef, parentRef string, updated bool, info ItemInfo) {
+	d.AddItem(repoRef, shortRef, parentRef, "", updated, info)
+}
+
+// AddItem adds an entry for an item, along with the hash of the item's
+// content.
+func (d *Details) AddItem(repoRef, shortRef, parentRef, contentHash string, updated bool, info ItemInfo) {
 	d.mu.Lock()
-	defer d.mu.Unlock()
-	d.Entries = append(d.Entries, DetailsEntry{
-		RepoRef:   repoRef,
-		ShortRef:  shortRef,
-		ParentRef: parentRef,
-		Updated:   updated,
-		ItemInfo:  info,
+	d.add(DetailsEntry{
+		RepoRef:     repoRef,
+		ShortRef:    shortRef,
+		ParentRef:   parentRef,
+		ContentHash: contentHash,
+		Updated:     updated,
+		ItemInfo:    info,
 	})
+	d.mu.Unlock()
+
+	d.writeChunks()
 }
 
 // AddFolders adds entries for the given folders. It skips adding entries that
 // have been added by previous calls.
 func (d *Details) AddFolders(folders []FolderEntry) {
+	defer d.writeChunks()
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -144,7 +172,7 @@ func (d *Details) AddFolders(folders []FolderEntry) {
 		}
 
 		d.knownFolders[folder.ShortRef] = struct{}{}
-		d.Entries = append(d.Entries, DetailsEntry{
+		d.add(DetailsEntry{
 			RepoRef:   folder.RepoRef,
 			ShortRef:  folder.ShortRef,
 			ParentRef: folder.ParentRef,
@@ -153,6 +181,17 @@ func (d *Details) AddFolders(folders []FolderEntry) {
 	}
 }
 
+// add appends the entry, or hands it to the chunker of chunked details.
+// Callers must hold the mutex.
+func (d *Details) add(de DetailsEntry) {
+	if d.chunks != nil {
+		d.chunks.add(de)
+		return
+	}
+
+	d.Entries = append(d.Entries, de)
+}
+
 // --------------------------------------------------------------------------------
 // Entry
 // --------------------------------------------------------------------------------
@@ -164,6 +203,10 @@ type DetailsEntry struct {
 	RepoRef   string `json:"repoRef"`
 	ShortRef  string `json:"shortRef"`
 	ParentRef string `json:"parentRef,omitempty"`
+	// ContentHash is the hex-encoded SHA-256 hash of the item's content,
+	// recorded when the item was backed up.  Restores and exports check
+	// the content they read against it.
+	ContentHash string `json:"contentHash,omitempty"`
 	// Indicates the item was added or updated in this backup
 	// Always `true` for full backups
 	Updated bool `json:"updated"`
@@ -280,6 +323,66 @@ func (i ItemInfo) infoType() ItemType {
 	return UnknownType
 }
 
+// Size returns the size of the item in bytes, or 0 if the item doesn't
+// record one.
+func (i ItemInfo) Size() int64 {
+	switch {
+	case i.Exchange != nil:
+		return i.Exchange.Size
+
+	case i.SharePoint != nil:
+		return i.SharePoint.Size
+
+	case i.OneDrive != nil:
+		return i.OneDrive.Size
+	}
+
+	return 0
+}
+
+// Modified returns the time the item was last modified, or the zero time
+// if the item doesn't record one.
+func (i ItemInfo) Modified() time.Time {
+	switch {
+	case i.Folder != nil:
+		return i.Folder.Modified
+
+	case i.Exchange != nil:
+		return i.Exchange.Modified
+
+	case i.SharePoint != nil:
+		return i.SharePoint.Modified
+
+	case i.OneDrive != nil:
+		return i.OneDrive.Modified
+	}
+
+	return time.Time{}
+}
+
+// name returns a human-readable name for the item.
+func (i ItemInfo) name() string {
+	switch {
+	case i.Folder != nil:
+		return i.Folder.DisplayName
+
+	case i.Exchange != nil:
+		if i.Exchange.ItemType == ExchangeContact {
+			return i.Exchange.ContactName
+		}
+
+		return i.Exchange.Subject
+
+	case i.SharePoint != nil:
+		return i.SharePoint.ItemName
+
+	case i.OneDrive != nil:
+		return i.OneDrive.ItemName
+	}
+
+	return ""
+}
+
 type FolderInfo struct {
 	ItemType    ItemType  `json:"itemType,omitempty"`
 	DisplayName string    `json:"displayName"`
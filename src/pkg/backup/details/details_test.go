@@ -221,6 +221,18 @@ func (suite *DetailsUnitSuite) TestDetailsModel_Items() {
 	}
 }
 
+func (suite *DetailsUnitSuite) TestDetailsModel_ContentHashes() {
+	t := suite.T()
+	d := details.Details{}
+
+	d.AddItem("item1", "ir1", "fr", "hash1", true, details.ItemInfo{Exchange: &details.ExchangeInfo{}})
+	d.AddItem("item2", "ir2", "fr", "", true, details.ItemInfo{Exchange: &details.ExchangeInfo{}})
+	d.Add("item3", "ir3", "fr", true, details.ItemInfo{Exchange: &details.ExchangeInfo{}})
+	d.AddItem("folder", "fr", "", "hash4", true, details.ItemInfo{Folder: &details.FolderInfo{}})
+
+	assert.Equal(t, map[string]string{"item1": "hash1"}, d.ContentHashes())
+}
+
 func (suite *DetailsUnitSuite) TestDetails_AddFolders() {
 	table := []struct {
 		name              string
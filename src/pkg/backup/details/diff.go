@@ -0,0 +1,278 @@
+package details
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/common"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// Change describes how an item differs between two backups.
+type Change string
+
+const (
+	ChangeAdded    Change = "added"
+	ChangeRemoved  Change = "removed"
+	ChangeModified Change = "modified"
+	ChangeMoved    Change = "moved"
+)
+
+// DiffEntry describes a single item that changed between two backups.
+type DiffEntry struct {
+	Change Change `json:"change"`
+	// Folder containing the item, as of the later backup.  Removed items
+	// hold the folder they were removed from.
+	Folder string `json:"folder"`
+	// PreviousFolder is the folder a moved item was moved from.
+	PreviousFolder string `json:"previousFolder,omitempty"`
+	// From is the item in the earlier backup; nil for added items.
+	From *DetailsEntry `json:"from,omitempty"`
+	// To is the item in the later backup; nil for removed items.
+	To *DetailsEntry `json:"to,omitempty"`
+}
+
+// Diff lists the items that changed between two backups, grouped by folder.
+type Diff struct {
+	Entries []DiffEntry `json:"entries"`
+}
+
+// Count returns the number of entries with the change.
+func (d Diff) Count(c Change) int {
+	var n int
+
+	for _, e := range d.Entries {
+		if e.Change == c {
+			n++
+		}
+	}
+
+	return n
+}
+
+// diffItem is a non-folder entry along with the parts of its path used to
+// match it to the entries in another backup.
+type diffItem struct {
+	entry  *DetailsEntry
+	folder string
+	// key identifies the item within its resource owner and category,
+	// regardless of its folder.
+	key string
+}
+
+func toDiffItems(dm DetailsModel) []diffItem {
+	items := dm.Items()
+	dis := make([]diffItem, 0, len(items))
+
+	for _, de := range items {
+		di := diffItem{entry: de, key: de.RepoRef}
+
+		// Entries that don't hold a valid path can still be matched by
+		// their RepoRef, they just can't be detected as moved.
+		if p, err := path.FromDataLayerPath(de.RepoRef, true); err == nil {
+			di.folder = strings.Join([]string{p.ResourceOwner(), p.Category().String(), p.Folder()}, "/")
+			di.key = strings.Join([]string{p.ResourceOwner(), p.Category().String(), p.Item()}, "/")
+		}
+
+		dis = append(dis, di)
+	}
+
+	return dis
+}
+
+// DiffDetails compares the items in two backups' details.  Items are matched
+// by their RepoRef.  Items present in both, whose size or modified time
+// differ, are modified.  An item that's removed from one folder and added to
+// another of the same resource owner and category, with the same ID, size,
+// and modified time, is reported once, as moved.  Folder entries aren't
+// compared.
+func DiffDetails(from, to DetailsModel) Diff {
+	var (
+		fromItems = toDiffItems(from)
+		toItems   = toDiffItems(to)
+		byRef     = make(map[string]diffItem, len(fromItems))
+		// unmatched items in from, by key, in case they've moved.
+		byKey = map[string][]diffItem{}
+		diff  = Diff{Entries: []DiffEntry{}}
+	)
+
+	for _, di := range fromItems {
+		byRef[di.entry.RepoRef] = di
+	}
+
+	toRefs := make(map[string]struct{}, len(toItems))
+	for _, di := range toItems {
+		toRefs[di.entry.RepoRef] = struct{}{}
+	}
+
+	for _, di := range fromItems {
+		if _, ok := toRefs[di.entry.RepoRef]; !ok {
+			byKey[di.key] = append(byKey[di.key], di)
+		}
+	}
+
+	for _, di := range toItems {
+		if prev, ok := byRef[di.entry.RepoRef]; ok {
+			if changed(prev.entry.ItemInfo, di.entry.ItemInfo) {
+				diff.Entries = append(diff.Entries, DiffEntry{
+					Change: ChangeModified,
+					Folder: di.folder,
+					From:   prev.entry,
+					To:     di.entry,
+				})
+			}
+
+			continue
+		}
+
+		if prev, ok := takeMoved(byKey, di); ok {
+			diff.Entries = append(diff.Entries, DiffEntry{
+				Change:         ChangeMoved,
+				Folder:         di.folder,
+				PreviousFolder: prev.folder,
+				From:           prev.entry,
+				To:             di.entry,
+			})
+
+			continue
+		}
+
+		diff.Entries = append(diff.Entries, DiffEntry{
+			Change: ChangeAdded,
+			Folder: di.folder,
+			To:     di.entry,
+		})
+	}
+
+	for _, prevs := range byKey {
+		for _, prev := range prevs {
+			diff.Entries = append(diff.Entries, DiffEntry{
+				Change: ChangeRemoved,
+				Folder: prev.folder,
+				From:   prev.entry,
+			})
+		}
+	}
+
+	diff.sort()
+
+	return diff
+}
+
+// MergeDiffs combines the diffs of separate sets of items, such as each
+// resource owner and category of two backups, into a single diff.  Items
+// are only detected as moved within a resource owner and category, so the
+// merged diff matches that of the full backups.
+func MergeDiffs(diffs ...Diff) Diff {
+	merged := Diff{Entries: []DiffEntry{}}
+
+	for _, d := range diffs {
+		merged.Entries = append(merged.Entries, d.Entries...)
+	}
+
+	merged.sort()
+
+	return merged
+}
+
+// sort orders the entries by folder, then by change, then by item.
+func (d Diff) sort() {
+	sort.SliceStable(d.Entries, func(i, j int) bool {
+		ei, ej := d.Entries[i], d.Entries[j]
+		if ei.Folder != ej.Folder {
+			return ei.Folder < ej.Folder
+		}
+
+		if ei.Change != ej.Change {
+			return ei.Change < ej.Change
+		}
+
+		return ei.entry().RepoRef < ej.entry().RepoRef
+	})
+}
+
+// takeMoved removes, and returns, the unmatched item that di was moved from:
+// the first with the same key whose metadata is unchanged.
+func takeMoved(byKey map[string][]diffItem, di diffItem) (diffItem, bool) {
+	prevs := byKey[di.key]
+
+	for i, prev := range prevs {
+		if changed(prev.entry.ItemInfo, di.entry.ItemInfo) {
+			continue
+		}
+
+		byKey[di.key] = append(prevs[:i:i], prevs[i+1:]...)
+
+		return prev, true
+	}
+
+	return diffItem{}, false
+}
+
+// changed reports whether the item's metadata differs between backups.
+func changed(from, to ItemInfo) bool {
+	return from.Size() != to.Size() || !from.Modified().Equal(to.Modified())
+}
+
+// entry returns the most recent version of the item.
+func (de DiffEntry) entry() *DetailsEntry {
+	if de.To != nil {
+		return de.To
+	}
+
+	return de.From
+}
+
+// --------------------------------------------------------------------------------
+// CLI Output
+// --------------------------------------------------------------------------------
+
+// interface compliance checks
+var _ print.Printable = DiffEntry{}
+
+// PrintEntries writes the Diff entries to StdOut, in the format requested
+// by the caller.
+func (d Diff) PrintEntries(ctx context.Context) {
+	if len(d.Entries) == 0 {
+		print.Info(ctx, "No changes between the backups")
+		return
+	}
+
+	ps := make([]print.Printable, 0, len(d.Entries))
+	for _, e := range d.Entries {
+		ps = append(ps, print.Printable(e))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable is a passthrough func, because no
+// reduction is needed for the json output.
+func (de DiffEntry) MinimumPrintable() any {
+	return de
+}
+
+// Headers returns the human-readable names of properties in a DiffEntry
+// for printing out to a terminal in a columnar display.
+func (de DiffEntry) Headers() []string {
+	return []string{"Folder", "Change", "ID", "Name", "Size", "Modified", "Previous Folder"}
+}
+
+// Values returns the values matching the Headers list.
+func (de DiffEntry) Values() []string {
+	e := de.entry()
+
+	return []string{
+		de.Folder,
+		string(de.Change),
+		e.ShortRef,
+		e.ItemInfo.name(),
+		humanize.Bytes(uint64(e.ItemInfo.Size())),
+		common.FormatTabularDisplayTime(e.ItemInfo.Modified()),
+		de.PreviousFolder,
+	}
+}
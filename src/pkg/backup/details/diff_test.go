@@ -0,0 +1,183 @@
+package details_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type DiffUnitSuite struct {
+	suite.Suite
+}
+
+func TestDiffUnitSuite(t *testing.T) {
+	suite.Run(t, new(DiffUnitSuite))
+}
+
+func oneDriveEntry(t *testing.T, item string, size int64, mod time.Time, folders ...string) details.DetailsEntry {
+	return ownedOneDriveEntry(t, "user", item, size, mod, folders...)
+}
+
+func ownedOneDriveEntry(
+	t *testing.T,
+	owner, item string,
+	size int64,
+	mod time.Time,
+	folders ...string,
+) details.DetailsEntry {
+	p, err := path.Builder{}.
+		Append(append([]string{"drive", "root:"}, folders...)...).
+		Append(item).
+		ToDataLayerOneDrivePath("tenant", owner, true)
+	require.NoError(t, err)
+
+	return details.DetailsEntry{
+		RepoRef:  p.String(),
+		ShortRef: p.ShortRef(),
+		ItemInfo: details.ItemInfo{
+			OneDrive: &details.OneDriveInfo{
+				ItemType: details.OneDriveItem,
+				ItemName: item + ".txt",
+				Modified: mod,
+				Size:     size,
+			},
+		},
+	}
+}
+
+func (suite *DiffUnitSuite) TestDiffDetails() {
+	t := suite.T()
+
+	var (
+		then = time.Date(2022, 10, 4, 0, 0, 0, 0, time.UTC)
+		now  = then.Add(24 * time.Hour)
+
+		kept     = oneDriveEntry(t, "kept", 10, then, "a")
+		edited   = oneDriveEntry(t, "edited", 10, then, "a")
+		edited2  = oneDriveEntry(t, "edited", 20, now, "a")
+		resized  = oneDriveEntry(t, "resized", 10, then, "a")
+		resized2 = oneDriveEntry(t, "resized", 15, then, "a")
+		moved    = oneDriveEntry(t, "moved", 10, then, "a")
+		moved2   = oneDriveEntry(t, "moved", 10, then, "b")
+		removed  = oneDriveEntry(t, "removed", 10, then, "b")
+		added    = oneDriveEntry(t, "added", 10, now, "b")
+		folder   = details.DetailsEntry{
+			RepoRef:  "folder",
+			ShortRef: "folder",
+			ItemInfo: details.ItemInfo{Folder: &details.FolderInfo{DisplayName: "a"}},
+		}
+	)
+
+	from := details.DetailsModel{Entries: []details.DetailsEntry{kept, edited, resized, moved, removed, folder}}
+	to := details.DetailsModel{Entries: []details.DetailsEntry{kept, edited2, resized2, moved2, added}}
+
+	diff := details.DiffDetails(from, to)
+
+	folderA := "user/files/drive/root:/a"
+	folderB := "user/files/drive/root:/b"
+
+	expect := []details.DiffEntry{
+		{Change: details.ChangeModified, Folder: folderA, From: &edited, To: &edited2},
+		{Change: details.ChangeModified, Folder: folderA, From: &resized, To: &resized2},
+		{Change: details.ChangeAdded, Folder: folderB, To: &added},
+		{Change: details.ChangeMoved, Folder: folderB, PreviousFolder: folderA, From: &moved, To: &moved2},
+		{Change: details.ChangeRemoved, Folder: folderB, From: &removed},
+	}
+
+	assert.Equal(t, expect, diff.Entries)
+	assert.Equal(t, 2, diff.Count(details.ChangeModified))
+	assert.Equal(t, 1, diff.Count(details.ChangeMoved))
+}
+
+func (suite *DiffUnitSuite) TestDiffDetails_notMoved() {
+	t := suite.T()
+
+	var (
+		then = time.Date(2022, 10, 4, 0, 0, 0, 0, time.UTC)
+		now  = then.Add(24 * time.Hour)
+
+		// same name, different file, in another folder
+		report  = oneDriveEntry(t, "report", 10, then, "a")
+		report2 = oneDriveEntry(t, "report", 30, now, "b")
+		// same name, and metadata, for another user
+		notes  = oneDriveEntry(t, "notes", 10, then, "a")
+		notes2 = ownedOneDriveEntry(t, "other", "notes", 10, then, "a")
+	)
+
+	from := details.DetailsModel{Entries: []details.DetailsEntry{report, notes}}
+	to := details.DetailsModel{Entries: []details.DetailsEntry{report2, notes2}}
+
+	diff := details.DiffDetails(from, to)
+
+	assert.Equal(t, 0, diff.Count(details.ChangeMoved))
+	assert.Equal(t, 2, diff.Count(details.ChangeAdded))
+	assert.Equal(t, 2, diff.Count(details.ChangeRemoved))
+}
+
+func (suite *DiffUnitSuite) TestDiffDetails_noChanges() {
+	t := suite.T()
+	e := oneDriveEntry(t, "item", 10, time.Now(), "a")
+	dm := details.DetailsModel{Entries: []details.DetailsEntry{e}}
+
+	assert.Empty(t, details.DiffDetails(dm, dm).Entries)
+}
+
+func (suite *DiffUnitSuite) TestMergeDiffs() {
+	t := suite.T()
+
+	var (
+		then = time.Date(2022, 10, 4, 0, 0, 0, 0, time.UTC)
+
+		aKept    = ownedOneDriveEntry(t, "a", "kept", 10, then, "a")
+		aMoved   = ownedOneDriveEntry(t, "a", "moved", 10, then, "a")
+		aMoved2  = ownedOneDriveEntry(t, "a", "moved", 10, then, "b")
+		bRemoved = ownedOneDriveEntry(t, "b", "removed", 10, then, "a")
+		bAdded   = ownedOneDriveEntry(t, "b", "added", 10, then, "a")
+	)
+
+	aFrom := details.DetailsModel{Entries: []details.DetailsEntry{aKept, aMoved}}
+	aTo := details.DetailsModel{Entries: []details.DetailsEntry{aKept, aMoved2}}
+	bFrom := details.DetailsModel{Entries: []details.DetailsEntry{bRemoved}}
+	bTo := details.DetailsModel{Entries: []details.DetailsEntry{bAdded}}
+
+	merged := details.MergeDiffs(
+		details.DiffDetails(bFrom, bTo),
+		details.DiffDetails(aFrom, aTo))
+
+	full := details.DiffDetails(
+		details.DetailsModel{Entries: append(aFrom.Entries, bFrom.Entries...)},
+		details.DetailsModel{Entries: append(aTo.Entries, bTo.Entries...)})
+
+	assert.Equal(t, full, merged)
+	assert.Len(t, merged.Entries, 3)
+	assert.Equal(t, 1, merged.Count(details.ChangeMoved))
+	assert.Empty(t, details.MergeDiffs().Entries)
+}
+
+func (suite *DiffUnitSuite) TestDiffEntry_HeadersValues() {
+	t := suite.T()
+	mod := time.Date(2022, 10, 4, 0, 0, 0, 0, time.UTC)
+	e := oneDriveEntry(t, "item", 2000, mod, "a")
+
+	de := details.DiffEntry{
+		Change:         details.ChangeMoved,
+		Folder:         "b",
+		PreviousFolder: "a",
+		To:             &e,
+	}
+
+	assert.Equal(
+		t,
+		[]string{"Folder", "Change", "ID", "Name", "Size", "Modified", "Previous Folder"},
+		de.Headers())
+	assert.Equal(
+		t,
+		[]string{"b", "moved", e.ShortRef, "item.txt", "2.0 kB", "2022-10-04T00:00:00Z", "a"},
+		de.Values())
+}
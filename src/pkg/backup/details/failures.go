@@ -0,0 +1,69 @@
+package details
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/alcionai/corso/src/cli/print"
+)
+
+// FailureClass categorizes the cause of an item failure.
+type FailureClass string
+
+const (
+	FailureUnknown      FailureClass = "unknown"
+	FailureNotFound     FailureClass = "notFound"
+	FailureAccessDenied FailureClass = "accessDenied"
+	FailureThrottled    FailureClass = "throttled"
+	FailureTimeout      FailureClass = "timeout"
+	FailureService      FailureClass = "serviceError"
+	FailureInvalidData  FailureClass = "invalidData"
+)
+
+// Failure describes an item that couldn't be backed up.  Failed items are
+// skipped in best-effort backups, and are absent from the backup details.
+type Failure struct {
+	// Path of the collection (folder) containing the item.
+	Path string `json:"path"`
+	// ItemID is the M365 ID of the item.
+	ItemID  string       `json:"itemID"`
+	Class   FailureClass `json:"class"`
+	Error   string       `json:"error"`
+	Retries int          `json:"retries"`
+}
+
+// interface compliance checks
+var _ print.Printable = Failure{}
+
+// PrintFailures writes the failures to StdOut, in the format requested by
+// the caller.
+func PrintFailures(ctx context.Context, fs []Failure) {
+	if len(fs) == 0 {
+		print.Info(ctx, "No failed items")
+		return
+	}
+
+	ps := make([]print.Printable, 0, len(fs))
+	for _, f := range fs {
+		ps = append(ps, print.Printable(f))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable is a passthrough func, because no
+// reduction is needed for the json output.
+func (f Failure) MinimumPrintable() any {
+	return f
+}
+
+// Headers returns the human-readable names of properties in a Failure
+// for printing out to a terminal in a columnar display.
+func (f Failure) Headers() []string {
+	return []string{"Path", "Item ID", "Class", "Retries", "Error"}
+}
+
+// Values returns the values matching the Headers list.
+func (f Failure) Values() []string {
+	return []string{f.Path, f.ItemID, string(f.Class), strconv.Itoa(f.Retries), f.Error}
+}
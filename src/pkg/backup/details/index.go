@@ -0,0 +1,89 @@
+package details
+
+import (
+	"strings"
+
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// Index describes how the entries of a backup's details are split into
+// shards of a single resource owner and category.  Queries use the index
+// to read only the shards that can hold the items they select, instead of
+// loading the full details.
+type Index struct {
+	// SnapshotID is the ID of the stream store snapshot holding the shards.
+	// For chunked details it's the snapshot holding the details.
+	SnapshotID string       `json:"snapshotID"`
+	Shards     []IndexShard `json:"shards"`
+}
+
+// IndexShard describes the entries stored in a single shard.  Entries whose
+// RepoRef isn't a data layer path are stored in a shard without a resource
+// owner or category.
+type IndexShard struct {
+	Name          string `json:"name"`
+	ResourceOwner string `json:"resourceOwner,omitempty"`
+	Category      string `json:"category,omitempty"`
+	Entries       int    `json:"entries"`
+	// Chunks names the details chunks holding the shard's entries.  Shards
+	// of indexes made before details were chunked don't have any.
+	Chunks []string `json:"chunks,omitempty"`
+}
+
+type shardKey struct {
+	resourceOwner string
+	category      string
+}
+
+// keyFor returns the key of the shard holding the entry.  Entries whose
+// RepoRef isn't a data layer path have an empty key.
+func keyFor(de DetailsEntry) shardKey {
+	p, err := path.FromDataLayerPath(de.RepoRef, true)
+	if err != nil {
+		return shardKey{}
+	}
+
+	return shardKey{p.ResourceOwner(), p.Category().String()}
+}
+
+// Select returns the shards that can hold entries of the resource owners and
+// categories.  Resource owners match regardless of case, like selectors do.
+// No resource owners, or no categories, matches all of them, as does the
+// unknown category.  Shards without a resource owner always match, since
+// their entries can't be attributed.
+func (idx Index) Select(owners []string, cats []path.CategoryType) []IndexShard {
+	var (
+		ownerSet = map[string]struct{}{}
+		catSet   = map[string]struct{}{}
+		allCats  = len(cats) == 0
+		selected = []IndexShard{}
+	)
+
+	for _, o := range owners {
+		ownerSet[strings.ToLower(o)] = struct{}{}
+	}
+
+	for _, c := range cats {
+		if c == path.UnknownCategory {
+			allCats = true
+		}
+
+		catSet[c.String()] = struct{}{}
+	}
+
+	for _, s := range idx.Shards {
+		if len(s.ResourceOwner) > 0 {
+			if _, ok := ownerSet[strings.ToLower(s.ResourceOwner)]; len(ownerSet) > 0 && !ok {
+				continue
+			}
+
+			if _, ok := catSet[s.Category]; !allCats && !ok {
+				continue
+			}
+		}
+
+		selected = append(selected, s)
+	}
+
+	return selected
+}
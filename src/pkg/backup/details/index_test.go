@@ -0,0 +1,99 @@
+package details_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type IndexUnitSuite struct {
+	suite.Suite
+}
+
+func TestIndexUnitSuite(t *testing.T) {
+	suite.Run(t, new(IndexUnitSuite))
+}
+
+func exchangeEntry(t *testing.T, user string, cat path.CategoryType, item string) details.DetailsEntry {
+	p, err := path.Builder{}.
+		Append("Inbox", item).
+		ToDataLayerExchangePathForCategory("tenant", user, cat, true)
+	require.NoError(t, err)
+
+	return details.DetailsEntry{
+		RepoRef:  p.String(),
+		ShortRef: p.ShortRef(),
+		ItemInfo: details.ItemInfo{Exchange: &details.ExchangeInfo{Subject: item}},
+	}
+}
+
+func (suite *IndexUnitSuite) TestIndex_Select() {
+	idx := details.Index{
+		Shards: []details.IndexShard{
+			{Name: "unparsed"},
+			{Name: "a-email", ResourceOwner: "a", Category: "email"},
+			{Name: "a-events", ResourceOwner: "a", Category: "events"},
+			{Name: "b-email", ResourceOwner: "b", Category: "email"},
+		},
+	}
+
+	table := []struct {
+		name   string
+		owners []string
+		cats   []path.CategoryType
+		expect []string
+	}{
+		{
+			name:   "everything",
+			expect: []string{"unparsed", "a-email", "a-events", "b-email"},
+		},
+		{
+			name:   "owner",
+			owners: []string{"a"},
+			expect: []string{"unparsed", "a-email", "a-events"},
+		},
+		{
+			name:   "category",
+			cats:   []path.CategoryType{path.EmailCategory},
+			expect: []string{"unparsed", "a-email", "b-email"},
+		},
+		{
+			name:   "owner and category",
+			owners: []string{"b", "c"},
+			cats:   []path.CategoryType{path.EmailCategory, path.ContactsCategory},
+			expect: []string{"unparsed", "b-email"},
+		},
+		{
+			name:   "unknown category",
+			owners: []string{"a"},
+			cats:   []path.CategoryType{path.EmailCategory, path.UnknownCategory},
+			expect: []string{"unparsed", "a-email", "a-events"},
+		},
+		{
+			name:   "owner in another case",
+			owners: []string{"A"},
+			cats:   []path.CategoryType{path.EventsCategory},
+			expect: []string{"unparsed", "a-events"},
+		},
+		{
+			name:   "no matches",
+			owners: []string{"c"},
+			expect: []string{"unparsed"},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			names := []string{}
+			for _, s := range idx.Select(test.owners, test.cats) {
+				names = append(names, s.Name)
+			}
+
+			assert.Equal(t, test.expect, names)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package backup
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/cli/print"
+)
+
+// Estimate summarizes the data a backup would retrieve for a single
+// resource owner and category, as found by a dry run.  Items are compared
+// against the most recent backup of the same resource owner and category.
+type Estimate struct {
+	ResourceOwner string `json:"resourceOwner"`
+	Category      string `json:"category"`
+	Containers    int    `json:"containers"`
+	Items         int    `json:"items"`
+	// NewItems counts the items that aren't in the most recent backup.
+	NewItems int `json:"newItems"`
+	// Bytes and NewBytes are estimates.  Items that don't report their size
+	// before they're retrieved use their size in the most recent backup, or
+	// the average size of the other items in the category.
+	Bytes    int64 `json:"bytes"`
+	NewBytes int64 `json:"newBytes"`
+	// UnsizedItems counts the items whose size couldn't be estimated, and
+	// aren't included in Bytes.
+	UnsizedItems int `json:"unsizedItems"`
+}
+
+// interface compliance checks
+var _ print.Printable = Estimate{}
+
+// PrintEstimates writes the estimates to StdOut, in the format requested by
+// the caller.  Warns when some items' sizes couldn't be estimated.
+func PrintEstimates(ctx context.Context, es []Estimate) {
+	if len(es) == 0 {
+		print.Info(ctx, "No data to back up")
+		return
+	}
+
+	var (
+		ps      = make([]print.Printable, 0, len(es))
+		unsized int
+	)
+
+	for _, e := range es {
+		ps = append(ps, print.Printable(e))
+		unsized += e.UnsizedItems
+	}
+
+	print.All(ctx, ps...)
+
+	if unsized > 0 {
+		print.Infof(
+			ctx,
+			"The size of %d items can't be estimated, and isn't included in the sizes above.  "+
+				"Exchange items don't report their size until they're backed up, so their size "+
+				"can only be estimated from a previous backup.",
+			unsized)
+	}
+}
+
+// MinimumPrintable is a passthrough func, because no
+// reduction is needed for the json output.
+func (e Estimate) MinimumPrintable() any {
+	return e
+}
+
+// Headers returns the human-readable names of properties in an Estimate
+// for printing out to a terminal in a columnar display.
+func (e Estimate) Headers() []string {
+	return []string{
+		"Resource Owner",
+		"Category",
+		"Containers",
+		"Items",
+		"New Items",
+		"Size",
+		"New Size",
+		"Unsized Items",
+	}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (e Estimate) Values() []string {
+	return []string{
+		e.ResourceOwner,
+		e.Category,
+		strconv.Itoa(e.Containers),
+		strconv.Itoa(e.Items),
+		strconv.Itoa(e.NewItems),
+		e.size(e.Bytes),
+		e.size(e.NewBytes),
+		strconv.Itoa(e.UnsizedItems),
+	}
+}
+
+// size formats the estimated bytes, which are unknown if none of the
+// estimate's items could be sized.
+func (e Estimate) size(bytes int64) string {
+	if bytes == 0 && e.UnsizedItems > 0 {
+		return "unknown"
+	}
+
+	return humanize.Bytes(uint64(bytes))
+}
@@ -0,0 +1,83 @@
+package backup
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/common"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+// ItemMatch is an item found by searching across backups, along with the
+// backup that holds that version of the item.
+type ItemMatch struct {
+	BackupID        model.StableID `json:"backupID"`
+	BackupStartedAt time.Time      `json:"backupStartedAt"`
+	details.DetailsEntry
+}
+
+// interface compliance checks
+var _ print.Printable = ItemMatch{}
+
+// PrintItemMatches writes the matches to StdOut, in the format requested
+// by the caller.  The table output groups matches of the same kind of item,
+// since each kind has its own columns.
+func PrintItemMatches(ctx context.Context, ms []ItemMatch) {
+	if len(ms) == 0 {
+		print.Info(ctx, "No items match the search")
+		return
+	}
+
+	if print.JSONFormat() {
+		ps := make([]print.Printable, 0, len(ms))
+		for _, m := range ms {
+			ps = append(ps, print.Printable(m))
+		}
+
+		print.All(ctx, ps...)
+
+		return
+	}
+
+	var (
+		order  = []string{}
+		byKind = map[string][]print.Printable{}
+	)
+
+	for _, m := range ms {
+		kind := strings.Join(m.Headers(), ",")
+
+		if _, ok := byKind[kind]; !ok {
+			order = append(order, kind)
+		}
+
+		byKind[kind] = append(byKind[kind], print.Printable(m))
+	}
+
+	for _, kind := range order {
+		print.All(ctx, byKind[kind]...)
+	}
+}
+
+// MinimumPrintable is a passthrough func, because no
+// reduction is needed for the json output.
+func (m ItemMatch) MinimumPrintable() any {
+	return m
+}
+
+// Headers returns the human-readable names of properties in an ItemMatch
+// for printing out to a terminal in a columnar display.
+func (m ItemMatch) Headers() []string {
+	return append([]string{"Backup ID", "Backup Started At"}, m.DetailsEntry.Headers()...)
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (m ItemMatch) Values() []string {
+	return append(
+		[]string{string(m.BackupID), common.FormatTabularDisplayTime(m.BackupStartedAt)},
+		m.DetailsEntry.Values()...)
+}
@@ -0,0 +1,80 @@
+package backup
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/model"
+)
+
+// OwnerResult summarizes the backup of a single resource owner, when
+// backing up many resource owners at once.
+type OwnerResult struct {
+	ResourceOwner string         `json:"resourceOwner"`
+	BackupID      model.StableID `json:"backupID,omitempty"`
+	Status        string         `json:"status"`
+	ItemsWritten  int            `json:"itemsWritten"`
+	BytesUploaded int64          `json:"bytesUploaded"`
+	FailedItems   int            `json:"failedItems"`
+	Duration      time.Duration  `json:"duration"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// interface compliance checks
+var _ print.Printable = OwnerResult{}
+
+// PrintOwnerResults writes the results to StdOut, in the format requested
+// by the caller.
+func PrintOwnerResults(ctx context.Context, rs []OwnerResult) {
+	if len(rs) == 0 {
+		print.Info(ctx, "No backups were run")
+		return
+	}
+
+	ps := make([]print.Printable, 0, len(rs))
+	for _, r := range rs {
+		ps = append(ps, print.Printable(r))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable is a passthrough func, because no
+// reduction is needed for the json output.
+func (r OwnerResult) MinimumPrintable() any {
+	return r
+}
+
+// Headers returns the human-readable names of properties in an OwnerResult
+// for printing out to a terminal in a columnar display.
+func (r OwnerResult) Headers() []string {
+	return []string{
+		"Resource Owner",
+		"ID",
+		"Status",
+		"Items",
+		"Uploaded",
+		"Failed Items",
+		"Duration",
+		"Error",
+	}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (r OwnerResult) Values() []string {
+	return []string{
+		r.ResourceOwner,
+		string(r.BackupID),
+		r.Status,
+		strconv.Itoa(r.ItemsWritten),
+		humanize.Bytes(uint64(r.BytesUploaded)),
+		strconv.Itoa(r.FailedItems),
+		r.Duration.Round(time.Second).String(),
+		r.Error,
+	}
+}
@@ -0,0 +1,34 @@
+package control
+
+// ArchiveFormat describes how exported data is packaged on the local disk.
+type ArchiveFormat string
+
+const (
+	// NoArchive writes exported data as a plain directory tree.
+	NoArchive  ArchiveFormat = ""
+	ZipArchive ArchiveFormat = "zip"
+	TarArchive ArchiveFormat = "tar"
+)
+
+// MailFormat describes the file format of exported email.
+type MailFormat string
+
+const (
+	// EMLFormat writes each message to its own RFC 5322 (.eml) file.
+	EMLFormat MailFormat = "eml"
+	// MBOXFormat writes the messages in each folder to a single mbox file.
+	MBOXFormat MailFormat = "mbox"
+)
+
+// ExportDestination is a POD that describes where exported data is written
+// on the local disk.
+type ExportDestination struct {
+	// Path is the local directory that receives the exported data.  If an
+	// Archive format is set, Path is the archive file to create instead.
+	Path string
+	// Archive packages the exported data into a single file.
+	Archive ArchiveFormat
+	// MailFormat selects the file format of exported email.  Defaults
+	// to EMLFormat.
+	MailFormat MailFormat
+}
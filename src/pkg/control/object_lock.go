@@ -0,0 +1,59 @@
+package control
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ObjectLockMode is the S3 Object Lock retention mode applied to the data
+// written to a repository.
+type ObjectLockMode string
+
+const (
+	// ObjectLockGovernance blocks deletes, except by users with the
+	// s3:BypassGovernanceRetention permission.
+	ObjectLockGovernance ObjectLockMode = "governance"
+	// ObjectLockCompliance blocks deletes by every user, including the
+	// bucket owner, until the retention period ends.
+	ObjectLockCompliance ObjectLockMode = "compliance"
+)
+
+// minObjectLockRetention is the shortest retention period kopia accepts.
+const minObjectLockRetention = 24 * time.Hour
+
+// ObjectLock configures S3 Object Lock for a repository.  The bucket must
+// have object lock enabled.  The zero value disables object lock.
+// Maintenance keeps every blob of the repository locked, including those
+// only used by deleted backups: in compliance mode no repository data ever
+// becomes deletable, so storage only grows.  Each maintenance run makes one
+// retention request per blob.
+type ObjectLock struct {
+	Mode      ObjectLockMode `json:"mode,omitempty"`
+	Retention time.Duration  `json:"retention,omitempty"`
+}
+
+// Enabled reports whether any object lock settings were provided.
+func (ol ObjectLock) Enabled() bool {
+	return len(ol.Mode) > 0 || ol.Retention != 0
+}
+
+// Validate returns an error if the object lock settings are incomplete or
+// unsupported.
+func (ol ObjectLock) Validate() error {
+	if !ol.Enabled() {
+		return nil
+	}
+
+	if ol.Mode != ObjectLockGovernance && ol.Mode != ObjectLockCompliance {
+		return errors.Errorf(
+			"object lock mode must be %s or %s, got %q",
+			ObjectLockGovernance, ObjectLockCompliance, ol.Mode)
+	}
+
+	if ol.Retention < minObjectLockRetention {
+		return errors.Errorf("object lock retention must be at least %s", minObjectLockRetention)
+	}
+
+	return nil
+}
@@ -6,6 +6,10 @@ import (
 
 const (
 	defaultRestoreLocation = "Corso_Restore_"
+
+	// Outlook expects at most 4 concurrent requests per mailbox.
+	defaultRestorePerResourceOwner = 4
+	defaultRestoreGlobal           = 16
 )
 
 // CollisionPolicy describes how the datalayer behaves in case of a collision.
@@ -21,16 +25,59 @@ const (
 
 // Options holds the optional configurations for a process
 type Options struct {
-	Collision      CollisionPolicy `json:"-"`
-	DisableMetrics bool            `json:"disableMetrics"`
-	FailFast       bool            `json:"failFast"`
+	// BackupParallelism is the max number of resource owners backed up at
+	// once when backing up many resource owners.  Defaults to one.
+	BackupParallelism int             `json:"backupParallelism"`
+	Collision         CollisionPolicy `json:"-"`
+	DisableMetrics    bool            `json:"disableMetrics"`
+	// FailFast stops the operation at the first item that fails.  Otherwise
+	// the operation makes a best effort, skipping and recording failed items.
+	FailFast           bool               `json:"failFast"`
+	RestoreParallelism RestoreParallelism `json:"restoreParallelism"`
+	// Tags are attached to the backups created by the process, so that the
+	// backups can be looked up by them later.
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // Defaults provides an Options with the default values set.
 func Defaults() Options {
 	return Options{
-		FailFast: true,
+		RestoreParallelism: RestoreParallelism{
+			PerResourceOwner: defaultRestorePerResourceOwner,
+			Global:           defaultRestoreGlobal,
+		},
+	}
+}
+
+// RestoreParallelism bounds the number of items restored concurrently.
+type RestoreParallelism struct {
+	// PerResourceOwner is the max number of items restored at once into a
+	// single user or site.
+	PerResourceOwner int `json:"perResourceOwner"`
+	// Global is the max number of items restored at once across all
+	// resource owners.
+	Global int `json:"global"`
+}
+
+// Limits returns the per resource owner and global limits, falling back
+// to the defaults for unset values.  The per resource owner limit never
+// exceeds the global limit.
+func (rp RestoreParallelism) Limits() (perResourceOwner, global int) {
+	perResourceOwner, global = rp.PerResourceOwner, rp.Global
+
+	if perResourceOwner <= 0 {
+		perResourceOwner = defaultRestorePerResourceOwner
+	}
+
+	if global <= 0 {
+		global = defaultRestoreGlobal
+	}
+
+	if perResourceOwner > global {
+		perResourceOwner = global
 	}
+
+	return perResourceOwner, global
 }
 
 // RestoreDestination is a POD that contains an override of the resource owner
@@ -42,12 +89,26 @@ type RestoreDestination struct {
 	// owner of the item.
 	ResourceOwnerOverride string
 	// ContainerName is the name of the root of the restored container hierarchy.
-	// This field must be populated for a restore.
+	// If it is not populated items are restored in place, back into the
+	// container they were originally backed up from.
 	ContainerName string
 }
 
+// InPlace returns true if items should be restored to their original
+// location instead of under a new root container.
+func (rd RestoreDestination) InPlace() bool {
+	return len(rd.ContainerName) == 0
+}
+
 func DefaultRestoreDestination(timeFormat common.TimeFormat) RestoreDestination {
 	return RestoreDestination{
 		ContainerName: defaultRestoreLocation + common.FormatNow(timeFormat),
 	}
 }
+
+// InPlaceRestoreDestination provides a RestoreDestination that writes items
+// back into their original container hierarchy.  Collisions with items that
+// still exist in that location are resolved using Options.Collision.
+func InPlaceRestoreDestination() RestoreDestination {
+	return RestoreDestination{}
+}
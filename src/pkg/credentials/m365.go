@@ -10,12 +10,21 @@ import (
 const (
 	AzureClientID     = "AZURE_CLIENT_ID"
 	AzureClientSecret = "AZURE_CLIENT_SECRET"
+
+	// AzureClientCertPath is the path to a PEM or PKCS#12 (PFX) file holding the
+	// certificate and private key registered for the azure app.
+	AzureClientCertPath     = "AZURE_CLIENT_CERTIFICATE_PATH"
+	AzureClientCertPassword = "AZURE_CLIENT_CERTIFICATE_PASSWORD"
 )
 
 // M365 aggregates m365 credentials from flag and env_var values.
+// The app authenticates with either a client secret or a certificate.
 type M365 struct {
 	AzureClientID     string
 	AzureClientSecret string
+
+	AzureClientCertPath     string
+	AzureClientCertPassword string // optional
 }
 
 // M365 is a helper for aggregating m365 secrets and credentials.
@@ -23,21 +32,26 @@ func GetM365() M365 {
 	// todo (rkeeprs): read from either corso config file or env vars.
 	// https://github.com/alcionai/corso/issues/120
 	return M365{
-		AzureClientID:     os.Getenv(AzureClientID),
-		AzureClientSecret: os.Getenv(AzureClientSecret),
+		AzureClientID:           os.Getenv(AzureClientID),
+		AzureClientSecret:       os.Getenv(AzureClientSecret),
+		AzureClientCertPath:     os.Getenv(AzureClientCertPath),
+		AzureClientCertPassword: os.Getenv(AzureClientCertPassword),
 	}
 }
 
+// UsesCertificate returns true if the app authenticates with a certificate.
+// A certificate takes precedence over a client secret when both are provided.
+func (c M365) UsesCertificate() bool {
+	return len(c.AzureClientCertPath) > 0
+}
+
 func (c M365) Validate() error {
-	check := map[string]string{
-		AzureClientID:     c.AzureClientID,
-		AzureClientSecret: c.AzureClientSecret,
+	if len(c.AzureClientID) == 0 {
+		return errors.Wrap(errMissingRequired, AzureClientID)
 	}
 
-	for k, v := range check {
-		if len(v) == 0 {
-			return errors.Wrap(errMissingRequired, k)
-		}
+	if !c.UsesCertificate() && len(c.AzureClientSecret) == 0 {
+		return errors.Wrap(errMissingRequired, AzureClientSecret+" or "+AzureClientCertPath)
 	}
 
 	return nil
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
-	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
 
 	"github.com/alcionai/corso/src/internal/events"
 	"github.com/alcionai/corso/src/internal/kopia"
@@ -36,6 +38,11 @@ type BackupGetter interface {
 		ctx context.Context,
 		backupID string,
 	) (*details.Details, *backup.Backup, error)
+	SelectedBackupDetails(
+		ctx context.Context,
+		backupID string,
+		sel selectors.Selector,
+	) (*details.Details, *backup.Backup, error)
 }
 
 type Repository interface {
@@ -45,13 +52,34 @@ type Repository interface {
 		ctx context.Context,
 		self selectors.Selector,
 	) (operations.BackupOperation, error)
+	NewMultiBackup(
+		ctx context.Context,
+		sels []selectors.Selector,
+	) operations.MultiBackupOperation
 	NewRestore(
 		ctx context.Context,
 		backupID string,
 		sel selectors.Selector,
 		dest control.RestoreDestination,
 	) (operations.RestoreOperation, error)
+	NewExport(
+		ctx context.Context,
+		backupID string,
+		sel selectors.Selector,
+		dest control.ExportDestination,
+	) (operations.ExportOperation, error)
 	DeleteBackup(ctx context.Context, id model.StableID) error
+	DiffBackups(ctx context.Context, fromID, toID string) (*details.Diff, error)
+	SearchBackups(ctx context.Context, sel selectors.Selector) ([]backup.ItemMatch, error)
+	UpdateBackupTags(
+		ctx context.Context,
+		id model.StableID,
+		set map[string]string,
+		remove []string,
+	) (*backup.Backup, error)
+	SetBackupHold(ctx context.Context, id model.StableID, reason, by string) (*backup.Backup, error)
+	ClearBackupHold(ctx context.Context, id model.StableID, reason, by string) (*backup.Backup, error)
+	ExtendRetention(ctx context.Context) (int, error)
 	BackupGetter
 }
 
@@ -78,7 +106,8 @@ func (r repository) GetID() string {
 //   - validate the m365 account & secrets
 //   - connect to the m365 account to ensure communication capability
 //   - validate the provider config & secrets
-//   - initialize the kopia repo with the provider
+//   - initialize the kopia repo with the provider, locking the repo data
+//     with the object lock settings, if any
 //   - store the configuration details
 //   - connect to the provider
 //   - return the connected repository
@@ -87,9 +116,10 @@ func Initialize(
 	acct account.Account,
 	s storage.Storage,
 	opts control.Options,
+	ol control.ObjectLock,
 ) (Repository, error) {
 	kopiaRef := kopia.NewConn(s)
-	if err := kopiaRef.Initialize(ctx); err != nil {
+	if err := kopiaRef.Initialize(ctx, ol); err != nil {
 		// replace common internal errors so that sdk users can check results with errors.Is()
 		if kopia.IsRepoAlreadyExistsError(err) {
 			return nil, ErrorRepoAlreadyExists
@@ -236,6 +266,23 @@ func (r repository) NewBackup(
 		r.Bus)
 }
 
+// NewMultiBackup generates a runner that backs up each of the resource
+// owners in sels, Opts.BackupParallelism of them at a time.  Each selector
+// must select a single resource owner.
+func (r repository) NewMultiBackup(
+	ctx context.Context,
+	sels []selectors.Selector,
+) operations.MultiBackupOperation {
+	return operations.NewMultiBackupOperation(
+		ctx,
+		r.Opts,
+		r.dataLayer,
+		store.NewKopiaStore(r.modelStore),
+		r.Account,
+		sels,
+		r.Bus)
+}
+
 // NewRestore generates a restoreOperation runner.
 func (r repository) NewRestore(
 	ctx context.Context,
@@ -255,6 +302,25 @@ func (r repository) NewRestore(
 		r.Bus)
 }
 
+// NewExport generates an exportOperation runner.
+func (r repository) NewExport(
+	ctx context.Context,
+	backupID string,
+	sel selectors.Selector,
+	dest control.ExportDestination,
+) (operations.ExportOperation, error) {
+	return operations.NewExportOperation(
+		ctx,
+		r.Opts,
+		r.dataLayer,
+		store.NewKopiaStore(r.modelStore),
+		r.Account,
+		model.StableID(backupID),
+		sel,
+		dest,
+		r.Bus)
+}
+
 // backups lists a backup by id
 func (r repository) Backup(ctx context.Context, id model.StableID) (*backup.Backup, error) {
 	sw := store.NewKopiaStore(r.modelStore)
@@ -288,7 +354,9 @@ func (r repository) BackupsByTag(ctx context.Context, fs ...store.FilterOption)
 	return sw.GetBackups(ctx, fs...)
 }
 
-// BackupDetails returns the specified backup details object
+// BackupDetails returns the specified backup details object, holding every
+// entry of the backup.  Use SelectedBackupDetails to read only the entries
+// of interest from large backups.
 func (r repository) BackupDetails(ctx context.Context, backupID string) (*details.Details, *backup.Backup, error) {
 	sw := store.NewKopiaStore(r.modelStore)
 
@@ -308,22 +376,389 @@ func (r repository) BackupDetails(ctx context.Context, backupID string) (*detail
 	return deets, b, nil
 }
 
+// SelectedBackupDetails returns the details entries of the backup that
+// match the selector.  The details are read one chunk at a time, keeping
+// only the entries the selector includes.  If the backup has a details
+// index, only the shards holding the resource owners and categories
+// included by the selector are read.
+func (r repository) SelectedBackupDetails(
+	ctx context.Context,
+	backupID string,
+	sel selectors.Selector,
+) (*details.Details, *backup.Backup, error) {
+	sw := store.NewKopiaStore(r.modelStore)
+
+	b, err := sw.GetBackup(ctx, model.StableID(backupID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deets, err := r.selectedDetails(ctx, b, sel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return deets, b, nil
+}
+
+// selectedDetails reads the details entries of the backup that match the
+// selector, reducing the details a chunk at a time.  Backups without an
+// index of the details' chunks have all of their chunks read.
+func (r repository) selectedDetails(
+	ctx context.Context,
+	b *backup.Backup,
+	sel selectors.Selector,
+) (*details.Details, error) {
+	ss := streamstore.New(r.dataLayer, r.Account.ID(), b.Selectors.PathService())
+
+	if !isChunked(b.DetailsIndex) {
+		return ss.ReduceBackupDetails(ctx, b.DetailsID, sel.Reduce)
+	}
+
+	shards, err := selectShards(*b.DetailsIndex, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := []string{}
+	for _, s := range shards {
+		chunks = append(chunks, s.Chunks...)
+	}
+
+	return ss.ReduceDetailsChunks(ctx, b.DetailsIndex.SnapshotID, chunks, sel.Reduce)
+}
+
+// isChunked reports whether the index names the details chunks of each of
+// its shards.  Shards indexed before details were chunked can't be read
+// alone.
+func isChunked(idx *details.Index) bool {
+	if idx == nil {
+		return false
+	}
+
+	for _, s := range idx.Shards {
+		if len(s.Chunks) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// selectShards returns the shards of the index that can hold the items
+// included by the selector.
+func selectShards(idx details.Index, sel selectors.Selector) ([]details.IndexShard, error) {
+	owners, err := sel.IncludedOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	cats, err := sel.PathCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	return idx.Select(owners, cats.Includes), nil
+}
+
+// DiffBackups compares the details of two backups of the same service,
+// and returns the items added, removed, modified, or moved between the
+// from backup and the to backup.  If both backups have an index of their
+// details' chunks, the backups are compared one resource owner and
+// category at a time, so only the entries of a single shard of each
+// backup are held in memory at once.
+func (r repository) DiffBackups(ctx context.Context, fromID, toID string) (*details.Diff, error) {
+	sw := store.NewKopiaStore(r.modelStore)
+
+	fromB, err := sw.GetBackup(ctx, model.StableID(fromID))
+	if err != nil {
+		return nil, err
+	}
+
+	toB, err := sw.GetBackup(ctx, model.StableID(toID))
+	if err != nil {
+		return nil, err
+	}
+
+	if fromB.Selectors.PathService() != toB.Selectors.PathService() {
+		return nil, errors.New("backups are of different services")
+	}
+
+	ss := streamstore.New(r.dataLayer, r.Account.ID(), fromB.Selectors.PathService())
+
+	if !isChunked(fromB.DetailsIndex) || !isChunked(toB.DetailsIndex) {
+		fromDeets, err := ss.ReadBackupDetails(ctx, fromB.DetailsID)
+		if err != nil {
+			return nil, err
+		}
+
+		toDeets, err := ss.ReadBackupDetails(ctx, toB.DetailsID)
+		if err != nil {
+			return nil, err
+		}
+
+		diff := details.DiffDetails(fromDeets.DetailsModel, toDeets.DetailsModel)
+
+		return &diff, nil
+	}
+
+	var (
+		fromChunks = chunksByShard(*fromB.DetailsIndex)
+		toChunks   = chunksByShard(*toB.DetailsIndex)
+		keys       = []string{}
+		diffs      = []details.Diff{}
+	)
+
+	for k := range fromChunks {
+		keys = append(keys, k)
+	}
+
+	for k := range toChunks {
+		if _, ok := fromChunks[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fromDeets, err := ss.ReadDetailsChunks(ctx, fromB.DetailsIndex.SnapshotID, fromChunks[k])
+		if err != nil {
+			return nil, err
+		}
+
+		toDeets, err := ss.ReadDetailsChunks(ctx, toB.DetailsIndex.SnapshotID, toChunks[k])
+		if err != nil {
+			return nil, err
+		}
+
+		diffs = append(diffs, details.DiffDetails(fromDeets.DetailsModel, toDeets.DetailsModel))
+	}
+
+	diff := details.MergeDiffs(diffs...)
+
+	return &diff, nil
+}
+
+// chunksByShard returns the chunks of each of the index's shards, keyed by
+// the shard's resource owner and category.
+func chunksByShard(idx details.Index) map[string][]string {
+	chunks := make(map[string][]string, len(idx.Shards))
+
+	for _, s := range idx.Shards {
+		k := s.ResourceOwner + "/" + s.Category
+		chunks[k] = append(chunks[k], s.Chunks...)
+	}
+
+	return chunks
+}
+
+// SearchBackups looks for the items matching the restore selector in every
+// backup of the selector's service.  Each version of a matching item is
+// returned along with the backup holding it, sorted by item, then by backup
+// start time.  Backups whose details can't be read are skipped, and the
+// error lists them.
+func (r repository) SearchBackups(
+	ctx context.Context,
+	sel selectors.Selector,
+) ([]backup.ItemMatch, error) {
+	sw := store.NewKopiaStore(r.modelStore)
+
+	bs, err := sw.GetBackups(ctx, store.Service(sel.PathService()))
+	if err != nil {
+		return nil, err
+	}
+
+	searchOwners, err := sel.IncludedOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		errs    *multierror.Error
+		matches = []backup.ItemMatch{}
+	)
+
+	for _, b := range bs {
+		if !mayHoldOwners(b.Selectors, searchOwners) {
+			continue
+		}
+
+		// backups with an index only read the shards that can match.
+		reduced, err := r.selectedDetails(ctx, b, sel)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, "reading details of backup "+string(b.ID)))
+			continue
+		}
+
+		for _, de := range reduced.Items() {
+			matches = append(matches, backup.ItemMatch{
+				BackupID:        b.ID,
+				BackupStartedAt: b.StartedAt,
+				DetailsEntry:    *de,
+			})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].RepoRef != matches[j].RepoRef {
+			return matches[i].RepoRef < matches[j].RepoRef
+		}
+
+		return matches[i].BackupStartedAt.Before(matches[j].BackupStartedAt)
+	})
+
+	return matches, errs.ErrorOrNil()
+}
+
+// mayHoldOwners reports whether a backup made with the selector could
+// hold data for any of the resource owners.  Resource owners match
+// regardless of case, like selectors do.  An empty set of resource owners
+// matches everything.
+func mayHoldOwners(sel selectors.Selector, owners []string) bool {
+	included, err := sel.IncludedOwners()
+	if err != nil {
+		return true
+	}
+
+	if len(owners) == 0 || len(included) == 0 {
+		return true
+	}
+
+	held := map[string]struct{}{}
+	for _, o := range included {
+		held[strings.ToLower(o)] = struct{}{}
+	}
+
+	for _, o := range owners {
+		if _, ok := held[strings.ToLower(o)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpdateBackupTags attaches the set tags to the backup, replacing the values
+// of any existing tags with the same keys, and removes the tags with the
+// remove keys.  Returns the updated backup.
+func (r repository) UpdateBackupTags(
+	ctx context.Context,
+	id model.StableID,
+	set map[string]string,
+	remove []string,
+) (*backup.Backup, error) {
+	sw := store.NewKopiaStore(r.modelStore)
+
+	b, err := sw.GetBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	b.UpdateUserTags(set, remove)
+
+	if err := sw.UpdateBackup(ctx, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// SetBackupHold places the backup under legal hold, which keeps it from
+// being deleted until the hold is cleared.  The reason and the person
+// setting the hold are kept in the backup's hold history.
+func (r repository) SetBackupHold(
+	ctx context.Context,
+	id model.StableID,
+	reason, by string,
+) (*backup.Backup, error) {
+	sw := store.NewKopiaStore(r.modelStore)
+
+	b, err := sw.GetBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	b.SetHold(reason, by, time.Now().UTC())
+
+	if err := sw.UpdateBackup(ctx, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ClearBackupHold releases the backup from legal hold.  The reason and the
+// person clearing the hold are kept in the backup's hold history.
+func (r repository) ClearBackupHold(
+	ctx context.Context,
+	id model.StableID,
+	reason, by string,
+) (*backup.Backup, error) {
+	sw := store.NewKopiaStore(r.modelStore)
+
+	b, err := sw.GetBackup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.ClearHold(reason, by, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	if err := sw.UpdateBackup(ctx, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
 // DeleteBackup removes the backup from both the model store and the backup storage.
+// Backups under legal hold are not deleted.
 func (r repository) DeleteBackup(ctx context.Context, id model.StableID) error {
 	bu, err := r.Backup(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	if bu.OnHold() {
+		return backup.ErrLegalHold
+	}
+
 	if err := r.dataLayer.DeleteSnapshot(ctx, bu.SnapshotID); err != nil {
 		return err
 	}
 
+	// indexes of chunked details are stored in the details' snapshot.
+	if bu.DetailsIndex != nil && bu.DetailsIndex.SnapshotID != bu.DetailsID {
+		if err := r.dataLayer.DeleteSnapshot(ctx, bu.DetailsIndex.SnapshotID); err != nil {
+			return err
+		}
+	}
+
+	// checkpoints are only left if they failed to be deleted once the
+	// backup completed.
+	if err := r.dataLayer.DeleteCheckpoints(ctx, string(id)); err != nil {
+		return err
+	}
+
 	sw := store.NewKopiaStore(r.modelStore)
 
 	return sw.DeleteBackup(ctx, id)
 }
 
+// ExtendRetention locks the repository's data for another full object lock
+// retention period, starting now.  Data is only locked for the retention
+// period following its write, so repositories initialized with object lock
+// must run this more often than the retention period to keep the data that's
+// still in use locked.  The data of deleted backups is locked as well, and
+// so never becomes deletable in compliance mode.  Returns the number of
+// blobs whose retention was extended.  Repositories without object lock are
+// left unchanged.
+func (r repository) ExtendRetention(ctx context.Context) (int, error) {
+	return r.dataLayer.ExtendRetention(ctx)
+}
+
 // ---------------------------------------------------------------------------
 // Repository ID Model
 // ---------------------------------------------------------------------------
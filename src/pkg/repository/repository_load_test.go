@@ -98,7 +98,7 @@ func initM365Repo(t *testing.T) (
 		FailFast:       true,
 	}
 
-	repo, err := repository.Initialize(ctx, ac, st, opts)
+	repo, err := repository.Initialize(ctx, ac, st, opts, control.ObjectLock{})
 	require.NoError(t, err)
 
 	return ctx, repo, ac, st
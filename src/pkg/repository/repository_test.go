@@ -50,7 +50,7 @@ func (suite *RepositorySuite) TestInitialize() {
 
 			st, err := test.storage()
 			assert.NoError(t, err)
-			_, err = repository.Initialize(ctx, test.account, st, control.Options{})
+			_, err = repository.Initialize(ctx, test.account, st, control.Options{}, control.ObjectLock{})
 			test.errCheck(t, err, "")
 		})
 	}
@@ -133,7 +133,7 @@ func (suite *RepositoryIntegrationSuite) TestInitialize() {
 	for _, test := range table {
 		suite.T().Run(test.name, func(t *testing.T) {
 			st := test.storage(t)
-			r, err := repository.Initialize(ctx, test.account, st, control.Options{})
+			r, err := repository.Initialize(ctx, test.account, st, control.Options{}, control.ObjectLock{})
 			if err == nil {
 				defer func() {
 					assert.NoError(t, r.Close(ctx))
@@ -154,7 +154,7 @@ func (suite *RepositoryIntegrationSuite) TestConnect() {
 	// need to initialize the repository before we can test connecting to it.
 	st := tester.NewPrefixedS3Storage(t)
 
-	_, err := repository.Initialize(ctx, account.Account{}, st, control.Options{})
+	_, err := repository.Initialize(ctx, account.Account{}, st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 
 	// now re-connect
@@ -171,7 +171,7 @@ func (suite *RepositoryIntegrationSuite) TestConnect_sameID() {
 	// need to initialize the repository before we can test connecting to it.
 	st := tester.NewPrefixedS3Storage(t)
 
-	r, err := repository.Initialize(ctx, account.Account{}, st, control.Options{})
+	r, err := repository.Initialize(ctx, account.Account{}, st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 
 	oldID := r.GetID()
@@ -195,7 +195,7 @@ func (suite *RepositoryIntegrationSuite) TestNewBackup() {
 	// need to initialize the repository before we can test connecting to it.
 	st := tester.NewPrefixedS3Storage(t)
 
-	r, err := repository.Initialize(ctx, acct, st, control.Options{})
+	r, err := repository.Initialize(ctx, acct, st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 
 	bo, err := r.NewBackup(ctx, selectors.Selector{})
@@ -215,7 +215,7 @@ func (suite *RepositoryIntegrationSuite) TestNewRestore() {
 	// need to initialize the repository before we can test connecting to it.
 	st := tester.NewPrefixedS3Storage(t)
 
-	r, err := repository.Initialize(ctx, acct, st, control.Options{})
+	r, err := repository.Initialize(ctx, acct, st, control.Options{}, control.ObjectLock{})
 	require.NoError(t, err)
 
 	ro, err := r.NewRestore(ctx, "backup-id", selectors.Selector{}, dest)
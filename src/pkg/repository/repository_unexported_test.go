@@ -2,13 +2,21 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
 type RepositoryModelSuite struct {
@@ -44,7 +52,7 @@ func (suite *RepositoryModelSuite) TestWriteGetModel() {
 		kopiaRef = kopia.NewConn(s)
 	)
 
-	require.NoError(t, kopiaRef.Initialize(ctx))
+	require.NoError(t, kopiaRef.Initialize(ctx, control.ObjectLock{}))
 	require.NoError(t, kopiaRef.Connect(ctx))
 
 	defer kopiaRef.Close(ctx)
@@ -60,3 +68,175 @@ func (suite *RepositoryModelSuite) TestWriteGetModel() {
 	require.NoError(t, err)
 	assert.Equal(t, "fnords", string(got.ID))
 }
+
+func (suite *RepositoryModelSuite) TestDeleteBackup_legalHold() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	var (
+		t        = suite.T()
+		s        = tester.NewPrefixedS3Storage(t)
+		kopiaRef = kopia.NewConn(s)
+	)
+
+	require.NoError(t, kopiaRef.Initialize(ctx, control.ObjectLock{}))
+	require.NoError(t, kopiaRef.Connect(ctx))
+
+	defer kopiaRef.Close(ctx)
+
+	ms, err := kopia.NewModelStore(kopiaRef)
+	require.NoError(t, err)
+
+	defer ms.Close(ctx)
+
+	w, err := kopia.NewWrapper(kopiaRef)
+	require.NoError(t, err)
+
+	defer w.Close(ctx)
+
+	r := repository{dataLayer: w, modelStore: ms}
+
+	b := backup.New(
+		"snapID", "deetsID", "status",
+		model.StableID("held-backup"),
+		selectors.NewExchangeBackup().Selector,
+		stats.ReadWrites{},
+		stats.StartAndEndTime{},
+		nil,
+		nil)
+	b.SetHold("Case 2022-CV-0042", "counsel", time.Now().UTC())
+
+	require.NoError(t, ms.Put(ctx, model.BackupSchema, b))
+
+	err = r.DeleteBackup(ctx, b.ID)
+	assert.ErrorIs(t, err, backup.ErrLegalHold)
+
+	// the held backup is kept
+	got, err := r.Backup(ctx, b.ID)
+	require.NoError(t, err)
+	assert.True(t, got.OnHold())
+
+	// once the hold is cleared, the hold no longer blocks the deletion
+	_, err = r.ClearBackupHold(ctx, b.ID, "Case closed", "counsel")
+	require.NoError(t, err)
+
+	err = r.DeleteBackup(ctx, b.ID)
+	assert.NotErrorIs(t, err, backup.ErrLegalHold)
+}
+
+type RepositoryUnexportedUnitSuite struct {
+	suite.Suite
+}
+
+func TestRepositoryUnexportedUnitSuite(t *testing.T) {
+	suite.Run(t, new(RepositoryUnexportedUnitSuite))
+}
+
+func (suite *RepositoryUnexportedUnitSuite) TestMayHoldOwners() {
+	backupOf := func(owners ...string) selectors.Selector {
+		sel := selectors.NewExchangeBackup()
+		sel.Include(sel.Users(owners))
+
+		return sel.Selector
+	}
+
+	table := []struct {
+		name   string
+		sel    selectors.Selector
+		owners []string
+		expect assert.BoolAssertionFunc
+	}{
+		{"same owner", backupOf("a"), []string{"a"}, assert.True},
+		{"one of the owners", backupOf("a", "b"), []string{"c", "b"}, assert.True},
+		{"same owner in another case", backupOf("User@X.com"), []string{"user@x.com"}, assert.True},
+		{"other owner", backupOf("a"), []string{"b"}, assert.False},
+		{"any owner searched", backupOf("a"), nil, assert.True},
+		{"any owner backed up", backupOf(selectors.Any()...), []string{"b"}, assert.True},
+		{"any among the owners backed up", backupOf("a", selectors.AnyTgt), []string{"b"}, assert.True},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			test.expect(t, mayHoldOwners(test.sel, test.owners))
+		})
+	}
+}
+
+func (suite *RepositoryUnexportedUnitSuite) TestSelectShards() {
+	idx := details.Index{
+		Shards: []details.IndexShard{
+			{Name: "a-email", ResourceOwner: "a", Category: path.EmailCategory.String()},
+			{Name: "a-events", ResourceOwner: "a", Category: path.EventsCategory.String()},
+			{Name: "b-email", ResourceOwner: "b", Category: path.EmailCategory.String()},
+		},
+	}
+
+	table := []struct {
+		name   string
+		sel    func() selectors.Selector
+		expect []string
+	}{
+		{
+			name: "all data",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeRestore()
+				sel.Include(sel.Users(selectors.Any()))
+
+				return sel.Selector
+			},
+			expect: []string{"a-email", "a-events", "b-email"},
+		},
+		{
+			name: "one user",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeRestore()
+				sel.Include(sel.Users([]string{"a"}))
+
+				return sel.Selector
+			},
+			expect: []string{"a-email", "a-events"},
+		},
+		{
+			name: "one user and any user",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeRestore()
+				sel.Include(sel.Users([]string{"a", selectors.AnyTgt}))
+
+				return sel.Selector
+			},
+			expect: []string{"a-email", "a-events", "b-email"},
+		},
+		{
+			name: "mail of any user",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeRestore()
+				sel.Include(sel.MailFolders(selectors.Any(), selectors.Any()))
+
+				return sel.Selector
+			},
+			expect: []string{"a-email", "b-email"},
+		},
+		{
+			name: "events of one user",
+			sel: func() selectors.Selector {
+				sel := selectors.NewExchangeRestore()
+				sel.Include(sel.EventCalendars([]string{"a"}, selectors.Any()))
+
+				return sel.Selector
+			},
+			expect: []string{"a-events"},
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			shards, err := selectShards(idx, test.sel())
+			require.NoError(t, err)
+
+			names := []string{}
+			for _, s := range shards {
+				names = append(names, s.Name)
+			}
+
+			assert.Equal(t, test.expect, names)
+		})
+	}
+}
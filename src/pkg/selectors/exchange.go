@@ -3,6 +3,9 @@ package selectors
 import (
 	"context"
 	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/alcionai/corso/src/internal/common"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -101,6 +104,8 @@ func (s exchange) ResourceOwners() selectorResourceOwners {
 		Excludes: resourceOwnersIn(s.Excludes, ExchangeUser.String()),
 		Filters:  resourceOwnersIn(s.Filters, ExchangeUser.String()),
 		Includes: resourceOwnersIn(s.Includes, ExchangeUser.String()),
+
+		IncludesAny: anyResourceOwnerIn(s.Includes, ExchangeUser.String()),
 	}
 }
 
@@ -339,6 +344,36 @@ func (sr *ExchangeRestore) ContactName(senderID string) []ExchangeScope {
 	}
 }
 
+// ContactModifiedAfter produces an exchange contact modified-after filter scope.
+// Matches any contact which was last modified after the timestring.
+// In a backup selector, only contacts modified after the timestring are backed up.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *exchange) ContactModifiedAfter(timeStrings string) []ExchangeScope {
+	return []ExchangeScope{
+		makeFilterScope[ExchangeScope](
+			ExchangeContact,
+			ExchangeFilterContactModifiedAfter,
+			[]string{timeStrings},
+			wrapFilter(filters.Less)),
+	}
+}
+
+// ContactModifiedBefore produces an exchange contact modified-before filter scope.
+// Matches any contact which was last modified before the timestring.
+// In a backup selector, only contacts modified before the timestring are backed up.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *exchange) ContactModifiedBefore(timeStrings string) []ExchangeScope {
+	return []ExchangeScope{
+		makeFilterScope[ExchangeScope](
+			ExchangeContact,
+			ExchangeFilterContactModifiedBefore,
+			[]string{timeStrings},
+			wrapFilter(filters.Greater)),
+	}
+}
+
 // EventSubject produces one or more exchange event subject filter scopes.
 // Matches any event where the event subject contains one of the provided strings.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -371,9 +406,10 @@ func (sr *ExchangeRestore) EventRecurs(recurs string) []ExchangeScope {
 
 // EventStartsAfter produces an exchange event starts-after filter scope.
 // Matches any event where the start time is after the timestring.
+// In a backup selector, only events starting after the timestring are backed up.
 // If the input equals selectors.Any, the scope will match all times.
 // If the input is empty or selectors.None, the scope will always fail comparisons.
-func (sr *ExchangeRestore) EventStartsAfter(timeStrings string) []ExchangeScope {
+func (s *exchange) EventStartsAfter(timeStrings string) []ExchangeScope {
 	return []ExchangeScope{
 		makeFilterScope[ExchangeScope](
 			ExchangeEvent,
@@ -385,9 +421,10 @@ func (sr *ExchangeRestore) EventStartsAfter(timeStrings string) []ExchangeScope
 
 // EventStartsBefore produces an exchange event starts-before filter scope.
 // Matches any event where the start time is before the timestring.
+// In a backup selector, only events starting before the timestring are backed up.
 // If the input equals selectors.Any, the scope will match all times.
 // If the input is empty or selectors.None, the scope will always fail comparisons.
-func (sr *ExchangeRestore) EventStartsBefore(timeStrings string) []ExchangeScope {
+func (s *exchange) EventStartsBefore(timeStrings string) []ExchangeScope {
 	return []ExchangeScope{
 		makeFilterScope[ExchangeScope](
 			ExchangeEvent,
@@ -414,9 +451,10 @@ func (sr *ExchangeRestore) EventSubject(subject string) []ExchangeScope {
 
 // MailReceivedAfter produces an exchange mail received-after filter scope.
 // Matches any mail which was received after the timestring.
+// In a backup selector, only mail received after the timestring is backed up.
 // If the input equals selectors.Any, the scope will match all times.
 // If the input is empty or selectors.None, the scope will always fail comparisons.
-func (sr *ExchangeRestore) MailReceivedAfter(timeStrings string) []ExchangeScope {
+func (s *exchange) MailReceivedAfter(timeStrings string) []ExchangeScope {
 	return []ExchangeScope{
 		makeFilterScope[ExchangeScope](
 			ExchangeMail,
@@ -428,9 +466,10 @@ func (sr *ExchangeRestore) MailReceivedAfter(timeStrings string) []ExchangeScope
 
 // MailReceivedBefore produces an exchange mail received-before filter scope.
 // Matches any mail which was received before the timestring.
+// In a backup selector, only mail received before the timestring is backed up.
 // If the input equals selectors.Any, the scope will match all times.
 // If the input is empty or selectors.None, the scope will always fail comparisons.
-func (sr *ExchangeRestore) MailReceivedBefore(timeStrings string) []ExchangeScope {
+func (s *exchange) MailReceivedBefore(timeStrings string) []ExchangeScope {
 	return []ExchangeScope{
 		makeFilterScope[ExchangeScope](
 			ExchangeMail,
@@ -470,6 +509,56 @@ func (sr *ExchangeRestore) MailSubject(subject string) []ExchangeScope {
 	}
 }
 
+// TimeWindow returns the bounds that the selector's time filters place on
+// items in the category: the received time of mail, the start time of
+// events, and the last modified time of contacts.  Backups only retrieve the
+// items within those bounds.  A zero time leaves that end of the window open.
+func (s exchange) TimeWindow(cat path.CategoryType) (after, before time.Time, err error) {
+	var afterCat, beforeCat exchangeCategory
+
+	switch cat {
+	case path.EmailCategory:
+		afterCat, beforeCat = ExchangeFilterMailReceivedAfter, ExchangeFilterMailReceivedBefore
+	case path.EventsCategory:
+		afterCat, beforeCat = ExchangeFilterEventStartsAfter, ExchangeFilterEventStartsBefore
+	case path.ContactsCategory:
+		afterCat, beforeCat = ExchangeFilterContactModifiedAfter, ExchangeFilterContactModifiedBefore
+	default:
+		return after, before, nil
+	}
+
+	for _, sc := range s.Filters {
+		es := ExchangeScope(sc)
+
+		fc := es.FilterCategory()
+		if fc != afterCat && fc != beforeCat {
+			continue
+		}
+
+		// Any() and None() filters don't bound the window.
+		f := es[fc.String()]
+		if f.Comparator != filters.LessThan && f.Comparator != filters.GreaterThan {
+			continue
+		}
+
+		t, err := common.ParseTime(f.Target)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Wrapf(err, "parsing %s time", fc)
+		}
+
+		// items must pass every filter, so the narrowest bounds win.
+		if fc == afterCat && t.After(after) {
+			after = t
+		}
+
+		if fc == beforeCat && (before.IsZero() || t.Before(before)) {
+			before = t
+		}
+	}
+
+	return after, before, nil
+}
+
 // ---------------------------------------------------------------------------
 // Categories
 // ---------------------------------------------------------------------------
@@ -494,16 +583,18 @@ const (
 	// append new data cats here
 
 	// filterable topics identified by exchange
-	ExchangeFilterMailSender         exchangeCategory = "ExchangeFilterMailSender"
-	ExchangeFilterMailSubject        exchangeCategory = "ExchangeFilterMailSubject"
-	ExchangeFilterMailReceivedAfter  exchangeCategory = "ExchangeFilterMailReceivedAfter"
-	ExchangeFilterMailReceivedBefore exchangeCategory = "ExchangeFilterMailReceivedBefore"
-	ExchangeFilterContactName        exchangeCategory = "ExchangeFilterContactName"
-	ExchangeFilterEventOrganizer     exchangeCategory = "ExchangeFilterEventOrganizer"
-	ExchangeFilterEventRecurs        exchangeCategory = "ExchangeFilterEventRecurs"
-	ExchangeFilterEventStartsAfter   exchangeCategory = "ExchangeFilterEventStartsAfter"
-	ExchangeFilterEventStartsBefore  exchangeCategory = "ExchangeFilterEventStartsBefore"
-	ExchangeFilterEventSubject       exchangeCategory = "ExchangeFilterEventSubject"
+	ExchangeFilterMailSender            exchangeCategory = "ExchangeFilterMailSender"
+	ExchangeFilterMailSubject           exchangeCategory = "ExchangeFilterMailSubject"
+	ExchangeFilterMailReceivedAfter     exchangeCategory = "ExchangeFilterMailReceivedAfter"
+	ExchangeFilterMailReceivedBefore    exchangeCategory = "ExchangeFilterMailReceivedBefore"
+	ExchangeFilterContactName           exchangeCategory = "ExchangeFilterContactName"
+	ExchangeFilterContactModifiedAfter  exchangeCategory = "ExchangeFilterContactModifiedAfter"
+	ExchangeFilterContactModifiedBefore exchangeCategory = "ExchangeFilterContactModifiedBefore"
+	ExchangeFilterEventOrganizer        exchangeCategory = "ExchangeFilterEventOrganizer"
+	ExchangeFilterEventRecurs           exchangeCategory = "ExchangeFilterEventRecurs"
+	ExchangeFilterEventStartsAfter      exchangeCategory = "ExchangeFilterEventStartsAfter"
+	ExchangeFilterEventStartsBefore     exchangeCategory = "ExchangeFilterEventStartsBefore"
+	ExchangeFilterEventSubject          exchangeCategory = "ExchangeFilterEventSubject"
 	// append new filter cats here
 )
 
@@ -541,7 +632,8 @@ func (ec exchangeCategory) String() string {
 // Ex: ExchangeUser.leafCat() => ExchangeUser
 func (ec exchangeCategory) leafCat() categorizer {
 	switch ec {
-	case ExchangeContact, ExchangeContactFolder, ExchangeFilterContactName:
+	case ExchangeContact, ExchangeContactFolder, ExchangeFilterContactName,
+		ExchangeFilterContactModifiedAfter, ExchangeFilterContactModifiedBefore:
 		return ExchangeContact
 
 	case ExchangeEvent, ExchangeEventCalendar, ExchangeFilterEventOrganizer, ExchangeFilterEventRecurs,
@@ -746,6 +838,8 @@ func (s ExchangeScope) matchesInfo(dii details.ItemInfo) bool {
 	switch filterCat {
 	case ExchangeFilterContactName:
 		i = info.ContactName
+	case ExchangeFilterContactModifiedAfter, ExchangeFilterContactModifiedBefore:
+		i = common.FormatTime(info.Modified)
 	case ExchangeFilterEventOrganizer:
 		i = info.Organizer
 	case ExchangeFilterEventRecurs:
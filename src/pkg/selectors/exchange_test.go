@@ -685,6 +685,7 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 				Sender:      sender,
 				Subject:     subject,
 				Received:    now,
+				Modified:    now,
 			},
 		}
 	}
@@ -759,6 +760,30 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 		{"contact with a different name", details.ExchangeContact, es.ContactName("blarps"), assert.False},
 		{"contact with the same name", details.ExchangeContact, es.ContactName(name), assert.True},
 		{"contact with a subname search", details.ExchangeContact, es.ContactName(name[2:5]), assert.True},
+		{
+			"contact modified after the epoch",
+			details.ExchangeContact,
+			es.ContactModifiedAfter(common.FormatTime(epoch)),
+			assert.True,
+		},
+		{
+			"contact modified after sometime later",
+			details.ExchangeContact,
+			es.ContactModifiedAfter(common.FormatTime(future)),
+			assert.False,
+		},
+		{
+			"contact modified before the epoch",
+			details.ExchangeContact,
+			es.ContactModifiedBefore(common.FormatTime(epoch)),
+			assert.False,
+		},
+		{
+			"contact modified before sometime later",
+			details.ExchangeContact,
+			es.ContactModifiedBefore(common.FormatTime(future)),
+			assert.True,
+		},
 	}
 	for _, test := range table {
 		suite.T().Run(test.name, func(t *testing.T) {
@@ -770,6 +795,97 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 	}
 }
 
+func (suite *ExchangeSelectorSuite) TestExchangeBackup_TimeWindow() {
+	var (
+		early = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+		mid   = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+		late  = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	table := []struct {
+		name         string
+		filters      func(*ExchangeBackup) [][]ExchangeScope
+		cat          path.CategoryType
+		expectAfter  time.Time
+		expectBefore time.Time
+		expectErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no filters",
+			filters:   func(*ExchangeBackup) [][]ExchangeScope { return nil },
+			cat:       path.EmailCategory,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "mail window",
+			filters: func(eb *ExchangeBackup) [][]ExchangeScope {
+				return [][]ExchangeScope{
+					eb.MailReceivedAfter(common.FormatTime(early)),
+					eb.MailReceivedBefore(common.FormatTime(late)),
+				}
+			},
+			cat:          path.EmailCategory,
+			expectAfter:  early,
+			expectBefore: late,
+			expectErr:    assert.NoError,
+		},
+		{
+			name: "narrowest bounds win",
+			filters: func(eb *ExchangeBackup) [][]ExchangeScope {
+				return [][]ExchangeScope{
+					eb.EventStartsAfter(common.FormatTime(early)),
+					eb.EventStartsAfter(common.FormatTime(mid)),
+					eb.EventStartsBefore(common.FormatTime(late)),
+					eb.EventStartsBefore(common.FormatTime(mid)),
+				}
+			},
+			cat:          path.EventsCategory,
+			expectAfter:  mid,
+			expectBefore: mid,
+			expectErr:    assert.NoError,
+		},
+		{
+			name: "other categories ignored",
+			filters: func(eb *ExchangeBackup) [][]ExchangeScope {
+				return [][]ExchangeScope{
+					eb.MailReceivedAfter(common.FormatTime(early)),
+					eb.ContactModifiedBefore(common.FormatTime(late)),
+				}
+			},
+			cat:          path.ContactsCategory,
+			expectBefore: late,
+			expectErr:    assert.NoError,
+		},
+		{
+			name: "any time",
+			filters: func(eb *ExchangeBackup) [][]ExchangeScope {
+				return [][]ExchangeScope{eb.ContactModifiedAfter(AnyTgt)}
+			},
+			cat:       path.ContactsCategory,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "bad time",
+			filters: func(eb *ExchangeBackup) [][]ExchangeScope {
+				return [][]ExchangeScope{eb.MailReceivedAfter("not a time")}
+			},
+			cat:       path.EmailCategory,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			eb := NewExchangeBackup()
+			eb.Filter(test.filters(eb)...)
+
+			after, before, err := eb.TimeWindow(test.cat)
+			test.expectErr(t, err)
+			assert.True(t, test.expectAfter.Equal(after), "after: %v", after)
+			assert.True(t, test.expectBefore.Equal(before), "before: %v", before)
+		})
+	}
+}
+
 func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesPath() {
 	const (
 		usr  = "userID"
@@ -1435,6 +1551,8 @@ func (suite *ExchangeSelectorSuite) TestCategory_PathType() {
 		{ExchangeFilterMailReceivedAfter, path.EmailCategory},
 		{ExchangeFilterMailReceivedBefore, path.EmailCategory},
 		{ExchangeFilterContactName, path.ContactsCategory},
+		{ExchangeFilterContactModifiedAfter, path.ContactsCategory},
+		{ExchangeFilterContactModifiedBefore, path.ContactsCategory},
 		{ExchangeFilterEventOrganizer, path.EventsCategory},
 		{ExchangeFilterEventRecurs, path.EventsCategory},
 		{ExchangeFilterEventStartsAfter, path.EventsCategory},
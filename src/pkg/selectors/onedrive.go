@@ -100,6 +100,8 @@ func (s oneDrive) ResourceOwners() selectorResourceOwners {
 		Excludes: resourceOwnersIn(s.Excludes, OneDriveUser.String()),
 		Filters:  resourceOwnersIn(s.Filters, OneDriveUser.String()),
 		Includes: resourceOwnersIn(s.Includes, OneDriveUser.String()),
+
+		IncludesAny: anyResourceOwnerIn(s.Includes, OneDriveUser.String()),
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -78,6 +79,9 @@ type selectorResourceOwners struct {
 	Includes []string
 	Excludes []string
 	Filters  []string
+	// IncludesAny is true when an include scope targets every resource owner,
+	// since the Any values are left out of the Includes.
+	IncludesAny bool
 }
 
 type resourceOwnerer interface {
@@ -228,6 +232,24 @@ func (s Selector) ResourceOwners() (selectorResourceOwners, error) {
 	return ro.ResourceOwners(), nil
 }
 
+// IncludedOwners returns the discrete resource owners included by the
+// selector in sorted order, or nil if it includes every resource owner.
+func (s Selector) IncludedOwners() ([]string, error) {
+	ros, err := s.ResourceOwners()
+	if err != nil {
+		return nil, err
+	}
+
+	if ros.IncludesAny {
+		return nil, nil
+	}
+
+	owners := append([]string{}, ros.Includes...)
+	sort.Strings(owners)
+
+	return owners, nil
+}
+
 // returns the sets of path categories identified in each scope set.
 func (s Selector) PathCategories() (selectorPathCategories, error) {
 	ro, err := selectorAsIface[pathCategorier](s)
@@ -412,6 +434,19 @@ func resourceOwnersIn(s []scope, rootCat string) []string {
 	return rs
 }
 
+// reports whether any scope in the slice targets every resource owner.
+func anyResourceOwnerIn(s []scope, rootCat string) bool {
+	for _, sc := range s {
+		for _, v := range split(sc[rootCat].Target) {
+			if v == AnyTgt {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // produces the discrete set of path categories in the slice of scopes.
 func pathCategoriesIn[T scopeT, C categoryT](ss []scope) []path.CategoryType {
 	rm := map[path.CategoryType]struct{}{}
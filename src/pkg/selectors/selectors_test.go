@@ -209,6 +209,81 @@ func (suite *SelectorSuite) TestResourceOwnersIn() {
 	}
 }
 
+func (suite *SelectorSuite) TestAnyResourceOwnerIn() {
+	rootCat := rootCatStub.String()
+
+	table := []struct {
+		name   string
+		input  []scope
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "nil",
+			input:  nil,
+			expect: assert.False,
+		},
+		{
+			name:   "discrete",
+			input:  []scope{{rootCat: filters.Identity(join("foo", "bar"))}},
+			expect: assert.False,
+		},
+		{
+			name:   "with none",
+			input:  []scope{{rootCat: filters.Identity(join("foo", NoneTgt))}},
+			expect: assert.False,
+		},
+		{
+			name: "any in one of many scopes",
+			input: []scope{
+				{rootCat: filters.Identity(join("foo", "bar"))},
+				{rootCat: filters.Identity(join("baz", AnyTgt))},
+			},
+			expect: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			test.expect(t, anyResourceOwnerIn(test.input, rootCat))
+		})
+	}
+}
+
+func (suite *SelectorSuite) TestIncludedOwners() {
+	table := []struct {
+		name   string
+		sel    func() Selector
+		expect []string
+	}{
+		{
+			name: "discrete owners",
+			sel: func() Selector {
+				sel := NewExchangeBackup()
+				sel.Include(sel.Users([]string{"bob", "alice"}))
+
+				return sel.Selector
+			},
+			expect: []string{"alice", "bob"},
+		},
+		{
+			name: "any owner",
+			sel: func() Selector {
+				sel := NewExchangeBackup()
+				sel.Include(sel.Users([]string{"alice"}), sel.Users(Any()))
+
+				return sel.Selector
+			},
+			expect: nil,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			owners, err := test.sel().IncludedOwners()
+			assert.NoError(t, err)
+			assert.Equal(t, test.expect, owners)
+		})
+	}
+}
+
 func (suite *SelectorSuite) TestPathCategoriesIn() {
 	leafCat := leafCatStub.String()
 	f := filters.Identity(leafCat)
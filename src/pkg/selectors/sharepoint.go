@@ -98,6 +98,8 @@ func (s sharePoint) ResourceOwners() selectorResourceOwners {
 		Excludes: resourceOwnersIn(s.Excludes, SharePointSite.String()),
 		Filters:  resourceOwnersIn(s.Filters, SharePointSite.String()),
 		Includes: resourceOwnersIn(s.Includes, SharePointSite.String()),
+
+		IncludesAny: anyResourceOwnerIn(s.Includes, SharePointSite.String()),
 	}
 }
 
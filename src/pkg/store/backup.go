@@ -36,6 +36,14 @@ func Service(pst path.ServiceType) FilterOption {
 	}
 }
 
+// Tag ensures the retrieved backups have the user tag
+// with the specified key and value.
+func Tag(key, value string) FilterOption {
+	return func(qf *queryFilters) {
+		qf.tags[model.UserTagPrefix+key] = value
+	}
+}
+
 // GetBackup gets a single backup by id.
 func (w Wrapper) GetBackup(ctx context.Context, backupID model.StableID) (*backup.Backup, error) {
 	b := backup.Backup{}
@@ -77,6 +85,11 @@ func (w Wrapper) GetBackups(
 	return bs, nil
 }
 
+// UpdateBackup saves changes to the backup in the model store.
+func (w Wrapper) UpdateBackup(ctx context.Context, b *backup.Backup) error {
+	return errors.Wrap(w.Update(ctx, model.BackupSchema, b), "updating backup")
+}
+
 // DeleteBackup deletes the backup and its details entry from the model store.
 func (w Wrapper) DeleteBackup(ctx context.Context, backupID model.StableID) error {
 	return w.Delete(ctx, model.BackupSchema, backupID)
@@ -137,6 +137,39 @@ func (suite *StoreBackupUnitSuite) TestDeleteBackup() {
 	}
 }
 
+func (suite *StoreBackupUnitSuite) TestUpdateBackup() {
+	ctx, flush := tester.NewContext()
+	defer flush()
+
+	table := []struct {
+		name   string
+		mock   *storeMock.MockModelStore
+		expect assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "updates backup",
+			mock:   storeMock.NewMock(&bu, nil),
+			expect: assert.NoError,
+		},
+		{
+			name:   "errors",
+			mock:   storeMock.NewMock(&bu, assert.AnError),
+			expect: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.T().Run(test.name, func(t *testing.T) {
+			sm := &store.Wrapper{Storer: test.mock}
+
+			b := bu
+			b.UpdateUserTags(map[string]string{"ticket": "INC123"}, nil)
+
+			err := sm.UpdateBackup(ctx, &b)
+			test.expect(t, err)
+		})
+	}
+}
+
 func (suite *StoreBackupUnitSuite) TestGetDetailsIDFromBackupID() {
 	ctx, flush := tester.NewContext()
 	defer flush()